@@ -1152,3 +1152,118 @@ func TestUnpackRevert(t *testing.T) {
 		})
 	}
 }
+
+const customErrorsJSON = `[
+	{"type":"error","name":"Unauthorized","inputs":[]},
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+	{"type":"error","name":"Overloaded","inputs":[{"name":"","type":"uint256"}]},
+	{"type":"error","name":"Overloaded","inputs":[{"name":"","type":"address"}]}
+]`
+
+func TestErrorParsing(t *testing.T) {
+	abi, err := JSON(strings.NewReader(customErrorsJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(abi.Errors) != 4 {
+		t.Fatalf("error count mismatch: have %d, want 4", len(abi.Errors))
+	}
+	for name, sig := range map[string]string{
+		"Unauthorized":        "Unauthorized()",
+		"InsufficientBalance": "InsufficientBalance(uint256,uint256)",
+		"Overloaded":          "Overloaded(uint256)",
+		"Overloaded0":         "Overloaded(address)",
+	} {
+		errABI, ok := abi.Errors[name]
+		if !ok {
+			t.Fatalf("error %s missing", name)
+		}
+		if errABI.Sig != sig {
+			t.Errorf("error %s signature mismatch: have %s, want %s", name, errABI.Sig, sig)
+		}
+		if errABI.ID != crypto.Keccak256Hash([]byte(sig)) {
+			t.Errorf("error %s id mismatch", name)
+		}
+		var id [4]byte
+		copy(id[:], errABI.ID[:4])
+		found, err := abi.ErrorByID(id)
+		if err != nil {
+			t.Fatalf("failed to look up error %s: %v", name, err)
+		}
+		if found.Name != name {
+			t.Errorf("looked up wrong error: have %s, want %s", found.Name, name)
+		}
+	}
+	if got := abi.Errors["Overloaded0"].String(); got != "error Overloaded(address arg0)" {
+		t.Errorf("string representation mismatch: %s", got)
+	}
+	if _, err := abi.ErrorByID([4]byte{0xde, 0xad, 0xbe, 0xef}); err == nil {
+		t.Errorf("expected error looking up unknown selector")
+	}
+}
+
+func TestUnpackRevertError(t *testing.T) {
+	t.Parallel()
+
+	abi, err := JSON(strings.NewReader(customErrorsJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	insufficient := abi.Errors["InsufficientBalance"]
+	packed, err := insufficient.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases = []struct {
+		input     string
+		expect    string
+		expectErr string
+	}{
+		{"", "", "invalid data for unpacking"},
+		{"08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e00000000000000000000000000000000000000", "execution reverted: revert reason", ""},
+		{"4e487b710000000000000000000000000000000000000000000000000000000000000011", "execution reverted: panic: arithmetic underflow or overflow (0x11)", ""},
+		{"4e487b7100000000000000000000000000000000000000000000000000000000000000ff", "execution reverted: panic: unknown panic code (0xff)", ""},
+		{"82b42900", "execution reverted: Unauthorized()", ""},
+		{common.Bytes2Hex(append(insufficient.ID[:4], packed...)), "execution reverted: InsufficientBalance(1, 2)", ""},
+		{common.Bytes2Hex(insufficient.ID[:4]), "", "abi: attempting to unmarshall an empty string while arguments are expected"},
+		{"deadbeef", "", "no error with id: 0xdeadbeef"},
+	}
+	for index, c := range cases {
+		t.Run(fmt.Sprintf("case %d", index), func(t *testing.T) {
+			revert, err := abi.UnpackRevertError(common.Hex2Bytes(c.input))
+			if c.expectErr != "" {
+				if err == nil {
+					t.Fatalf("Expected non-nil error")
+				}
+				if err.Error() != c.expectErr {
+					t.Fatalf("Expected error mismatch, want %v, got %v", c.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if revert.Error() != c.expect {
+				t.Fatalf("Output mismatch, want %v, got %v", c.expect, revert.Error())
+			}
+			if revert.ErrorData() != "0x"+c.input {
+				t.Fatalf("Error data mismatch, want 0x%v, got %v", c.input, revert.ErrorData())
+			}
+		})
+	}
+	// Custom error arguments can be copied into typed structs
+	revert, err := abi.UnpackRevertError(append(insufficient.ID[:4], packed...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var args struct {
+		Available *big.Int
+		Required  *big.Int
+	}
+	if err := revert.Copy(&args); err != nil {
+		t.Fatalf("Failed to copy arguments: %v", err)
+	}
+	if args.Available.Cmp(big.NewInt(1)) != 0 || args.Required.Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("Argument mismatch: have %v, %v", args.Available, args.Required)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/simplechain-org/client"
 	"github.com/simplechain-org/client/accounts/abi"
 	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
 	"github.com/simplechain-org/client/core/types"
 	"github.com/simplechain-org/client/crypto"
 	"github.com/simplechain-org/client/event"
@@ -171,7 +172,10 @@ func (c *BoundContract) Call(opts *CallOpts, results *[]interface{}, method stri
 			return ErrNoPendingState
 		}
 		output, err = pb.PendingCallContract(ctx, msg)
-		if err == nil && len(output) == 0 {
+		if err != nil {
+			return c.revertError(err)
+		}
+		if len(output) == 0 {
 			// Make sure we have a contracts to operate on, and bail out otherwise.
 			if code, err = pb.PendingCodeAt(ctx, c.address); err != nil {
 				return err
@@ -182,7 +186,7 @@ func (c *BoundContract) Call(opts *CallOpts, results *[]interface{}, method stri
 	} else {
 		output, err = c.caller.CallContract(ctx, msg, opts.BlockNumber)
 		if err != nil {
-			return err
+			return c.revertError(err)
 		}
 		if len(output) == 0 {
 			// Make sure we have a contracts to operate on, and bail out otherwise.
@@ -302,7 +306,7 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 		msg := client.CallMsg{From: opts.From, To: contract, GasPrice: opts.GasPrice, GasTipCap: opts.GasTipCap, GasFeeCap: opts.GasFeeCap, Value: value, Data: input}
 		gasLimit, err = c.transactor.EstimateGas(ensureContext(opts.Context), msg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas needed: %v", err)
+			return nil, fmt.Errorf("failed to estimate gas needed: %w", c.revertError(err))
 		}
 	}
 	// Create the transaction, sign it and schedule it for execution
@@ -467,6 +471,31 @@ func (c *BoundContract) UnpackLogIntoMap(out map[string]interface{}, event strin
 	return abi.ParseTopicsIntoMap(out, indexed, log.Topics[1:])
 }
 
+// revertError resolves the revert data attached to the error of a failed contract
+// execution into an *abi.RevertError, decoding the custom errors of the contract.
+// The original error stays available through errors.As and errors.Is. Other
+// errors are returned unchanged.
+func (c *BoundContract) revertError(err error) error {
+	var dataErr interface{ ErrorData() interface{} }
+	if !errors.As(err, &dataErr) {
+		return err
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return err
+	}
+	revert, unpackErr := c.abi.UnpackRevertError(data)
+	if unpackErr != nil {
+		return err
+	}
+	revert.Err = err
+	return revert
+}
+
 // ensureContext is a helper method to ensure a context is not nil, even if the
 // user specified it as such.
 func ensureContext(ctx context.Context) context.Context {
@@ -18,12 +18,14 @@ package bind_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"reflect"
 	"strings"
 	"testing"
 
-	"github.com/simplechain-org/client"
+	ethereum "github.com/simplechain-org/client"
 	"github.com/simplechain-org/client/accounts/abi"
 	"github.com/simplechain-org/client/accounts/abi/bind"
 	"github.com/simplechain-org/client/common"
@@ -31,6 +33,7 @@ import (
 	"github.com/simplechain-org/client/core/types"
 	"github.com/simplechain-org/client/crypto"
 	"github.com/simplechain-org/client/rlp"
+	"github.com/simplechain-org/client/rpc"
 )
 
 type mockCaller struct {
@@ -255,3 +258,165 @@ func newMockLog(topics []common.Hash, txHash common.Hash) types.Log {
 		Removed:     false,
 	}
 }
+
+// revertError mimics the errors returned by the RPC API for reverted executions.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+type revertCaller struct {
+	mockCaller
+	err error
+}
+
+func (rc *revertCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, rc.err
+}
+
+func TestCallRevertError(t *testing.T) {
+	const abiString = `[{"inputs":[],"name":"something","outputs":[],"stateMutability":"view","type":"function"},{"inputs":[{"name":"needed","type":"uint256"},{"name":"available","type":"uint256"}],"name":"InsufficientBalance","type":"error"}]`
+	parsedAbi, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := parsedAbi.Errors["InsufficientBalance"].Inputs.Pack(big.NewInt(10), big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(crypto.Keccak256([]byte("InsufficientBalance(uint256,uint256)"))[:4], packed...)
+
+	mc := &revertCaller{err: &revertError{data: hexutil.Encode(data)}}
+	bc := bind.NewBoundContract(common.Address{}, parsedAbi, mc, nil, nil)
+
+	err = bc.Call(&bind.CallOpts{}, nil, "something")
+	revert, ok := err.(*abi.RevertError)
+	if !ok {
+		t.Fatalf("expected *abi.RevertError, got %T: %v", err, err)
+	}
+	if revert.Custom == nil || revert.Custom.Name != "InsufficientBalance" {
+		t.Fatalf("unexpected custom error: %v", revert.Custom)
+	}
+	var args struct {
+		Needed    *big.Int
+		Available *big.Int
+	}
+	if err := revert.Copy(&args); err != nil {
+		t.Fatalf("failed to copy error arguments: %v", err)
+	}
+	if args.Needed.Cmp(big.NewInt(10)) != 0 || args.Available.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("unexpected error arguments: %v, %v", args.Needed, args.Available)
+	}
+	if want := "execution reverted: InsufficientBalance(10, 3)"; err.Error() != want {
+		t.Fatalf("error message mismatch: have %q, want %q", err.Error(), want)
+	}
+	// The RPC error stays reachable for callers checking for it
+	if !errors.Is(err, mc.err) {
+		t.Errorf("original error not wrapped: %v", err)
+	}
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != 3 {
+		t.Errorf("error code mismatch: have %v", rpcErr)
+	}
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) || dataErr.ErrorData() != hexutil.Encode(data) {
+		t.Errorf("error data mismatch: have %v", dataErr)
+	}
+	// Errors carrying unknown revert data are returned unchanged
+	mc.err = &revertError{data: "0xdeadbeef"}
+	if err := bc.Call(&bind.CallOpts{}, nil, "something"); err != mc.err {
+		t.Fatalf("expected original error, got %v", err)
+	}
+}
+
+// bankABI is the ABI of a contract reverting with a custom error, which the
+// hand-written binding below mirrors.
+const bankABI = `[{"inputs":[],"name":"something","outputs":[],"stateMutability":"view","type":"function"},{"inputs":[{"name":"needed","type":"uint256"},{"name":"available","type":"uint256"}],"name":"InsufficientBalance","type":"error"}]`
+
+// bankUnpackInsufficientBalanceSource is the custom error helper generated for
+// bankABI, which must match BankInsufficientBalanceError and
+// UnpackBankInsufficientBalanceError below.
+const bankUnpackInsufficientBalanceSource = `// UnpackBankInsufficientBalanceError extracts a InsufficientBalance error of the
+// Bank contracts from the error of a failed call or transaction, reporting
+// whether the contracts reverted with it.
+//
+// Solidity: error InsufficientBalance(uint256 needed, uint256 available)
+func UnpackBankInsufficientBalanceError(err error) (*BankInsufficientBalanceError, bool) {
+	var revert *abi.RevertError
+	if !errors.As(err, &revert) || revert.Custom == nil || revert.Custom.ID != common.HexToHash("0xcf4791818fba6e019216eb4864093b4947f674afada5d305e57d598b641dad1d") {
+		return nil, false
+	}
+	custom := new(BankInsufficientBalanceError)
+	if err := revert.Copy(custom); err != nil {
+		return nil, false
+	}
+	return custom, true
+}`
+
+// BankInsufficientBalanceError is the binding generated for the custom error of bankABI.
+type BankInsufficientBalanceError struct {
+	Needed    *big.Int
+	Available *big.Int
+}
+
+func (e *BankInsufficientBalanceError) Error() string {
+	return fmt.Sprint("execution reverted: InsufficientBalance(", e.Needed, ", ", e.Available, ")")
+}
+
+// UnpackBankInsufficientBalanceError is the helper generated for the custom error
+// of bankABI, kept in sync with bankUnpackInsufficientBalanceSource.
+func UnpackBankInsufficientBalanceError(err error) (*BankInsufficientBalanceError, bool) {
+	var revert *abi.RevertError
+	if !errors.As(err, &revert) || revert.Custom == nil || revert.Custom.ID != common.HexToHash("0xcf4791818fba6e019216eb4864093b4947f674afada5d305e57d598b641dad1d") {
+		return nil, false
+	}
+	custom := new(BankInsufficientBalanceError)
+	if err := revert.Copy(custom); err != nil {
+		return nil, false
+	}
+	return custom, true
+}
+
+// Tests that the custom error helpers of the bindings extract the errors the
+// contract reverted with from call errors, even wrapped ones.
+func TestUnpackCustomError(t *testing.T) {
+	code, err := bind.Bind([]string{"Bank"}, []string{bankABI}, []string{""}, nil, "bindtest", bind.LangGo, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	if !strings.Contains(code, bankUnpackInsufficientBalanceSource) {
+		t.Fatalf("generated helper differs from the tested one:\n%s", code)
+	}
+	parsedAbi, err := abi.JSON(strings.NewReader(bankABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := parsedAbi.Errors["InsufficientBalance"].Inputs.Pack(big.NewInt(10), big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := append(crypto.Keccak256([]byte("InsufficientBalance(uint256,uint256)"))[:4], packed...)
+
+	mc := &revertCaller{err: &revertError{data: hexutil.Encode(data)}}
+	bc := bind.NewBoundContract(common.Address{}, parsedAbi, mc, nil, nil)
+
+	err = fmt.Errorf("failed to call: %w", bc.Call(&bind.CallOpts{}, nil, "something"))
+	custom, ok := UnpackBankInsufficientBalanceError(err)
+	if !ok || custom.Needed.Cmp(big.NewInt(10)) != 0 || custom.Available.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("custom error mismatch: have %v, %v", custom, ok)
+	}
+	if want := "execution reverted: InsufficientBalance(10, 3)"; custom.Error() != want {
+		t.Errorf("custom error message mismatch: have %q, want %q", custom.Error(), want)
+	}
+	// Errors not carrying the custom error are not extracted
+	mc.err = &revertError{data: "0xdeadbeef"}
+	if _, ok := UnpackBankInsufficientBalanceError(bc.Call(&bind.CallOpts{}, nil, "something")); ok {
+		t.Errorf("custom error extracted from unknown revert data")
+	}
+	if _, ok := UnpackBankInsufficientBalanceError(errors.New("execution reverted")); ok {
+		t.Errorf("custom error extracted from plain error")
+	}
+}
@@ -1856,6 +1856,79 @@ var bindTests = []struct {
 		nil,
 		nil,
 	},
+	// Test that custom errors are bound and extracted from revert errors
+	{
+		`CustomErrors`,
+		`
+		 pragma solidity ^0.8.4;
+
+		 contracts CustomErrors {
+			 error Unauthorized();
+			 error InsufficientBalance(uint256 available, uint256 required);
+			 error Overloaded(uint256);
+			 error Overloaded(address);
+		 }
+	   `,
+		[]string{``},
+		[]string{`[{"inputs":[{"internalType":"uint256","name":"available","type":"uint256"},{"internalType":"uint256","name":"required","type":"uint256"}],"name":"InsufficientBalance","type":"error"},{"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"Overloaded","type":"error"},{"inputs":[{"internalType":"address","name":"","type":"address"}],"name":"Overloaded","type":"error"},{"inputs":[],"name":"Unauthorized","type":"error"}]`},
+		`
+			"errors"
+			"fmt"
+			"math/big"
+			"strings"
+
+			"github.com/simplechain-org/client/accounts/abi"
+			"github.com/simplechain-org/client/common"
+		`,
+		`
+			parsed, err := abi.JSON(strings.NewReader(CustomErrorsABI))
+			if err != nil {
+				t.Fatalf("Failed to parse ABI: %v", err)
+			}
+			revert := func(sig string, args ...interface{}) error {
+				for _, e := range parsed.Errors {
+					if e.Sig != sig {
+						continue
+					}
+					packed, err := e.Inputs.Pack(args...)
+					if err != nil {
+						t.Fatalf("Failed to pack %s: %v", sig, err)
+					}
+					revert, err := parsed.UnpackRevertError(append(e.ID[:4], packed...))
+					if err != nil {
+						t.Fatalf("Failed to unpack %s: %v", sig, err)
+					}
+					return fmt.Errorf("failed to estimate gas needed: %w", revert)
+				}
+				t.Fatalf("Unknown error %s", sig)
+				return nil
+			}
+			err = revert("InsufficientBalance(uint256,uint256)", big.NewInt(1), big.NewInt(2))
+			if custom, ok := UnpackCustomErrorsInsufficientBalanceError(err); !ok || custom.Available.Int64() != 1 || custom.Required.Int64() != 2 {
+				t.Fatalf("InsufficientBalance mismatch: %v, %v", custom, ok)
+			}
+			if _, ok := UnpackCustomErrorsUnauthorizedError(err); ok {
+				t.Fatalf("Unauthorized extracted from InsufficientBalance")
+			}
+			if custom, ok := UnpackCustomErrorsUnauthorizedError(revert("Unauthorized()")); !ok || custom.Error() != "execution reverted: Unauthorized()" {
+				t.Fatalf("Unauthorized mismatch: %v, %v", custom, ok)
+			}
+			err = revert("Overloaded(address)", common.Address{0x01})
+			if _, ok := UnpackCustomErrorsOverloadedError(err); ok {
+				t.Fatalf("Overloaded(uint256) extracted from Overloaded(address)")
+			}
+			if custom, ok := UnpackCustomErrorsOverloaded0Error(err); !ok || custom.Arg0 != (common.Address{0x01}) {
+				t.Fatalf("Overloaded(address) mismatch: %v, %v", custom, ok)
+			}
+			if _, ok := UnpackCustomErrorsUnauthorizedError(errors.New("execution reverted")); ok {
+				t.Fatalf("Unauthorized extracted from plain error")
+			}
+		`,
+		nil,
+		nil,
+		nil,
+		nil,
+	},
 }
 
 // Tests that packages generated by the binder can be successfully compiled and
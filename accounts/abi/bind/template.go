@@ -38,6 +38,7 @@ type tmplContract struct {
 	Fallback    *tmplMethod            // Additional special fallback function
 	Receive     *tmplMethod            // Additional special receive function
 	Events      map[string]*tmplEvent  // Contract events accessors
+	Errors      map[string]*tmplError  // Contract custom errors
 	Libraries   map[string]string      // Same as tmplData, but filtered to only keep what the contracts needs
 	Library     bool                   // Indicator whether the contracts is a library
 }
@@ -57,6 +58,13 @@ type tmplEvent struct {
 	Normalized abi.Event // Normalized version of the parsed fields
 }
 
+// tmplError is a wrapper around an abi.Error that contains a few preprocessed
+// and cached data fields.
+type tmplError struct {
+	Original   abi.Error // Original error as parsed by the abi package
+	Normalized abi.Error // Normalized version of the parsed fields
+}
+
 // tmplField is a wrapper around a struct field with binding language
 // struct type definition and relative filed name.
 type tmplField struct {
@@ -91,6 +99,7 @@ import (
 	"math/big"
 	"strings"
 	"errors"
+	"fmt"
 
 	ethereum "github.com/simplechain-org/client"
 	"github.com/simplechain-org/client/accounts/abi"
@@ -103,6 +112,7 @@ import (
 // Reference imports to suppress errors if they are not otherwise used.
 var (
 	_ = errors.New
+	_ = fmt.Sprint
 	_ = big.NewInt
 	_ = strings.NewReader
 	_ = ethereum.NotFound
@@ -567,6 +577,36 @@ var (
 		}
 
  	{{end}}
+
+	{{range .Errors}}
+		// {{$contracts.Type}}{{.Normalized.Name}}Error represents a {{.Normalized.Name}} error raised by the {{$contracts.Type}} contracts.
+		type {{$contracts.Type}}{{.Normalized.Name}}Error struct { {{range .Normalized.Inputs}}
+			{{capitalise .Name}} {{bindtype .Type $structs}}; {{end}}
+		}
+
+		// Error implements the error interface, formatting the error like the
+		// abi.RevertError it was decoded from.
+		func (e *{{$contracts.Type}}{{.Normalized.Name}}Error) Error() string {
+			return fmt.Sprint("execution reverted: {{.Original.RawName}}(", {{range $i, $_ := .Normalized.Inputs}}{{if $i}}", ", {{end}}e.{{capitalise .Name}}, {{end}}")")
+		}
+
+		// Unpack{{$contracts.Type}}{{.Normalized.Name}}Error extracts a {{.Normalized.Name}} error of the
+		// {{$contracts.Type}} contracts from the error of a failed call or transaction, reporting
+		// whether the contracts reverted with it.
+		//
+		// Solidity: {{.Original.String}}
+		func Unpack{{$contracts.Type}}{{.Normalized.Name}}Error(err error) (*{{$contracts.Type}}{{.Normalized.Name}}Error, bool) {
+			var revert *abi.RevertError
+			if !errors.As(err, &revert) || revert.Custom == nil || revert.Custom.ID != common.HexToHash("{{.Original.ID.Hex}}") {
+				return nil, false
+			}
+			custom := new({{$contracts.Type}}{{.Normalized.Name}}Error)
+			if err := revert.Copy(custom); err != nil {
+				return nil, false
+			}
+			return custom, true
+		}
+	{{end}}
 {{end}}
 `
 
@@ -17,11 +17,85 @@
 package abi
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/crypto"
 )
 
+// Error is a custom error declared by a contract, introduced in solidity v0.8.4.
+// Contracts revert with custom errors by returning their arguments abi-encoded as
+// if they were a call to a function with the error's signature.
+type Error struct {
+	// Name is the error name used for internal representation. It's derived from
+	// the raw name and a suffix will be added in the case of an error overload.
+	Name string
+	// RawName is the raw error name parsed from ABI.
+	RawName string
+	Inputs  Arguments
+	str     string
+	// Sig contains the string signature according to the ABI spec.
+	// e.g.	 error foo(uint32 a, int b) = "foo(uint32,int256)"
+	Sig string
+	// ID returns the canonical representation of the error's signature, the
+	// first four bytes of which select the error in revert data.
+	ID common.Hash
+}
+
+// NewError creates a new Error.
+// It sanitizes the input arguments to remove unnamed arguments.
+// It also precomputes the id, signature and string representation
+// of the error.
+func NewError(name, rawName string, inputs Arguments) Error {
+	names := make([]string, len(inputs))
+	types := make([]string, len(inputs))
+	for i, input := range inputs {
+		if input.Name == "" {
+			inputs[i] = Argument{
+				Name: fmt.Sprintf("arg%d", i),
+				Type: input.Type,
+			}
+		} else {
+			inputs[i] = input
+		}
+		names[i] = fmt.Sprintf("%v %v", input.Type, inputs[i].Name)
+		types[i] = input.Type.String()
+	}
+
+	str := fmt.Sprintf("error %v(%v)", rawName, strings.Join(names, ", "))
+	sig := fmt.Sprintf("%v(%v)", rawName, strings.Join(types, ","))
+	id := common.BytesToHash(crypto.Keccak256([]byte(sig)))
+
+	return Error{
+		Name:    name,
+		RawName: rawName,
+		Inputs:  inputs,
+		str:     str,
+		Sig:     sig,
+		ID:      id,
+	}
+}
+
+func (e Error) String() string {
+	return e.str
+}
+
+// Unpack decodes the arguments of the error from revert data, which must start
+// with the error's selector.
+func (e *Error) Unpack(data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, errors.New("invalid data for unpacking")
+	}
+	if !bytes.Equal(data[:4], e.ID[:4]) {
+		return nil, fmt.Errorf("abi: revert data selector %#x doesn't match error %v", data[:4], e.Sig)
+	}
+	return e.Inputs.Unpack(data[4:])
+}
+
 var (
 	errBadBool = errors.New("abi: improperly encoded boolean value")
 )
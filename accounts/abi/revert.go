@@ -0,0 +1,170 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/crypto"
+)
+
+// Panic codes of the checks the solidity compiler inserts, reported through
+// Panic(uint256) reverts since v0.8.0.
+const (
+	PanicGeneric               = 0x00 // Generic compiler inserted panic
+	PanicAssert                = 0x01 // Failed assert
+	PanicArithmetic            = 0x11 // Arithmetic underflow or overflow outside of unchecked blocks
+	PanicDivisionByZero        = 0x12 // Division or modulo by zero
+	PanicEnumConversion        = 0x21 // Conversion of a too big or negative value into an enum
+	PanicStorageEncoding       = 0x22 // Access to an incorrectly encoded storage byte array
+	PanicEmptyArrayPop         = 0x31 // Pop on an empty array
+	PanicArrayOutOfBounds      = 0x32 // Out-of-bounds index into an array, bytesN or array slice
+	PanicOutOfMemory           = 0x41 // Allocation of too much memory or too large an array
+	PanicUninitializedFunction = 0x51 // Call of a zero-initialized internal function variable
+)
+
+var (
+	// panicSelector is the function selector of solidity panics.
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+	panicReasons = map[uint64]string{
+		PanicGeneric:               "generic panic",
+		PanicAssert:                "assert(false)",
+		PanicArithmetic:            "arithmetic underflow or overflow",
+		PanicDivisionByZero:        "division or modulo by zero",
+		PanicEnumConversion:        "enum overflow",
+		PanicStorageEncoding:       "invalid encoded storage byte array accessed",
+		PanicEmptyArrayPop:         "out-of-bounds array access; popping on an empty array",
+		PanicArrayOutOfBounds:      "out-of-bounds access of an array or bytesN",
+		PanicOutOfMemory:           "out of memory",
+		PanicUninitializedFunction: "uninitialized function",
+	}
+)
+
+// PanicReason returns the description of a solidity panic code.
+func PanicReason(code *big.Int) string {
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+	return "unknown panic code"
+}
+
+// RevertError is the decoded revert data of a failed contract execution. Panic is
+// set if the contract panicked, Custom if it reverted with one of the custom
+// errors of its ABI, and Reason otherwise.
+type RevertError struct {
+	Reason string        // Reason given to require or revert, for Error(string) reverts
+	Panic  *big.Int      // Panic code, for Panic(uint256) reverts
+	Custom *Error        // Definition of the custom error reverted with
+	Args   []interface{} // Decoded arguments of the custom error
+	Data   []byte        // Raw revert data
+	Err    error         // Error the revert data was decoded from, if any
+}
+
+// revertErrorCode is the JSON-RPC error code of reverted executions.
+const revertErrorCode = 3
+
+func (e *RevertError) Error() string {
+	switch {
+	case e.Custom != nil:
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprintf("%v", arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.Custom.RawName, strings.Join(args, ", "))
+	case e.Panic != nil:
+		return fmt.Sprintf("execution reverted: panic: %s (%#x)", PanicReason(e.Panic), e.Panic)
+	default:
+		return "execution reverted: " + e.Reason
+	}
+}
+
+// Unwrap returns the error the revert data was decoded from, such as the error
+// response of the RPC API.
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the JSON-RPC error code of the error the revert data was
+// decoded from, or the code of reverted executions if it carries none.
+func (e *RevertError) ErrorCode() int {
+	var coded interface{ ErrorCode() int }
+	if errors.As(e.Err, &coded) {
+		return coded.ErrorCode()
+	}
+	return revertErrorCode
+}
+
+// ErrorData returns the hex encoded revert data, like the errors returned by the
+// RPC API for failed executions do.
+func (e *RevertError) ErrorData() interface{} {
+	return fmt.Sprintf("%#x", e.Data)
+}
+
+// Copy copies the arguments of a custom error into v, which must be a pointer to
+// a struct with a field for each argument, or to the type of the single argument.
+func (e *RevertError) Copy(v interface{}) error {
+	if e.Custom == nil {
+		return errors.New("abi: not reverted with a custom error")
+	}
+	return e.Custom.Inputs.Copy(v, e.Args)
+}
+
+// UnpackRevertError decodes the revert data of a failed contract execution. Besides
+// the Error(string) reasons UnpackRevert understands, it resolves the Panic(uint256)
+// codes of failed compiler checks and the custom errors declared in the ABI.
+func (abi ABI) UnpackRevertError(data []byte) (*RevertError, error) {
+	if len(data) < 4 {
+		return nil, errors.New("invalid data for unpacking")
+	}
+	data = common.CopyBytes(data)
+
+	switch {
+	case bytes.Equal(data[:4], revertSelector):
+		reason, err := UnpackRevert(data)
+		if err != nil {
+			return nil, err
+		}
+		return &RevertError{Reason: reason, Data: data}, nil
+
+	case bytes.Equal(data[:4], panicSelector):
+		typ, _ := NewType("uint256", "", nil)
+		unpacked, err := (Arguments{{Type: typ}}).Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		return &RevertError{Panic: unpacked[0].(*big.Int), Data: data}, nil
+	}
+	var id [4]byte
+	copy(id[:], data)
+	errABI, err := abi.ErrorByID(id)
+	if err != nil {
+		return nil, err
+	}
+	args, err := errABI.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	return &RevertError{Custom: errABI, Args: args, Data: data}, nil
+}
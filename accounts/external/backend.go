@@ -24,6 +24,7 @@ import (
 
 	"github.com/simplechain-org/client"
 	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/typeddata"
 	"github.com/simplechain-org/client/common"
 	"github.com/simplechain-org/client/common/hexutil"
 	"github.com/simplechain-org/client/core/types"
@@ -156,8 +157,12 @@ func (api *ExternalSigner) signHash(account accounts.Account, hash []byte) ([]by
 	return []byte{}, fmt.Errorf("operation not supported on external signers")
 }
 
-// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed
+// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
+// Typed data must be given in its JSON representation, so the signer can display it.
 func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	if mimeType == accounts.MimetypeTypedData && typeddata.IsPayload(data) {
+		return nil, fmt.Errorf("external signers require typed data in JSON form")
+	}
 	var res hexutil.Bytes
 	var signAddress = common.NewMixedcaseAddress(account.Address)
 	if err := api.client.Call(&res, "account_signData",
@@ -166,9 +171,9 @@ func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, d
 		hexutil.Encode(data)); err != nil {
 		return nil, err
 	}
-	// If V is on 27/28-form, convert to 0/1 for Clique
-	if mimeType == accounts.MimetypeClique && (res[64] == 27 || res[64] == 28) {
-		res[64] -= 27 // Transform V from 27/28 to 0/1 for Clique use
+	// If V is on 27/28-form, convert to 0/1 for Clique and typed data
+	if (mimeType == accounts.MimetypeClique || mimeType == accounts.MimetypeTypedData) && (res[64] == 27 || res[64] == 28) {
+		res[64] -= 27 // Transform V from 27/28 to 0/1
 	}
 	return res, nil
 }
@@ -0,0 +1,152 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hdwallet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/keystore"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/event"
+	"github.com/simplechain-org/client/log"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// ErrWalletExists is returned if a mnemonic is imported whose wallet is already
+// stored in the backend directory.
+var ErrWalletExists = errors.New("wallet already exists")
+
+// Backend is an accounts.Backend managing HD wallets stored encrypted in a
+// directory, one file per seed.
+type Backend struct {
+	dir     string // Directory storing the wallet files
+	scryptN int    // Scrypt N parameter to encrypt seeds with
+	scryptP int    // Scrypt P parameter to encrypt seeds with
+
+	wallets     []accounts.Wallet       // Wallets loaded from the directory, sorted by URL
+	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
+	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
+	lock        sync.RWMutex            // Protects the wallet list
+}
+
+// NewBackend creates a backend for the HD wallets stored in the given directory,
+// encrypting imported seeds with the given scrypt parameters. Files which can't
+// be loaded are skipped.
+func NewBackend(dir string, scryptN, scryptP int) (*Backend, error) {
+	b := &Backend{dir: dir, scryptN: scryptN, scryptP: scryptP}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		wallet, err := loadWallet(filepath.Join(dir, name))
+		if err != nil {
+			log.Warn("Failed to load HD wallet", "file", name, "err", err)
+			continue
+		}
+		b.wallets = append(b.wallets, wallet)
+	}
+	sort.Slice(b.wallets, func(i, j int) bool {
+		return b.wallets[i].URL().Cmp(b.wallets[j].URL()) < 0
+	})
+	return b, nil
+}
+
+// Wallets implements accounts.Backend, returning all the HD wallets loaded from
+// the backend directory.
+func (b *Backend) Wallets() []accounts.Wallet {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	cpy := make([]accounts.Wallet, len(b.wallets))
+	copy(cpy, b.wallets)
+	return cpy
+}
+
+// Subscribe implements accounts.Backend, creating an async subscription to
+// receive notifications on the addition of HD wallets.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return b.updateScope.Track(b.updateFeed.Subscribe(sink))
+}
+
+// Import stores the seed of a BIP-39 mnemonic and passphrase in a new wallet
+// file, encrypted with the given password. The returned wallet is closed.
+func (b *Backend) Import(mnemonic, passphrase, password string) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range seed {
+			seed[i] = 0
+		}
+	}()
+	master, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	id, err := masterID(master)
+	master.zero()
+	if err != nil {
+		return nil, err
+	}
+	crypted, err := keystore.EncryptDataV3(seed, []byte(password), b.scryptN, b.scryptP)
+	if err != nil {
+		return nil, err
+	}
+	file := filepath.Join(b.dir, "hd-"+id+".json")
+
+	b.lock.Lock()
+	if _, err := os.Stat(file); err == nil {
+		b.lock.Unlock()
+		return nil, ErrWalletExists
+	}
+	wallet := &Wallet{
+		url:   accounts.URL{Scheme: Scheme, Path: file},
+		file:  file,
+		seed:  &crypted,
+		paths: make(map[common.Address]accounts.DerivationPath),
+	}
+	if err := wallet.store(); err != nil {
+		b.lock.Unlock()
+		return nil, err
+	}
+	b.wallets = append(b.wallets, wallet)
+	sort.Slice(b.wallets, func(i, j int) bool {
+		return b.wallets[i].URL().Cmp(b.wallets[j].URL()) < 0
+	})
+	b.lock.Unlock()
+
+	b.updateFeed.Send(accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletArrived})
+	return wallet, nil
+}
+
+// Close terminates all the subscriptions of the backend.
+func (b *Backend) Close() {
+	b.updateScope.Close()
+}
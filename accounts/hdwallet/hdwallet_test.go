@@ -0,0 +1,231 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hdwallet
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/keystore"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+)
+
+const testMnemonic = "test test test test test test test test test test test junk"
+
+var testAddrs = []common.Address{
+	common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+	common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+	common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"),
+}
+
+// Tests key derivation against test vector 1 of BIP-32.
+func TestBIP32Vector(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := newMasterKey(seed)
+	if err != nil {
+		t.Fatalf("failed to derive master key: %v", err)
+	}
+	tests := []struct {
+		path string
+		key  string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, tt := range tests {
+		var path accounts.DerivationPath
+		if tt.path != "m" {
+			if path, err = accounts.ParseDerivationPath(tt.path); err != nil {
+				t.Fatalf("%s: invalid path: %v", tt.path, err)
+			}
+		}
+		key, err := master.derive(path)
+		if err != nil {
+			t.Fatalf("%s: derivation failed: %v", tt.path, err)
+		}
+		if have := hex.EncodeToString(crypto.FromECDSA(key)); have != tt.key {
+			t.Errorf("%s: key mismatch: have %s, want %s", tt.path, have, tt.key)
+		}
+	}
+	// Derivation must not wipe the parent key
+	if have := hex.EncodeToString(master.key); have != tests[0].key {
+		t.Errorf("master key modified: have %s", have)
+	}
+}
+
+// Tests that accounts are derived from mnemonics along standard paths.
+func TestDerive(t *testing.T) {
+	wallet, err := NewWallet(testMnemonic, "")
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	for i, want := range testAddrs {
+		path := append(accounts.DerivationPath{}, accounts.DefaultBaseDerivationPath...)
+		path[len(path)-1] += uint32(i)
+
+		account, err := wallet.Derive(path, i == 0)
+		if err != nil {
+			t.Fatalf("account %d: derivation failed: %v", i, err)
+		}
+		if account.Address != want {
+			t.Errorf("account %d: address mismatch: have %x, want %x", i, account.Address, want)
+		}
+	}
+	if accs := wallet.Accounts(); len(accs) != 1 || accs[0].Address != testAddrs[0] {
+		t.Errorf("pinned accounts mismatch: have %v", accs)
+	}
+	if wallet.Contains(accounts.Account{Address: testAddrs[1]}) {
+		t.Errorf("unpinned account contained")
+	}
+	if _, err := NewWallet("test test test", ""); err == nil {
+		t.Errorf("invalid mnemonic accepted")
+	}
+}
+
+// Tests signing with derived accounts.
+func TestSign(t *testing.T) {
+	wallet, _ := NewWallet(testMnemonic, "")
+	account, _ := wallet.Derive(accounts.DefaultBaseDerivationPath, true)
+
+	sig, err := wallet.SignText(account, []byte("hello"))
+	if err != nil {
+		t.Fatalf("failed to sign text: %v", err)
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig)
+	if err != nil || crypto.PubkeyToAddress(*pub) != account.Address {
+		t.Errorf("text signer mismatch: %v", err)
+	}
+	chainID := big.NewInt(1337)
+	tx, err := wallet.SignTx(account, types.NewTransaction(0, testAddrs[1], big.NewInt(1), 21000, big.NewInt(1), nil), chainID)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(chainID), tx); err != nil || from != account.Address {
+		t.Errorf("transaction sender mismatch: have %x, %v", from, err)
+	}
+	if _, err := wallet.SignText(accounts.Account{Address: testAddrs[1]}, []byte("hello")); err != accounts.ErrUnknownAccount {
+		t.Errorf("unknown account error mismatch: have %v", err)
+	}
+}
+
+// testChain is a chain state reader reporting the accounts with a nonce.
+type testChain map[common.Address]uint64
+
+func (c testChain) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+func (c testChain) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c testChain) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c testChain) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return c[account], nil
+}
+
+// Tests that self-derivation discovers used accounts and the next empty one.
+func TestSelfDerive(t *testing.T) {
+	wallet, _ := NewWallet(testMnemonic, "")
+	wallet.SelfDerive([]accounts.DerivationPath{accounts.DefaultBaseDerivationPath}, testChain{testAddrs[0]: 1})
+
+	accs := wallet.Accounts()
+	if len(accs) != 2 || accs[0].Address != testAddrs[0] || accs[1].Address != testAddrs[1] {
+		t.Fatalf("discovered accounts mismatch: have %v", accs)
+	}
+	// Once the empty account is used, the next call discovers past it
+	wallet.SelfDerive([]accounts.DerivationPath{accounts.DefaultBaseDerivationPath}, testChain{testAddrs[0]: 1, testAddrs[1]: 3})
+	if accs = wallet.Accounts(); len(accs) != 3 || accs[2].Address != testAddrs[2] {
+		t.Fatalf("rediscovered accounts mismatch: have %v", accs)
+	}
+}
+
+// Tests that imported wallets are persisted encrypted, together with their
+// pinned accounts.
+func TestBackend(t *testing.T) {
+	dir := t.TempDir()
+
+	backend, err := NewBackend(dir, keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	sink := make(chan accounts.WalletEvent, 1)
+	sub := backend.Subscribe(sink)
+	defer sub.Unsubscribe()
+
+	wallet, err := backend.Import(testMnemonic, "", "password")
+	if err != nil {
+		t.Fatalf("failed to import mnemonic: %v", err)
+	}
+	if ev := <-sink; ev.Wallet != wallet || ev.Kind != accounts.WalletArrived {
+		t.Errorf("wallet event mismatch: have %v", ev)
+	}
+	if _, err := backend.Import(testMnemonic, "", "other"); err != ErrWalletExists {
+		t.Errorf("duplicate import error mismatch: have %v", err)
+	}
+	if _, err := wallet.Derive(accounts.DefaultBaseDerivationPath, true); err != accounts.ErrWalletClosed {
+		t.Errorf("closed derivation error mismatch: have %v", err)
+	}
+	if err := wallet.Open("wrong"); err != keystore.ErrDecrypt {
+		t.Errorf("wrong password error mismatch: have %v", err)
+	}
+	if err := wallet.Open("password"); err != nil {
+		t.Fatalf("failed to open wallet: %v", err)
+	}
+	if _, err := wallet.Derive(accounts.DefaultBaseDerivationPath, true); err != nil {
+		t.Fatalf("failed to derive account: %v", err)
+	}
+	wallet.Close()
+
+	// Reload the backend and check the pinned account is usable with the password
+	backend, err = NewBackend(dir, keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatalf("failed to reload backend: %v", err)
+	}
+	wallets := backend.Wallets()
+	if len(wallets) != 1 || wallets[0].URL() != wallet.URL() {
+		t.Fatalf("reloaded wallets mismatch: have %v", wallets)
+	}
+	reloaded := wallets[0]
+	accs := reloaded.Accounts()
+	if len(accs) != 1 || accs[0].Address != testAddrs[0] {
+		t.Fatalf("reloaded accounts mismatch: have %v", accs)
+	}
+	if status, _ := reloaded.Status(); status != "Closed" {
+		t.Errorf("reloaded status mismatch: have %s", status)
+	}
+	if _, err := reloaded.SignText(accs[0], []byte("hello")); err != accounts.ErrWalletClosed {
+		t.Errorf("closed signing error mismatch: have %v", err)
+	}
+	sig, err := reloaded.SignTextWithPassphrase(accs[0], "password", []byte("hello"))
+	if err != nil {
+		t.Fatalf("failed to sign with passphrase: %v", err)
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig)
+	if err != nil || crypto.PubkeyToAddress(*pub) != testAddrs[0] {
+		t.Errorf("signer mismatch: %v", err)
+	}
+}
@@ -0,0 +1,577 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package hdwallet implements a hierarchical deterministic software wallet,
+// deriving its accounts from a BIP-39 mnemonic via BIP-32.
+package hdwallet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/keystore"
+	"github.com/simplechain-org/client/accounts/typeddata"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/log"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Scheme is the URL scheme of HD software wallets and their accounts.
+const Scheme = "hd"
+
+// Minimum time to wait between self derivation attempts, even it the user is
+// requesting accounts like crazy.
+const selfDeriveThrottling = time.Second
+
+// walletVersion is the version of the wallet file format.
+const walletVersion = 1
+
+// walletJSON is the on-disk representation of a wallet.
+type walletJSON struct {
+	Version  int                 `json:"version"`
+	Seed     keystore.CryptoJSON `json:"seed"`
+	Accounts []accountJSON       `json:"accounts"`
+}
+
+// accountJSON is an account pinned in a wallet file.
+type accountJSON struct {
+	Address common.Address          `json:"address"`
+	Path    accounts.DerivationPath `json:"path"`
+}
+
+// Wallet is a hierarchical deterministic software wallet, deriving accounts from
+// a BIP-39 seed. It is either held in memory only, or backed by a file storing
+// the seed encrypted, which needs to be opened with its password to derive keys.
+type Wallet struct {
+	url  accounts.URL         // Textual URL uniquely identifying this wallet
+	file string               // Path of the wallet file, empty for in-memory wallets
+	seed *keystore.CryptoJSON // Encrypted seed of file backed wallets
+
+	master   *extendedKey                               // BIP-32 master key, nil while closed
+	accounts []accounts.Account                         // List of derived accounts pinned to the wallet
+	paths    map[common.Address]accounts.DerivationPath // Known derivation paths for signing operations
+
+	deriveNextPaths []accounts.DerivationPath // Next derivation paths for account auto-discovery (multiple bases supported)
+	deriveNextAddrs []common.Address          // Next derived account addresses for auto-discovery (multiple bases supported)
+	deriveChain     client.ChainStateReader   // Blockchain state reader to discover used account with
+	deriveTime      time.Time                 // Time of the last self-derivation, for throttling
+	deriveEpoch     uint64                    // Counter of SelfDerive calls, to drop results of stale derivations
+	deriveLock      sync.Mutex                // Serializes self-derivations
+
+	stateLock sync.RWMutex // Protects read and write access to the wallet struct fields
+}
+
+// NewMnemonic generates a random BIP-39 mnemonic encoding the given number of
+// bits of entropy, which must be a multiple of 32 between 128 and 256.
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NewWallet creates an in-memory wallet deriving its accounts from the seed of
+// a BIP-39 mnemonic and passphrase. The wallet is open and stays so.
+func NewWallet(mnemonic, passphrase string) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	master, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	id, err := masterID(master)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		url:    accounts.URL{Scheme: Scheme, Path: id},
+		master: master,
+		paths:  make(map[common.Address]accounts.DerivationPath),
+	}, nil
+}
+
+// masterID returns an identifier of the wallet of a master key: the hex encoded
+// address of its public key.
+func masterID(master *extendedKey) (string, error) {
+	priv, err := crypto.ToECDSA(master.key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", crypto.PubkeyToAddress(priv.PublicKey)), nil
+}
+
+// loadWallet reads a wallet file. The wallet is closed.
+func loadWallet(file string) (*Wallet, error) {
+	blob, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var enc walletJSON
+	if err := json.Unmarshal(blob, &enc); err != nil {
+		return nil, err
+	}
+	if enc.Version != walletVersion {
+		return nil, fmt.Errorf("unsupported wallet version %d", enc.Version)
+	}
+	w := &Wallet{
+		url:   accounts.URL{Scheme: Scheme, Path: file},
+		file:  file,
+		seed:  &enc.Seed,
+		paths: make(map[common.Address]accounts.DerivationPath),
+	}
+	for _, acc := range enc.Accounts {
+		w.pin(acc.Address, acc.Path)
+	}
+	return w, nil
+}
+
+// store writes the encrypted seed and the pinned accounts into the wallet file.
+// It is a noop for in-memory wallets.
+//
+// The caller must hold the state lock.
+func (w *Wallet) store() error {
+	if w.file == "" {
+		return nil
+	}
+	enc := walletJSON{
+		Version:  walletVersion,
+		Seed:     *w.seed,
+		Accounts: make([]accountJSON, len(w.accounts)),
+	}
+	for i, acc := range w.accounts {
+		enc.Accounts[i] = accountJSON{Address: acc.Address, Path: w.paths[acc.Address]}
+	}
+	blob, err := json.MarshalIndent(&enc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeWalletFile(w.file, blob)
+}
+
+// writeWalletFile atomically replaces a wallet file, creating a temporary file
+// first and moving it into place.
+func writeWalletFile(file string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), file)
+}
+
+// pin starts tracking an account at the given derivation path.
+//
+// The caller must hold the state lock.
+func (w *Wallet) pin(address common.Address, path accounts.DerivationPath) bool {
+	if _, ok := w.paths[address]; ok {
+		return false
+	}
+	w.paths[address] = append(accounts.DerivationPath{}, path...)
+	w.accounts = append(w.accounts, accounts.Account{
+		Address: address,
+		URL:     accounts.URL{Scheme: Scheme, Path: fmt.Sprintf("%s/%s", w.url.Path, path)},
+	})
+	return true
+}
+
+// URL implements accounts.Wallet, returning the URL of the wallet: the path of
+// the wallet file, or an identifier of the seed for in-memory wallets.
+func (w *Wallet) URL() accounts.URL {
+	return w.url // Immutable, no need for a lock
+}
+
+// Status implements accounts.Wallet, returning whether the seed of the wallet
+// is available for deriving keys.
+func (w *Wallet) Status() (string, error) {
+	w.stateLock.RLock()
+	defer w.stateLock.RUnlock()
+
+	if w.master == nil {
+		return "Closed", nil
+	}
+	return "Open", nil
+}
+
+// Open implements accounts.Wallet, decrypting the seed of a file backed wallet
+// with the given passphrase. In-memory wallets are always open.
+func (w *Wallet) Open(passphrase string) error {
+	if w.file == "" {
+		return nil
+	}
+	w.stateLock.Lock()
+	defer w.stateLock.Unlock()
+
+	if w.master != nil {
+		return accounts.ErrWalletAlreadyOpen
+	}
+	master, err := w.decrypt(passphrase)
+	if err != nil {
+		return err
+	}
+	w.master = master
+	return nil
+}
+
+// decrypt decrypts the seed of a file backed wallet and derives its master key.
+func (w *Wallet) decrypt(passphrase string) (*extendedKey, error) {
+	seed, err := keystore.DecryptDataV3(*w.seed, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range seed {
+			seed[i] = 0
+		}
+	}()
+	return newMasterKey(seed)
+}
+
+// Close implements accounts.Wallet, wiping the decrypted seed of a file backed
+// wallet from memory. In-memory wallets can't be closed.
+func (w *Wallet) Close() error {
+	if w.file == "" {
+		return nil
+	}
+	w.stateLock.Lock()
+	defer w.stateLock.Unlock()
+
+	if w.master != nil {
+		w.master.zero()
+		w.master = nil
+	}
+	return nil
+}
+
+// Accounts implements accounts.Wallet, returning the list of accounts pinned to
+// the wallet. If self-derivation was enabled, the account list is expanded based
+// on the current chain state.
+func (w *Wallet) Accounts() []accounts.Account {
+	w.selfDerive()
+
+	w.stateLock.RLock()
+	defer w.stateLock.RUnlock()
+
+	cpy := make([]accounts.Account, len(w.accounts))
+	copy(cpy, w.accounts)
+	return cpy
+}
+
+// selfDerive attempts to find new non-zero accounts, unless self-derivation is
+// disabled, the wallet closed or the last attempt too recent.
+func (w *Wallet) selfDerive() {
+	// Skip if another self-derivation is running already
+	if !w.deriveLock.TryLock() {
+		return
+	}
+	defer w.deriveLock.Unlock()
+
+	w.stateLock.RLock()
+	if w.master == nil || w.deriveChain == nil || time.Since(w.deriveTime) < selfDeriveThrottling {
+		w.stateLock.RUnlock()
+		return
+	}
+	var (
+		chain     = w.deriveChain
+		epoch     = w.deriveEpoch
+		nextPaths = make([]accounts.DerivationPath, len(w.deriveNextPaths))
+		nextAddrs = append([]common.Address{}, w.deriveNextAddrs...)
+	)
+	for i, path := range w.deriveNextPaths {
+		nextPaths[i] = append(accounts.DerivationPath{}, path...)
+	}
+	w.stateLock.RUnlock()
+
+	// Derive the next batch of accounts without holding the lock during chain access
+	var (
+		accs  []common.Address
+		paths []accounts.DerivationPath
+		ctx   = context.Background()
+	)
+	for i := 0; i < len(nextAddrs); i++ {
+		for empty := false; !empty; {
+			// Retrieve the next derived Ethereum account
+			if nextAddrs[i] == (common.Address{}) {
+				addr, err := w.deriveAddress(nextPaths[i])
+				if err != nil {
+					log.Warn("HD wallet account derivation failed", "err", err)
+					break
+				}
+				nextAddrs[i] = addr
+			}
+			// Check the account's status against the current chain state
+			balance, err := chain.BalanceAt(ctx, nextAddrs[i], nil)
+			if err != nil {
+				log.Warn("HD wallet balance retrieval failed", "err", err)
+				break
+			}
+			nonce, err := chain.NonceAt(ctx, nextAddrs[i], nil)
+			if err != nil {
+				log.Warn("HD wallet nonce retrieval failed", "err", err)
+				break
+			}
+			// Track the account, the first empty one included for the next use,
+			// but only on the last base
+			empty = balance.Sign() == 0 && nonce == 0
+			if empty && i < len(nextAddrs)-1 {
+				break
+			}
+			accs = append(accs, nextAddrs[i])
+			paths = append(paths, append(accounts.DerivationPath{}, nextPaths[i]...))
+
+			// Fetch the next potential account
+			if !empty {
+				nextAddrs[i] = common.Address{}
+				nextPaths[i][len(nextPaths[i])-1]++
+			}
+		}
+	}
+	// Insert any accounts successfully derived and shift the self-derivation forward
+	w.stateLock.Lock()
+	defer w.stateLock.Unlock()
+
+	var pinned bool
+	for i, addr := range accs {
+		if w.pin(addr, paths[i]) {
+			log.Info("HD wallet discovered new account", "address", addr, "path", paths[i])
+			pinned = true
+		}
+	}
+	if w.deriveEpoch == epoch {
+		w.deriveNextAddrs = nextAddrs
+		w.deriveNextPaths = nextPaths
+	}
+	w.deriveTime = time.Now()
+
+	if pinned {
+		if err := w.store(); err != nil {
+			log.Warn("Failed to store HD wallet", "url", w.url, "err", err)
+		}
+	}
+}
+
+// deriveAddress derives the address of the account at the given path.
+func (w *Wallet) deriveAddress(path accounts.DerivationPath) (common.Address, error) {
+	w.stateLock.RLock()
+	defer w.stateLock.RUnlock()
+
+	if w.master == nil {
+		return common.Address{}, accounts.ErrWalletClosed
+	}
+	key, err := w.master.derive(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	defer zeroKey(key)
+	return crypto.PubkeyToAddress(key.PublicKey), nil
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not pinned into this wallet instance.
+func (w *Wallet) Contains(account accounts.Account) bool {
+	w.stateLock.RLock()
+	defer w.stateLock.RUnlock()
+
+	_, exists := w.paths[account.Address]
+	return exists
+}
+
+// Derive implements accounts.Wallet, deriving a new account at the specific
+// derivation path. If pin is set to true, the account will be added to the list
+// of tracked accounts, and stored in the wallet file.
+func (w *Wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	address, err := w.deriveAddress(path)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	account := accounts.Account{
+		Address: address,
+		URL:     accounts.URL{Scheme: Scheme, Path: fmt.Sprintf("%s/%s", w.url.Path, path)},
+	}
+	if !pin {
+		return account, nil
+	}
+	w.stateLock.Lock()
+	defer w.stateLock.Unlock()
+
+	if w.pin(address, path) {
+		if err := w.store(); err != nil {
+			return accounts.Account{}, err
+		}
+	}
+	return account, nil
+}
+
+// SelfDerive sets a base account derivation path from which the wallet attempts
+// to discover non zero accounts and automatically add them to list of tracked
+// accounts.
+//
+// Note, self derivation will increment the last component of the specified path
+// opposed to decending into a child path to allow discovering accounts starting
+// from non zero components.
+//
+// Multiple bases may be given to discover accounts on several paths, only the
+// last base will be used to derive the next empty account.
+//
+// You can disable automatic account discovery by calling SelfDerive with a nil
+// chain state reader.
+func (w *Wallet) SelfDerive(bases []accounts.DerivationPath, chain client.ChainStateReader) {
+	w.stateLock.Lock()
+	defer w.stateLock.Unlock()
+
+	w.deriveNextPaths = make([]accounts.DerivationPath, len(bases))
+	for i, base := range bases {
+		w.deriveNextPaths[i] = make(accounts.DerivationPath, len(base))
+		copy(w.deriveNextPaths[i][:], base[:])
+	}
+	w.deriveNextAddrs = make([]common.Address, len(bases))
+	w.deriveChain = chain
+	w.deriveTime = time.Time{}
+	w.deriveEpoch++
+}
+
+// key derives the private key of a pinned account from the master key of the
+// open wallet or, if a passphrase is given, from the seed decrypted with it.
+func (w *Wallet) key(account accounts.Account, passphrase *string) (*ecdsa.PrivateKey, error) {
+	w.stateLock.RLock()
+	defer w.stateLock.RUnlock()
+
+	path, ok := w.paths[account.Address]
+	if !ok {
+		return nil, accounts.ErrUnknownAccount
+	}
+	master := w.master
+	if passphrase != nil && w.seed != nil {
+		decrypted, err := w.decrypt(*passphrase)
+		if err != nil {
+			return nil, err
+		}
+		defer decrypted.zero()
+		master = decrypted
+	}
+	if master == nil {
+		return nil, accounts.ErrWalletClosed
+	}
+	return master.derive(path)
+}
+
+// signHash signs the given hash with the key of the account.
+func (w *Wallet) signHash(account accounts.Account, passphrase *string, hash []byte) ([]byte, error) {
+	key, err := w.key(account, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key)
+	return crypto.Sign(hash, key)
+}
+
+// signTx signs the transaction with the key of the account.
+func (w *Wallet) signTx(account accounts.Account, passphrase *string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	key, err := w.key(account, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key)
+
+	// Depending on the presence of the chain ID, sign with 2718 or homestead
+	signer := types.LatestSignerForChainID(chainID)
+	return types.SignTx(tx, signer, key)
+}
+
+// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
+// Typed data may be given in its JSON representation, it is encoded before hashing.
+func (w *Wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	data, err := encodeData(mimeType, data)
+	if err != nil {
+		return nil, err
+	}
+	return w.signHash(account, nil, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase is identical to SignData, but decrypts the seed of file
+// backed wallets with the passphrase, even if the wallet is closed.
+func (w *Wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	data, err := encodeData(mimeType, data)
+	if err != nil {
+		return nil, err
+	}
+	return w.signHash(account, &passphrase, crypto.Keccak256(data))
+}
+
+// SignText implements accounts.Wallet, attempting to sign the hash of
+// the given text with the given account.
+func (w *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return w.signHash(account, nil, accounts.TextHash(text))
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, attempting to sign the
+// hash of the given text with the given account using passphrase as extra authentication.
+func (w *Wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	return w.signHash(account, &passphrase, accounts.TextHash(text))
+}
+
+// SignTx implements accounts.Wallet, attempting to sign the given transaction
+// with the given account.
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.signTx(account, nil, tx, chainID)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, attempting to sign the given
+// transaction with the given account using passphrase as extra authentication.
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.signTx(account, &passphrase, tx, chainID)
+}
+
+// encodeData converts typed data signing requests into the EIP-712 payload to
+// hash and sign, leaving the data of other mimetypes untouched.
+func encodeData(mimeType string, data []byte) ([]byte, error) {
+	if mimeType != accounts.MimetypeTypedData {
+		return data, nil
+	}
+	return typeddata.ParsePayload(data)
+}
+
+// zeroKey zeroes a private key in memory.
+func zeroKey(k *ecdsa.PrivateKey) {
+	b := k.D.Bits()
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"time"
 
 	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/typeddata"
 	"github.com/simplechain-org/client/common"
 	"github.com/simplechain-org/client/core/types"
 	"github.com/simplechain-org/client/crypto"
@@ -82,8 +83,14 @@ type unlocked struct {
 
 // NewKeyStore creates a keystore for the given directory.
 func NewKeyStore(keydir string, scryptN, scryptP int) *KeyStore {
+	return NewKeyStoreWithKDF(keydir, ScryptKDF(scryptN, scryptP))
+}
+
+// NewKeyStoreWithKDF creates a keystore for the given directory, encrypting new
+// keys with the given key derivation function.
+func NewKeyStoreWithKDF(keydir string, kdf KDFParams) *KeyStore {
 	keydir, _ = filepath.Abs(keydir)
-	ks := &KeyStore{storage: &keyStorePassphrase{keydir, scryptN, scryptP, false}}
+	ks := &KeyStore{storage: &keyStorePassphrase{keydir, kdf, false}}
 	ks.init(keydir)
 	return ks
 }
@@ -301,6 +308,26 @@ func (ks *KeyStore) SignHashWithPassphrase(a accounts.Account, passphrase string
 	return crypto.Sign(hash, key.PrivateKey)
 }
 
+// SignTypedData signs the EIP-712 hash of the typed data with the requested
+// account. The produced signature is in the [R || S || V] format where V is 0 or 1.
+func (ks *KeyStore) SignTypedData(a accounts.Account, typedData *typeddata.TypedData) ([]byte, error) {
+	hash, err := typedData.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return ks.SignHash(a, hash[:])
+}
+
+// SignTypedDataWithPassphrase signs the EIP-712 hash of the typed data if the
+// private key matching the given address can be decrypted with the given passphrase.
+func (ks *KeyStore) SignTypedDataWithPassphrase(a accounts.Account, passphrase string, typedData *typeddata.TypedData) ([]byte, error) {
+	hash, err := typedData.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return ks.SignHashWithPassphrase(a, passphrase, hash[:])
+}
+
 // SignTxWithPassphrase signs the transaction if the private key matching the
 // given address can be decrypted with the given passphrase.
 func (ks *KeyStore) SignTxWithPassphrase(a accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
@@ -425,13 +452,11 @@ func (ks *KeyStore) Export(a accounts.Account, passphrase, newPassphrase string)
 	if err != nil {
 		return nil, err
 	}
-	var N, P int
+	kdf := ScryptKDF(StandardScryptN, StandardScryptP)
 	if store, ok := ks.storage.(*keyStorePassphrase); ok {
-		N, P = store.scryptN, store.scryptP
-	} else {
-		N, P = StandardScryptN, StandardScryptP
+		kdf = store.kdf
 	}
-	return EncryptKey(key, newPassphrase, N, P)
+	return EncryptKeyWithKDF(key, newPassphrase, kdf)
 }
 
 // Import stores the given encrypted JSON key into the key directory.
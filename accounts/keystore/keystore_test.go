@@ -17,7 +17,9 @@
 package keystore
 
 import (
+	"bytes"
 	"io/ioutil"
+	"math/big"
 	"math/rand"
 	"os"
 	"runtime"
@@ -29,7 +31,9 @@ import (
 	"time"
 
 	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/typeddata"
 	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/math"
 	"github.com/simplechain-org/client/crypto"
 	"github.com/simplechain-org/client/event"
 )
@@ -116,6 +120,61 @@ func TestSignWithPassphrase(t *testing.T) {
 	}
 }
 
+func TestSignTypedData(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	pass := "passwd"
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	acc, err := ks.ImportECDSA(key, pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	td := &typeddata.TypedData{
+		Types: typeddata.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+			"Permit":       {{Name: "spender", Type: "address"}, {Name: "value", Type: "uint256"}},
+		},
+		PrimaryType: "Permit",
+		Domain:      typeddata.Domain{Name: "Token", ChainId: math.NewHexOrDecimal256(1)},
+		Message:     typeddata.Message{"spender": common.Address{0x01}, "value": big.NewInt(1000)},
+	}
+	hash, err := td.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sign via the keystore, the wallet's passphrase and unlocked paths
+	sig, err := ks.SignTypedDataWithPassphrase(acc, pass, td)
+	if err != nil || !bytes.Equal(sig, want) {
+		t.Fatalf("keystore signature mismatch: have %x, %v, want %x", sig, err, want)
+	}
+	wallet := ks.Wallets()[0]
+	if sig, err = typeddata.SignWithPassphrase(wallet, acc, pass, td); err != nil || !bytes.Equal(sig, want) {
+		t.Fatalf("wallet signature mismatch: have %x, %v, want %x", sig, err, want)
+	}
+	if err := ks.Unlock(acc, pass); err != nil {
+		t.Fatal(err)
+	}
+	if sig, err = ks.SignTypedData(acc, td); err != nil || !bytes.Equal(sig, want) {
+		t.Fatalf("unlocked keystore signature mismatch: have %x, %v, want %x", sig, err, want)
+	}
+	if sig, err = typeddata.Sign(wallet, acc, td); err != nil || !bytes.Equal(sig, want) {
+		t.Fatalf("unlocked wallet signature mismatch: have %x, %v, want %x", sig, err, want)
+	}
+	// Pre-encoded payloads are signed as is
+	payload, _ := td.Payload()
+	if sig, err = wallet.SignData(acc, accounts.MimetypeTypedData, payload); err != nil || !bytes.Equal(sig, want) {
+		t.Fatalf("payload signature mismatch: have %x, %v, want %x", sig, err, want)
+	}
+	if _, err = wallet.SignData(acc, accounts.MimetypeTypedData, []byte("{}")); err == nil {
+		t.Fatal("expected error signing invalid typed data")
+	}
+}
+
 func TestTimedUnlock(t *testing.T) {
 	dir, ks := tmpKeyStore(t, true)
 	defer os.RemoveAll(dir)
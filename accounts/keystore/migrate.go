@@ -0,0 +1,249 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/simplechain-org/client/accounts"
+)
+
+// minPBKDF2Iterations is the number of PBKDF2-HMAC-SHA256 iterations below which
+// key files are reported weak, as recommended by OWASP.
+const minPBKDF2Iterations = 600000
+
+// renameKeyFile moves a migrated key file into place, replaceable by tests.
+var renameKeyFile = os.Rename
+
+// rekeyedFile is a key file being migrated by Rekey.
+type rekeyedFile struct {
+	path     string // Path of the key file
+	original []byte // Content of the key file before the migration
+	tmp      string // Temporary file holding the migrated content, empty once moved
+}
+
+// Rekey re-encrypts the key files of the given accounts, or of all accounts if
+// nil, with a new passphrase and key derivation function. All keys must decrypt
+// with the passphrase; pass it as newPassphrase to only change the KDF.
+//
+// The migration is atomic: every key is re-encrypted and verified before any key
+// file is replaced, and the replaced files are restored if moving another one
+// into place fails. Unlocked accounts stay unlocked.
+func (ks *KeyStore) Rekey(accs []accounts.Account, passphrase, newPassphrase string, kdf KDFParams) error {
+	store, ok := ks.storage.(*keyStorePassphrase)
+	if !ok {
+		return accounts.ErrNotSupported
+	}
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	if accs == nil {
+		accs = ks.Accounts()
+	}
+	// Re-encrypt every key into a temporary file, leaving the key files untouched
+	var (
+		files []*rekeyedFile
+		seen  = make(map[string]bool)
+	)
+	defer func() {
+		for _, f := range files {
+			if f.tmp != "" {
+				os.Remove(f.tmp)
+			}
+		}
+	}()
+	for _, a := range accs {
+		a, err := ks.Find(a)
+		if err != nil {
+			return err
+		}
+		if seen[a.URL.Path] {
+			continue
+		}
+		seen[a.URL.Path] = true
+
+		f, err := rekeyFile(store, a, passphrase, newPassphrase, kdf)
+		if f != nil {
+			files = append(files, f)
+		}
+		if err != nil {
+			return fmt.Errorf("account %x: %w", a.Address, err)
+		}
+	}
+	// Move the migrated files into place, restoring the originals on failure
+	for i, f := range files {
+		if err := renameKeyFile(f.tmp, f.path); err != nil {
+			return rollbackRekey(files[:i], fmt.Errorf("rekey of %s failed: %w", f.path, err))
+		}
+		f.tmp = ""
+	}
+	return nil
+}
+
+// rekeyFile writes the key of an account, re-encrypted with the new passphrase
+// and KDF, into a temporary file next to its key file.
+func rekeyFile(store *keyStorePassphrase, a accounts.Account, passphrase, newPassphrase string, kdf KDFParams) (*rekeyedFile, error) {
+	original, err := os.ReadFile(a.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := DecryptKey(original, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key.PrivateKey)
+
+	if key.Address != a.Address {
+		return nil, fmt.Errorf("key content mismatch: have account %x, want %x", key.Address, a.Address)
+	}
+	keyjson, err := EncryptKeyWithKDF(key, newPassphrase, kdf)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := writeTemporaryKeyFile(a.URL.Path, keyjson)
+	if err != nil {
+		return nil, err
+	}
+	f := &rekeyedFile{path: a.URL.Path, original: original, tmp: tmp}
+	if !store.skipKeyFileVerification {
+		// Verify that we can decrypt the file with the new password.
+		verified, err := store.GetKey(a.Address, tmp, newPassphrase)
+		if err != nil {
+			return f, fmt.Errorf("verification failed: %w", err)
+		}
+		zeroKey(verified.PrivateKey)
+	}
+	return f, nil
+}
+
+// rollbackRekey restores the original content of the already migrated key files.
+func rollbackRekey(moved []*rekeyedFile, err error) error {
+	errs := []error{err}
+	for _, f := range moved {
+		if rerr := writeKeyFile(f.path, f.original); rerr != nil {
+			errs = append(errs, fmt.Errorf("restoring %s failed: %w", f.path, rerr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// KDFAudit describes the key derivation function protecting a key file, and the
+// reasons it is weak compared to the standard parameters of its KDF, if any.
+type KDFAudit struct {
+	Account accounts.Account
+	KDF     string                 // Name of the key derivation function
+	Params  map[string]interface{} // Parameters of the KDF, without the salt
+	Weak    []string               // Reasons the protection is weak, empty if not
+}
+
+// AuditKDF reports the key derivation functions and parameters protecting the
+// key files, flagging the keys weaker than the standard scrypt and Argon2id
+// parameters or than the recommended PBKDF2 iterations. Key files which can't
+// be read are reported weak as well.
+func (ks *KeyStore) AuditKDF() []KDFAudit {
+	accs := ks.Accounts()
+	audits := make([]KDFAudit, len(accs))
+	for i, a := range accs {
+		audits[i] = auditKeyFile(a)
+	}
+	return audits
+}
+
+// auditKeyFile inspects the public KDF parameters of a key file.
+func auditKeyFile(a accounts.Account) KDFAudit {
+	audit := KDFAudit{Account: a}
+
+	keyjson, err := os.ReadFile(a.URL.Path)
+	if err != nil {
+		audit.Weak = []string{fmt.Sprintf("unreadable: %v", err)}
+		return audit
+	}
+	var k struct {
+		Crypto  CryptoJSON  `json:"crypto"`
+		Version interface{} `json:"version"`
+	}
+	if err := json.Unmarshal(keyjson, &k); err != nil {
+		audit.Weak = []string{fmt.Sprintf("invalid key file: %v", err)}
+		return audit
+	}
+	if v, ok := k.Version.(string); ok && v == "1" {
+		audit.Weak = append(audit.Weak, "legacy version 1 key format")
+	}
+	audit.KDF = k.Crypto.KDF
+	audit.Params = make(map[string]interface{}, len(k.Crypto.KDFParams))
+	for name, value := range k.Crypto.KDFParams {
+		if name != "salt" {
+			audit.Params[name] = value
+		}
+	}
+	audit.Weak = append(audit.Weak, weakKDFParams(k.Crypto)...)
+	return audit
+}
+
+// weakKDFParams returns the reasons the KDF parameters of an encrypted key are
+// weak.
+func weakKDFParams(c CryptoJSON) []string {
+	var weak []string
+
+	if salt, ok := c.KDFParams["salt"].(string); !ok {
+		weak = append(weak, "missing salt")
+	} else if b, err := hex.DecodeString(salt); err != nil || len(b) < 16 {
+		weak = append(weak, "salt shorter than 16 bytes")
+	}
+	if dklen, ok := kdfParam(c.KDFParams, "dklen"); !ok || dklen < scryptDKLen {
+		weak = append(weak, fmt.Sprintf("derived key shorter than %d bytes", scryptDKLen))
+	}
+	switch c.KDF {
+	case KDFScrypt:
+		if n, ok := kdfParam(c.KDFParams, "n"); !ok || n < StandardScryptN {
+			weak = append(weak, fmt.Sprintf("scrypt N %d below %d", n, StandardScryptN))
+		}
+		if r, ok := kdfParam(c.KDFParams, "r"); !ok || r < scryptR {
+			weak = append(weak, fmt.Sprintf("scrypt r %d below %d", r, scryptR))
+		}
+	case KDFPBKDF2:
+		if iters, ok := kdfParam(c.KDFParams, "c"); !ok || iters < minPBKDF2Iterations {
+			weak = append(weak, fmt.Sprintf("PBKDF2 iterations %d below %d", iters, minPBKDF2Iterations))
+		}
+	case KDFArgon2id:
+		if t, ok := kdfParam(c.KDFParams, "t"); !ok || t < StandardArgon2Time {
+			weak = append(weak, fmt.Sprintf("Argon2id time %d below %d", t, StandardArgon2Time))
+		}
+		if m, ok := kdfParam(c.KDFParams, "m"); !ok || m < StandardArgon2Memory {
+			weak = append(weak, fmt.Sprintf("Argon2id memory %dKiB below %dKiB", m, StandardArgon2Memory))
+		}
+	default:
+		weak = append(weak, fmt.Sprintf("unsupported KDF %q", c.KDF))
+	}
+	return weak
+}
+
+// kdfParam returns an integer KDF parameter, reporting false if it is missing or
+// not a number.
+func kdfParam(params map[string]interface{}, name string) (int, bool) {
+	switch v := params[name].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/simplechain-org/client/accounts"
+)
+
+// Tests that all keys are migrated to a new passphrase and KDF.
+func TestRekey(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	a1, _ := ks.NewAccount("old")
+	a2, _ := ks.NewAccount("old")
+
+	kdf := Argon2idKDF(LightArgon2Time, LightArgon2Memory, LightArgon2Threads)
+	if err := ks.Rekey(nil, "old", "new", kdf); err != nil {
+		t.Fatalf("failed to rekey: %v", err)
+	}
+	for _, a := range []accounts.Account{a1, a2} {
+		if err := ks.Unlock(a, "new"); err != nil {
+			t.Errorf("account %x: failed to unlock with new passphrase: %v", a.Address, err)
+		}
+		audit := auditKeyFile(a)
+		if audit.KDF != KDFArgon2id {
+			t.Errorf("account %x: KDF mismatch: have %s, want %s", a.Address, audit.KDF, KDFArgon2id)
+		}
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, ".*.tmp*")); len(matches) != 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+}
+
+// Tests that no key is touched if any key fails to migrate, and that moved key
+// files are restored if moving another one fails.
+func TestRekeyRollback(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	a1, _ := ks.NewAccount("old")
+	a2, _ := ks.NewAccount("other")
+
+	original := make(map[string][]byte)
+	for _, a := range []accounts.Account{a1, a2} {
+		original[a.URL.Path], _ = os.ReadFile(a.URL.Path)
+	}
+	check := func() {
+		t.Helper()
+		for path, want := range original {
+			if have, _ := os.ReadFile(path); !bytes.Equal(have, want) {
+				t.Errorf("key file %s modified", path)
+			}
+		}
+		if matches, _ := filepath.Glob(filepath.Join(dir, ".*.tmp*")); len(matches) != 0 {
+			t.Errorf("temporary files left behind: %v", matches)
+		}
+	}
+	// A key not decrypting with the passphrase aborts the migration
+	if err := ks.Rekey(nil, "old", "new", ScryptKDF(veryLightScryptN, veryLightScryptP)); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("rekey error mismatch: have %v, want %v", err, ErrDecrypt)
+	}
+	check()
+
+	// A failure to move a key file into place restores the moved ones
+	if err := ks.Update(a2, "other", "old"); err != nil {
+		t.Fatal(err)
+	}
+	original[a2.URL.Path], _ = os.ReadFile(a2.URL.Path)
+
+	failure := errors.New("rename failure")
+	defer func(rename func(string, string) error) { renameKeyFile = rename }(renameKeyFile)
+	var renames int
+	renameKeyFile = func(from, to string) error {
+		if renames++; renames > 1 {
+			return failure
+		}
+		return os.Rename(from, to)
+	}
+	if err := ks.Rekey(nil, "old", "new", ScryptKDF(veryLightScryptN, veryLightScryptP)); !errors.Is(err, failure) {
+		t.Fatalf("rekey error mismatch: have %v, want %v", err, failure)
+	}
+	check()
+	for _, a := range []accounts.Account{a1, a2} {
+		if err := ks.Unlock(a, "old"); err != nil {
+			t.Errorf("account %x: failed to unlock with old passphrase: %v", a.Address, err)
+		}
+	}
+}
+
+// Tests that keys encrypted with parameters below the standard ones are
+// reported weak.
+func TestAuditKDF(t *testing.T) {
+	dir, ks := tmpKeyStore(t, true)
+	defer os.RemoveAll(dir)
+
+	weak, _ := ks.NewAccount("pass")
+	strong, _ := ks.NewAccount("pass")
+	if err := ks.Rekey([]accounts.Account{strong}, "pass", "pass", Argon2idKDF(StandardArgon2Time, StandardArgon2Memory, 1)); err != nil {
+		t.Fatalf("failed to rekey: %v", err)
+	}
+	audits := make(map[accounts.Account]KDFAudit)
+	for _, audit := range ks.AuditKDF() {
+		audits[audit.Account] = audit
+	}
+	if audit := audits[weak]; audit.KDF != KDFScrypt || len(audit.Weak) != 1 {
+		t.Errorf("weak key audit mismatch: have %+v", audit)
+	}
+	if _, ok := audits[weak].Params["salt"]; ok {
+		t.Errorf("salt reported")
+	}
+	if audit := audits[strong]; audit.KDF != KDFArgon2id || len(audit.Weak) != 0 {
+		t.Errorf("strong key audit mismatch: have %+v", audit)
+	}
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/simplechain-org/client/common/math"
 	"github.com/simplechain-org/client/crypto"
 	"github.com/google/uuid"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
 )
@@ -50,6 +51,16 @@ import (
 const (
 	keyHeaderKDF = "scrypt"
 
+	// KDFScrypt is the name of the scrypt key derivation function.
+	KDFScrypt = keyHeaderKDF
+
+	// KDFPBKDF2 is the name of the PBKDF2 key derivation function, which is only
+	// supported for decryption.
+	KDFPBKDF2 = "pbkdf2"
+
+	// KDFArgon2id is the name of the Argon2id key derivation function.
+	KDFArgon2id = "argon2id"
+
 	// StandardScryptN is the N parameter of Scrypt encryption algorithm, using 256MB
 	// memory and taking approximately 1s CPU time on a modern processor.
 	StandardScryptN = 1 << 18
@@ -68,12 +79,120 @@ const (
 
 	scryptR     = 8
 	scryptDKLen = 32
+
+	// StandardArgon2Time is the number of passes of Argon2id encryption, using
+	// 64MB memory as recommended by RFC 9106.
+	StandardArgon2Time = 3
+
+	// StandardArgon2Memory is the memory of Argon2id encryption in KiB, as
+	// recommended by RFC 9106.
+	StandardArgon2Memory = 64 * 1024
+
+	// StandardArgon2Threads is the parallelism of Argon2id encryption, as
+	// recommended by RFC 9106.
+	StandardArgon2Threads = 4
+
+	// LightArgon2Time is the number of passes of Argon2id encryption, using 4MB
+	// memory.
+	LightArgon2Time = 1
+
+	// LightArgon2Memory is the memory of Argon2id encryption in KiB.
+	LightArgon2Memory = 4 * 1024
+
+	// LightArgon2Threads is the parallelism of Argon2id encryption, using 4MB
+	// memory.
+	LightArgon2Threads = 1
+)
+
+// KDFParams selects the key derivation function stretching passwords into
+// encryption keys, together with its cost parameters.
+type KDFParams struct {
+	KDF string // KDFScrypt or KDFArgon2id
+
+	ScryptN int // CPU/memory cost of scrypt
+	ScryptP int // Parallelization of scrypt
+
+	Argon2Time    uint32 // Number of passes of Argon2id
+	Argon2Memory  uint32 // Memory of Argon2id, in KiB
+	Argon2Threads uint8  // Parallelism of Argon2id
+}
+
+// ScryptKDF returns the parameters of scrypt with the given N and P.
+func ScryptKDF(scryptN, scryptP int) KDFParams {
+	return KDFParams{KDF: KDFScrypt, ScryptN: scryptN, ScryptP: scryptP}
+}
+
+// Argon2idKDF returns the parameters of Argon2id with the given number of
+// passes, memory in KiB and parallelism.
+func Argon2idKDF(time, memory uint32, threads uint8) KDFParams {
+	return KDFParams{KDF: KDFArgon2id, Argon2Time: time, Argon2Memory: memory, Argon2Threads: threads}
+}
+
+// deriveKey stretches the password with the key derivation function, returning
+// the derived key and the kdfparams to store along with the encrypted data.
+func (p KDFParams) deriveKey(auth, salt []byte) ([]byte, map[string]interface{}, error) {
+	switch p.KDF {
+	case KDFScrypt:
+		derivedKey, err := scrypt.Key(auth, salt, p.ScryptN, scryptR, p.ScryptP, scryptDKLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		params := make(map[string]interface{}, 5)
+		params["n"] = p.ScryptN
+		params["r"] = scryptR
+		params["p"] = p.ScryptP
+		params["dklen"] = scryptDKLen
+		params["salt"] = hex.EncodeToString(salt)
+		return derivedKey, params, nil
+
+	case KDFArgon2id:
+		if err := checkArgon2(p.Argon2Time, p.Argon2Memory, int(p.Argon2Threads)); err != nil {
+			return nil, nil, err
+		}
+		derivedKey := argon2.IDKey(auth, salt, p.Argon2Time, p.Argon2Memory, p.Argon2Threads, scryptDKLen)
+		params := make(map[string]interface{}, 5)
+		params["t"] = p.Argon2Time
+		params["m"] = p.Argon2Memory
+		params["p"] = p.Argon2Threads
+		params["dklen"] = scryptDKLen
+		params["salt"] = hex.EncodeToString(salt)
+		return derivedKey, params, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported KDF: %s", p.KDF)
+}
+
+// Bounds of the Argon2id parameters, keeping key files from making the process
+// allocate unbounded memory or spin for an unbounded time. They may be raised to
+// use keys encrypted with stronger parameters.
+var (
+	MaxArgon2Time   uint32 = 64          // Maximum number of passes of Argon2id
+	MaxArgon2Memory uint32 = 1024 * 1024 // Maximum memory of Argon2id in KiB, 1GB
 )
 
+// checkArgon2 validates Argon2id parameters, which argon2.IDKey panics on, and
+// enforces their configured bounds.
+func checkArgon2(time, memory uint32, threads int) error {
+	if time < 1 {
+		return fmt.Errorf("invalid Argon2id time %d", time)
+	}
+	if time > MaxArgon2Time {
+		return fmt.Errorf("too many Argon2id passes %d, limit %d", time, MaxArgon2Time)
+	}
+	if memory > MaxArgon2Memory {
+		return fmt.Errorf("too much Argon2id memory %dKiB, limit %dKiB", memory, MaxArgon2Memory)
+	}
+	if threads < 1 || threads > 255 {
+		return fmt.Errorf("invalid Argon2id parallelism %d", threads)
+	}
+	if memory < 8*uint32(threads) {
+		return fmt.Errorf("invalid Argon2id memory %dKiB for parallelism %d", memory, threads)
+	}
+	return nil
+}
+
 type keyStorePassphrase struct {
 	keysDirPath string
-	scryptN     int
-	scryptP     int
+	kdf         KDFParams
 	// skipKeyFileVerification disables the security-feature which does
 	// reads and decrypts any newly created keyfiles. This should be 'false' in all
 	// cases except tests -- setting this to 'true' is not recommended.
@@ -99,12 +218,12 @@ func (ks keyStorePassphrase) GetKey(addr common.Address, filename, auth string)
 
 // StoreKey generates a key, encrypts with 'auth' and stores in the given directory
 func StoreKey(dir, auth string, scryptN, scryptP int) (accounts.Account, error) {
-	_, a, err := storeNewKey(&keyStorePassphrase{dir, scryptN, scryptP, false}, rand.Reader, auth)
+	_, a, err := storeNewKey(&keyStorePassphrase{dir, ScryptKDF(scryptN, scryptP), false}, rand.Reader, auth)
 	return a, err
 }
 
 func (ks keyStorePassphrase) StoreKey(filename string, key *Key, auth string) error {
-	keyjson, err := EncryptKey(key, auth, ks.scryptN, ks.scryptP)
+	keyjson, err := EncryptKeyWithKDF(key, auth, ks.kdf)
 	if err != nil {
 		return err
 	}
@@ -139,12 +258,18 @@ func (ks keyStorePassphrase) JoinPath(filename string) string {
 
 // Encryptdata encrypts the data given as 'data' with the password 'auth'.
 func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error) {
+	return EncryptDataV3WithKDF(data, auth, ScryptKDF(scryptN, scryptP))
+}
+
+// EncryptDataV3WithKDF encrypts the data given as 'data' with the password 'auth',
+// stretched by the given key derivation function.
+func EncryptDataV3WithKDF(data, auth []byte, kdf KDFParams) (CryptoJSON, error) {
 
 	salt := make([]byte, 32)
 	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
 		panic("reading from crypto/rand failed: " + err.Error())
 	}
-	derivedKey, err := scrypt.Key(auth, salt, scryptN, scryptR, scryptP, scryptDKLen)
+	derivedKey, kdfParamsJSON, err := kdf.deriveKey(auth, salt)
 	if err != nil {
 		return CryptoJSON{}, err
 	}
@@ -160,12 +285,6 @@ func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error)
 	}
 	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
 
-	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = scryptN
-	scryptParamsJSON["r"] = scryptR
-	scryptParamsJSON["p"] = scryptP
-	scryptParamsJSON["dklen"] = scryptDKLen
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
 	cipherParamsJSON := cipherparamsJSON{
 		IV: hex.EncodeToString(iv),
 	}
@@ -174,8 +293,8 @@ func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error)
 		Cipher:       "aes-128-ctr",
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          keyHeaderKDF,
-		KDFParams:    scryptParamsJSON,
+		KDF:          kdf.KDF,
+		KDFParams:    kdfParamsJSON,
 		MAC:          hex.EncodeToString(mac),
 	}
 	return cryptoStruct, nil
@@ -184,8 +303,14 @@ func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error)
 // EncryptKey encrypts a key using the specified scrypt parameters into a json
 // blob that can be decrypted later on.
 func EncryptKey(key *Key, auth string, scryptN, scryptP int) ([]byte, error) {
+	return EncryptKeyWithKDF(key, auth, ScryptKDF(scryptN, scryptP))
+}
+
+// EncryptKeyWithKDF encrypts a key using the specified key derivation function
+// into a json blob that can be decrypted later on.
+func EncryptKeyWithKDF(key *Key, auth string, kdf KDFParams) ([]byte, error) {
 	keyBytes := math.PaddedBigBytes(key.PrivateKey.D, 32)
-	cryptoStruct, err := EncryptDataV3(keyBytes, []byte(auth), scryptN, scryptP)
+	cryptoStruct, err := EncryptDataV3WithKDF(keyBytes, []byte(auth), kdf)
 	if err != nil {
 		return nil, err
 	}
@@ -343,7 +468,7 @@ func getKDFKey(cryptoJSON CryptoJSON, auth string) ([]byte, error) {
 		p := ensureInt(cryptoJSON.KDFParams["p"])
 		return scrypt.Key(authArray, salt, n, r, p, dkLen)
 
-	} else if cryptoJSON.KDF == "pbkdf2" {
+	} else if cryptoJSON.KDF == KDFPBKDF2 {
 		c := ensureInt(cryptoJSON.KDFParams["c"])
 		prf := cryptoJSON.KDFParams["prf"].(string)
 		if prf != "hmac-sha256" {
@@ -351,6 +476,18 @@ func getKDFKey(cryptoJSON CryptoJSON, auth string) ([]byte, error) {
 		}
 		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
 		return key, nil
+
+	} else if cryptoJSON.KDF == KDFArgon2id {
+		t := ensureInt(cryptoJSON.KDFParams["t"])
+		m := ensureInt(cryptoJSON.KDFParams["m"])
+		p := ensureInt(cryptoJSON.KDFParams["p"])
+		if t < 0 || m < 0 || int64(t) > int64(^uint32(0)) || int64(m) > int64(^uint32(0)) {
+			return nil, fmt.Errorf("invalid Argon2id parameters t=%d m=%d", t, m)
+		}
+		if err := checkArgon2(uint32(t), uint32(m), p); err != nil {
+			return nil, err
+		}
+		return argon2.IDKey(authArray, salt, uint32(t), uint32(m), uint8(p), uint32(dkLen)), nil
 	}
 
 	return nil, fmt.Errorf("unsupported KDF: %s", cryptoJSON.KDF)
@@ -17,7 +17,9 @@
 package keystore
 
 import (
+	"encoding/json"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/simplechain-org/client/common"
@@ -58,3 +60,53 @@ func TestKeyEncryptDecrypt(t *testing.T) {
 		}
 	}
 }
+
+// Tests that keys encrypted with Argon2id can be decrypted, and that invalid
+// Argon2id parameters are rejected instead of panicking.
+func TestKeyEncryptDecryptArgon2id(t *testing.T) {
+	keyjson, err := ioutil.ReadFile("testdata/very-light-scrypt.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := DecryptKey(keyjson, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyjson, err = EncryptKeyWithKDF(key, "pass", Argon2idKDF(LightArgon2Time, LightArgon2Memory, LightArgon2Threads)); err != nil {
+		t.Fatalf("failed to encrypt key: %v", err)
+	}
+	if _, err := DecryptKey(keyjson, "bad"); err != ErrDecrypt {
+		t.Errorf("bad password error mismatch: have %v, want %v", err, ErrDecrypt)
+	}
+	decrypted, err := DecryptKey(keyjson, "pass")
+	if err != nil {
+		t.Fatalf("failed to decrypt key: %v", err)
+	}
+	if decrypted.Address != key.Address {
+		t.Errorf("key address mismatch: have %x, want %x", decrypted.Address, key.Address)
+	}
+	for _, kdf := range []KDFParams{
+		Argon2idKDF(0, LightArgon2Memory, 1),
+		Argon2idKDF(1, LightArgon2Memory, 0),
+		Argon2idKDF(1, 7, 1),
+		Argon2idKDF(MaxArgon2Time+1, LightArgon2Memory, 1),
+		Argon2idKDF(1, MaxArgon2Memory+1, 1),
+		{KDF: "bcrypt"},
+	} {
+		if _, err := EncryptKeyWithKDF(key, "pass", kdf); err == nil {
+			t.Errorf("invalid KDF %+v accepted", kdf)
+		}
+	}
+	// Key files exceeding the bounds are rejected before deriving the key
+	for _, param := range []string{"t", "m"} {
+		var crafted encryptedKeyJSONV3
+		if err := json.Unmarshal(keyjson, &crafted); err != nil {
+			t.Fatal(err)
+		}
+		crafted.Crypto.KDFParams[param] = float64(^uint32(0))
+		blob, _ := json.Marshal(&crafted)
+		if _, err := DecryptKey(blob, "pass"); err == nil || !strings.Contains(err.Error(), "limit") {
+			t.Errorf("oversized Argon2id %s error mismatch: have %v", param, err)
+		}
+	}
+}
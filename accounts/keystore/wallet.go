@@ -21,6 +21,7 @@ import (
 
 	"github.com/simplechain-org/client"
 	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/typeddata"
 	"github.com/simplechain-org/client/core/types"
 	"github.com/simplechain-org/client/crypto"
 )
@@ -94,7 +95,12 @@ func (w *keystoreWallet) signHash(account accounts.Account, hash []byte) ([]byte
 }
 
 // SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
+// Typed data may be given in its JSON representation, it is encoded before hashing.
 func (w *keystoreWallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	data, err := encodeData(mimeType, data)
+	if err != nil {
+		return nil, err
+	}
 	return w.signHash(account, crypto.Keccak256(data))
 }
 
@@ -104,10 +110,23 @@ func (w *keystoreWallet) SignDataWithPassphrase(account accounts.Account, passph
 	if !w.Contains(account) {
 		return nil, accounts.ErrUnknownAccount
 	}
+	data, err := encodeData(mimeType, data)
+	if err != nil {
+		return nil, err
+	}
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignHashWithPassphrase(account, passphrase, crypto.Keccak256(data))
 }
 
+// encodeData converts typed data signing requests into the EIP-712 payload to
+// hash and sign, leaving the data of other mimetypes untouched.
+func encodeData(mimeType string, data []byte) ([]byte, error) {
+	if mimeType != accounts.MimetypeTypedData {
+		return data, nil
+	}
+	return typeddata.ParsePayload(data)
+}
+
 // SignText implements accounts.Wallet, attempting to sign the hash of
 // the given text with the given account.
 func (w *keystoreWallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
@@ -0,0 +1,115 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package multisig
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/event"
+)
+
+// Backend is an accounts.Backend of multisig wallets sharing a database and a
+// finder of the owner wallets.
+type Backend struct {
+	db     ethdb.KeyValueStore
+	finder Finder
+
+	wallets     []accounts.Wallet       // Multisig wallets, sorted by URL
+	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
+	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
+	lock        sync.RWMutex            // Protects the wallet list
+}
+
+// NewBackend creates a backend storing the proposals of its multisig wallets in
+// the database. The finder, usually the accounts.Manager the backend is added
+// to, looks up the wallets of the owners and executors.
+func NewBackend(db ethdb.KeyValueStore, finder Finder) *Backend {
+	return &Backend{db: db, finder: finder}
+}
+
+// Wallets implements accounts.Backend, returning the multisig wallets added.
+func (b *Backend) Wallets() []accounts.Wallet {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	cpy := make([]accounts.Wallet, len(b.wallets))
+	copy(cpy, b.wallets)
+	return cpy
+}
+
+// Subscribe implements accounts.Backend, creating an async subscription to
+// receive notifications on the addition or removal of multisig wallets.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return b.updateScope.Track(b.updateFeed.Subscribe(sink))
+}
+
+// Add creates a multisig wallet of the given configuration, loading its stored
+// proposals, and adds it to the backend. Adding a multisig account again
+// replaces its wallet.
+func (b *Backend) Add(config Config) (*Wallet, error) {
+	wallet, err := NewWallet(config, b.db, b.finder)
+	if err != nil {
+		return nil, err
+	}
+	b.lock.Lock()
+	var dropped accounts.Wallet
+	for i, w := range b.wallets {
+		if w.URL() == wallet.URL() {
+			dropped = w
+			b.wallets = append(b.wallets[:i], b.wallets[i+1:]...)
+			break
+		}
+	}
+	b.wallets = append(b.wallets, wallet)
+	sort.Slice(b.wallets, func(i, j int) bool {
+		return b.wallets[i].URL().Cmp(b.wallets[j].URL()) < 0
+	})
+	b.lock.Unlock()
+
+	if dropped != nil {
+		b.updateFeed.Send(accounts.WalletEvent{Wallet: dropped, Kind: accounts.WalletDropped})
+	}
+	b.updateFeed.Send(accounts.WalletEvent{Wallet: wallet, Kind: accounts.WalletArrived})
+	return wallet, nil
+}
+
+// Remove drops the wallet of a multisig account from the backend. Its proposals
+// stay stored in the database.
+func (b *Backend) Remove(url accounts.URL) {
+	b.lock.Lock()
+	var dropped accounts.Wallet
+	for i, w := range b.wallets {
+		if w.URL() == url {
+			dropped = w
+			b.wallets = append(b.wallets[:i], b.wallets[i+1:]...)
+			break
+		}
+	}
+	b.lock.Unlock()
+
+	if dropped != nil {
+		b.updateFeed.Send(accounts.WalletEvent{Wallet: dropped, Kind: accounts.WalletDropped})
+	}
+}
+
+// Close terminates all the subscriptions of the backend.
+func (b *Backend) Close() {
+	b.updateScope.Close()
+}
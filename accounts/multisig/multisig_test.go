@@ -0,0 +1,366 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package multisig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/abi"
+	"github.com/simplechain-org/client/accounts/keystore"
+	"github.com/simplechain-org/client/accounts/typeddata"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethdb/memorydb"
+)
+
+var testChainID = big.NewInt(1337)
+
+// newTestKeyStore creates a keystore with the given number of accounts, all
+// encrypted with the passphrase "pass", and a manager to find them with.
+func newTestKeyStore(t *testing.T, n int) (*keystore.KeyStore, *accounts.Manager, []common.Address) {
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	addrs := make([]common.Address, n)
+	for i := range addrs {
+		acc, err := ks.NewAccount("pass")
+		if err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		addrs[i] = acc.Address
+	}
+	manager := accounts.NewManager(&accounts.Config{}, ks)
+	t.Cleanup(func() { manager.Close() })
+	return ks, manager, addrs
+}
+
+// Tests that transactions proposed to an executor multisig are signed once
+// enough owners approved them.
+func TestExecutorMultisig(t *testing.T) {
+	ks, manager, addrs := newTestKeyStore(t, 4)
+	owners, executor := addrs[:3], addrs[3]
+
+	db := memorydb.New()
+	config := Config{Owners: owners, Threshold: 2, ChainID: testChainID, Executor: &executor}
+	wallet, err := NewWallet(config, db, manager)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	ready := make(chan *Proposal, 1)
+	sub := wallet.SubscribeReady(ready)
+	defer sub.Unsubscribe()
+
+	ks.Unlock(accounts.Account{Address: owners[0]}, "pass")
+	ks.Unlock(accounts.Account{Address: executor}, "pass")
+
+	// A single unlocked owner doesn't meet the threshold
+	account := wallet.Accounts()[0]
+	tx := types.NewTransaction(7, common.HexToAddress("0xdead"), big.NewInt(100), 21000, big.NewInt(1), nil)
+
+	var terr *ThresholdError
+	if _, err := wallet.SignTx(account, tx, testChainID); !errors.As(err, &terr) || terr.Have != 1 || terr.Need != 2 {
+		t.Fatalf("threshold error mismatch: have %v", err)
+	}
+	if _, err := wallet.SignTx(account, tx, big.NewInt(1)); err == nil {
+		t.Errorf("foreign chain ID accepted")
+	}
+	// Transactions with other gas or fee fields need their own approvals
+	to := common.HexToAddress("0xdead")
+	for _, other := range []*types.Transaction{
+		types.NewTransaction(7, common.HexToAddress("0xdead"), big.NewInt(100), 50000, big.NewInt(1), nil),
+		types.NewTransaction(7, common.HexToAddress("0xdead"), big.NewInt(100), 21000, big.NewInt(2), nil),
+		types.NewTx(&types.DynamicFeeTx{ChainID: testChainID, Nonce: 7, To: &to, Value: big.NewInt(100), Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)}),
+	} {
+		p, err := wallet.ProposeTx(other)
+		if err != nil {
+			t.Fatalf("failed to propose transaction: %v", err)
+		}
+		if p.ID == terr.ID {
+			t.Errorf("gas and fee fields not approved: proposal %x", p.ID)
+		}
+		if err := wallet.Discard(p.ID); err != nil {
+			t.Fatalf("failed to discard proposal: %v", err)
+		}
+	}
+	// A conflicting transaction of the same approved content is rejected
+	conflict := types.NewTx(&types.AccessListTx{ChainID: testChainID, Nonce: 7, To: &to, Value: big.NewInt(100), Gas: 21000, GasPrice: big.NewInt(1)})
+	p, err := wallet.ProposeTx(conflict)
+	if err != nil {
+		t.Fatalf("failed to propose transaction: %v", err)
+	}
+	conflict = types.NewTx(&types.AccessListTx{ChainID: testChainID, Nonce: 7, To: &to, Value: big.NewInt(100), Gas: 21000, GasPrice: big.NewInt(1), AccessList: types.AccessList{{Address: common.Address{0x01}}}})
+	if _, err := wallet.ProposeTx(conflict); err != ErrConflict {
+		t.Errorf("conflict error mismatch: have %v", err)
+	}
+	if err := wallet.Discard(p.ID); err != nil {
+		t.Fatalf("failed to discard proposal: %v", err)
+	}
+	// Once another owner is available, the executor signs the transaction
+	ks.Unlock(accounts.Account{Address: owners[2]}, "pass")
+	signed, err := wallet.SignTx(account, tx, testChainID)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(testChainID), signed); err != nil || from != executor {
+		t.Errorf("sender mismatch: have %x, %v", from, err)
+	}
+	if signed.Hash() == tx.Hash() || signed.Nonce() != 7 {
+		t.Errorf("signed transaction mismatch")
+	}
+	select {
+	case p := <-ready:
+		if p.Signed.Hash() != signed.Hash() {
+			t.Errorf("ready proposal mismatch")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("proposal readiness not announced")
+	}
+	// Reload the wallet and check the proposal was persisted
+	reloaded, err := NewWallet(config, db, manager)
+	if err != nil {
+		t.Fatalf("failed to reload wallet: %v", err)
+	}
+	proposals := reloaded.Proposals()
+	if len(proposals) != 1 || !proposals[0].Ready() || proposals[0].Signed.Hash() != signed.Hash() {
+		t.Fatalf("reloaded proposals mismatch: have %v", proposals)
+	}
+	if want := []common.Address{owners[0], owners[2]}; len(proposals[0].Approvers) != 2 || proposals[0].Approvers[0] != want[0] || proposals[0].Approvers[1] != want[1] {
+		t.Errorf("approvers mismatch: have %v, want %v", proposals[0].Approvers, want)
+	}
+	if err := reloaded.Discard(proposals[0].ID); err != nil {
+		t.Fatalf("failed to discard proposal: %v", err)
+	}
+	if reloaded, _ = NewWallet(config, db, manager); len(reloaded.Proposals()) != 0 {
+		t.Errorf("discarded proposal persisted")
+	}
+}
+
+// selfFinder lists a multisig wallet ahead of the wallets of a manager, as an
+// accounts.Manager does with executors whose URL sorts after the multisig one.
+type selfFinder struct {
+	self    accounts.Wallet
+	manager *accounts.Manager
+}
+
+func (f *selfFinder) Wallets() []accounts.Wallet {
+	return append([]accounts.Wallet{f.self}, f.manager.Wallets()...)
+}
+
+// Tests that executor multisigs don't mistake themselves for the wallet of the
+// executor, which would recurse into proposing the transaction again.
+func TestExecutorFindsItself(t *testing.T) {
+	ks, manager, addrs := newTestKeyStore(t, 2)
+	owner, executor := addrs[0], addrs[1]
+	for _, addr := range addrs {
+		ks.Unlock(accounts.Account{Address: addr}, "pass")
+	}
+	finder := &selfFinder{manager: manager}
+	wallet, err := NewWallet(Config{Owners: []common.Address{owner}, Threshold: 1, ChainID: testChainID, Executor: &executor}, memorydb.New(), finder)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	finder.self = wallet
+
+	tx := types.NewTransaction(0, common.HexToAddress("0xdead"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	done := make(chan error, 1)
+	go func() {
+		signed, err := wallet.SignTx(wallet.Accounts()[0], tx, testChainID)
+		if err == nil {
+			if sender, _ := types.Sender(types.LatestSignerForChainID(testChainID), signed); sender != executor {
+				err = fmt.Errorf("sender mismatch: have %x, want %x", sender, executor)
+			}
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("signing deadlocked")
+	}
+}
+
+// Tests that executors refuse to sign a stored transaction whose fee fields
+// differ from the approved ones.
+func TestExecutorUnapprovedTx(t *testing.T) {
+	ks, manager, addrs := newTestKeyStore(t, 3)
+	owners, executor := addrs[:2], addrs[2]
+	for _, addr := range addrs {
+		ks.Unlock(accounts.Account{Address: addr}, "pass")
+	}
+	db := memorydb.New()
+	config := Config{Owners: owners, Threshold: 2, ChainID: testChainID, Executor: &executor}
+	wallet, err := NewWallet(config, db, manager)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	p, err := wallet.ProposeTx(types.NewTransaction(0, common.HexToAddress("0xdead"), big.NewInt(1), 21000, big.NewInt(1), nil))
+	if err != nil {
+		t.Fatalf("failed to propose transaction: %v", err)
+	}
+	// Approve by the first owner and sign off by the second out of band
+	if _, err := wallet.Approve(p.ID, owners[0]); err != nil {
+		t.Fatalf("failed to approve proposal: %v", err)
+	}
+	td, err := wallet.TypedData(p.ID)
+	if err != nil {
+		t.Fatalf("failed to retrieve typed data: %v", err)
+	}
+	signer, err := manager.Find(accounts.Account{Address: owners[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := typeddata.Sign(signer, accounts.Account{Address: owners[1]}, td)
+	if err != nil {
+		t.Fatalf("failed to sign typed data: %v", err)
+	}
+	// Swap the stored transaction for a pricier one behind the owners' back
+	recs, err := readRecords(db, wallet.address)
+	if err != nil || len(recs) != 1 {
+		t.Fatalf("failed to read records: %v", err)
+	}
+	if recs[0].Tx, err = types.NewTransaction(0, common.HexToAddress("0xdead"), big.NewInt(1), 21000, big.NewInt(1000), nil).MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeRecord(db, wallet.address, recs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if wallet, err = NewWallet(config, db, manager); err != nil {
+		t.Fatalf("failed to reload wallet: %v", err)
+	}
+	// Neither new approvals nor the executor accept the swapped transaction
+	if _, err := wallet.Approve(p.ID, owners[1]); !errors.Is(err, errUnapprovedTx) {
+		t.Errorf("tampered approval error mismatch: have %v, want %v", err, errUnapprovedTx)
+	}
+	if _, err := wallet.AddApproval(p.ID, sig); !errors.Is(err, errUnapprovedTx) {
+		t.Errorf("tampered finalize error mismatch: have %v, want %v", err, errUnapprovedTx)
+	}
+}
+
+// Tests that calls proposed to a contract multisig yield call data carrying the
+// approvals, including ones given out of band.
+func TestContractMultisig(t *testing.T) {
+	_, manager, addrs := newTestKeyStore(t, 3)
+	owners := addrs[:2]
+
+	contract := common.HexToAddress("0xc0ffee")
+	wallet, err := NewWallet(Config{Owners: owners, Threshold: 2, ChainID: testChainID, Contract: &contract}, memorydb.New(), manager)
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	if _, err := wallet.ProposeTx(types.NewTransaction(0, contract, nil, 0, nil, nil)); err != accounts.ErrNotSupported {
+		t.Errorf("transaction proposal error mismatch: have %v", err)
+	}
+	call := Call{To: common.HexToAddress("0xdead"), Value: big.NewInt(5), Data: []byte{0x01, 0x02}, Nonce: 3}
+	p, err := wallet.ProposeCall(call)
+	if err != nil {
+		t.Fatalf("failed to propose call: %v", err)
+	}
+	if p, err = wallet.ApproveWithPassphrase(p.ID, owners[0], "pass"); err != nil || p.Ready() {
+		t.Fatalf("first approval mismatch: ready %v, err %v", p != nil && p.Ready(), err)
+	}
+	if _, err := wallet.Approve(p.ID, addrs[2]); err != ErrNotOwner {
+		t.Errorf("non-owner approval error mismatch: have %v", err)
+	}
+	// Approve by the second owner out of band
+	td, err := wallet.TypedData(p.ID)
+	if err != nil {
+		t.Fatalf("failed to retrieve typed data: %v", err)
+	}
+	if hash, _ := td.Hash(); hash != p.ID {
+		t.Fatalf("typed data hash mismatch: have %x, want %x", hash, p.ID)
+	}
+	outsiderWallet, _ := manager.Find(accounts.Account{Address: addrs[2]})
+	outsider, err := typeddata.SignWithPassphrase(outsiderWallet, accounts.Account{Address: addrs[2]}, "pass", td)
+	if err != nil {
+		t.Fatalf("failed to sign typed data: %v", err)
+	}
+	if _, err := wallet.AddApproval(p.ID, outsider); err != ErrNotOwner {
+		t.Errorf("non-owner signature error mismatch: have %v", err)
+	}
+	ownerWallet, _ := manager.Find(accounts.Account{Address: owners[1]})
+	sig, err := typeddata.SignWithPassphrase(ownerWallet, accounts.Account{Address: owners[1]}, "pass", td)
+	if err != nil {
+		t.Fatalf("failed to sign typed data: %v", err)
+	}
+	if p, err = wallet.AddApproval(p.ID, sig); err != nil || !p.Ready() {
+		t.Fatalf("second approval mismatch: err %v", err)
+	}
+	// Decode the call data and verify the ordered signatures
+	parsed, _ := abi.JSON(strings.NewReader(executeABI))
+	method, err := parsed.MethodById(p.CallData[:4])
+	if err != nil || method.Name != "execute" {
+		t.Fatalf("call data method mismatch: %v", err)
+	}
+	args, err := method.Inputs.Unpack(p.CallData[4:])
+	if err != nil {
+		t.Fatalf("failed to unpack call data: %v", err)
+	}
+	if args[0].(common.Address) != call.To || args[1].(*big.Int).Cmp(call.Value) != 0 || !bytes.Equal(args[2].([]byte), call.Data) {
+		t.Errorf("call mismatch: have %v", args[:3])
+	}
+	sigs := args[3].([]byte)
+	if len(sigs) != 2*crypto.SignatureLength {
+		t.Fatalf("signatures length mismatch: have %d", len(sigs))
+	}
+	var prev common.Address
+	for i := 0; i < 2; i++ {
+		sig := common.CopyBytes(sigs[i*crypto.SignatureLength : (i+1)*crypto.SignatureLength])
+		if v := sig[crypto.RecoveryIDOffset]; v != 27 && v != 28 {
+			t.Errorf("signature %d: V mismatch: have %d", i, v)
+		}
+		sig[crypto.RecoveryIDOffset] -= 27
+		pub, err := crypto.SigToPub(p.ID[:], sig)
+		if err != nil {
+			t.Fatalf("signature %d: recovery failed: %v", i, err)
+		}
+		signer := crypto.PubkeyToAddress(*pub)
+		if bytes.Compare(signer[:], prev[:]) <= 0 {
+			t.Errorf("signature %d: signers not ascending", i)
+		}
+		prev = signer
+	}
+}
+
+// Tests that invalid multisig configurations are rejected.
+func TestInvalidConfig(t *testing.T) {
+	var (
+		a, b = common.HexToAddress("0x01"), common.HexToAddress("0x02")
+		exec = common.HexToAddress("0x03")
+	)
+	tests := []Config{
+		{Owners: []common.Address{a, b}, Threshold: 1, Executor: &exec},                                        // missing chain ID
+		{Owners: []common.Address{a, b}, Threshold: 1, ChainID: testChainID},                                   // missing executor
+		{Owners: []common.Address{a, b}, Threshold: 1, ChainID: testChainID, Executor: &exec, Contract: &exec}, // both modes
+		{Owners: []common.Address{a, b}, Threshold: 0, ChainID: testChainID, Executor: &exec},                  // zero threshold
+		{Owners: []common.Address{a, b}, Threshold: 3, ChainID: testChainID, Executor: &exec},                  // threshold above owners
+		{Owners: []common.Address{a, a}, Threshold: 1, ChainID: testChainID, Executor: &exec},                  // duplicate owner
+	}
+	for i, config := range tests {
+		if _, err := NewWallet(config, memorydb.New(), nil); err == nil {
+			t.Errorf("test %d: invalid config accepted", i)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package multisig
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/rlp"
+)
+
+// proposalPrefix + multisig address + id (hash) -> RLP encoded record
+var proposalPrefix = []byte("multisig-proposal-")
+
+// approval is an owner signature over the approval hash of a proposal.
+type approval struct {
+	Owner     common.Address
+	Signature []byte // 65 byte [R || S || V] signature, V being 0 or 1
+}
+
+// record is the persisted state of a proposal.
+type record struct {
+	ID        common.Hash // Approval hash signed by the owners
+	To        common.Address
+	Value     *big.Int
+	Data      []byte
+	Nonce     uint64
+	Tx        []byte     // Binary encoded unsigned transaction, empty for contract calls
+	Approvals []approval // Owner approvals, in the order they were given
+	Result    []byte     // Binary encoded signed transaction or call data, once the threshold is met
+	Created   uint64     // Time of the proposal, in unix seconds
+}
+
+func proposalKey(wallet common.Address, id common.Hash) []byte {
+	key := append(append([]byte{}, proposalPrefix...), wallet.Bytes()...)
+	return append(key, id.Bytes()...)
+}
+
+// readRecords loads all records of a multisig wallet stored in the database.
+func readRecords(db ethdb.KeyValueStore, wallet common.Address) ([]*record, error) {
+	prefix := append(append([]byte{}, proposalPrefix...), wallet.Bytes()...)
+
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var records []*record
+	for it.Next() {
+		rec := new(record)
+		if err := rlp.DecodeBytes(it.Value(), rec); err != nil {
+			return nil, fmt.Errorf("invalid proposal record %x: %w", it.Key()[len(prefix):], err)
+		}
+		records = append(records, rec)
+	}
+	return records, it.Error()
+}
+
+// writeRecord stores a record in the database.
+func writeRecord(db ethdb.KeyValueWriter, wallet common.Address, rec *record) error {
+	blob, err := rlp.EncodeToBytes(rec)
+	if err != nil {
+		return err
+	}
+	return db.Put(proposalKey(wallet, rec.ID), blob)
+}
+
+// deleteRecord removes a record from the database.
+func deleteRecord(db ethdb.KeyValueWriter, wallet common.Address, id common.Hash) error {
+	return db.Delete(proposalKey(wallet, id))
+}
@@ -0,0 +1,852 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package multisig implements an m-of-n approval wallet on top of single key
+// wallets.
+//
+// Transactions and contract calls are proposed to a multisig wallet and approved
+// by its owners, each signing the EIP-712 hash of the proposal with the wallet
+// holding their account. Owners whose wallets live elsewhere sign the typed data
+// of the proposal out of band. Pending proposals and their approvals are stored
+// in a database, so they survive restarts.
+//
+// Once the threshold of approvals is met, the proposal is finalized depending on
+// the mode of the multisig:
+//
+//   - With an executor account, the proposed transaction is signed by the
+//     executor, which only signs it through the multisig once approved.
+//   - With a multisig contract, the call data executing the proposed call on the
+//     contract is encoded, carrying the signatures of the owners for on-chain
+//     verification.
+package multisig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/abi"
+	"github.com/simplechain-org/client/accounts/typeddata"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/math"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/event"
+	"github.com/simplechain-org/client/log"
+)
+
+// Scheme is the URL scheme of multisig wallets.
+const Scheme = "multisig"
+
+// primaryType is the EIP-712 struct type of proposals.
+const primaryType = "Transaction"
+
+// executeABI is the interface of the method the default encoder packs the call
+// data of contract multisigs for.
+const executeABI = `[{"type":"function","name":"execute","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"signatures","type":"bytes"}],"outputs":[]}]`
+
+var (
+	// ErrUnknownProposal is returned for proposals the wallet doesn't know.
+	ErrUnknownProposal = errors.New("unknown proposal")
+
+	// ErrNotOwner is returned for approvals of accounts which aren't owners of
+	// the multisig.
+	ErrNotOwner = errors.New("not a multisig owner")
+
+	// ErrConflict is returned if a transaction is proposed whose approval hash
+	// matches a pending proposal of a different transaction.
+	ErrConflict = errors.New("conflicting proposal pending")
+
+	// errUnapprovedTx is returned if the stored transaction of a proposal doesn't
+	// match the approval hash signed by the owners.
+	errUnapprovedTx = errors.New("transaction differs from the approved one")
+)
+
+// ThresholdError is returned if a proposal is to be finalized before its
+// approvals meet the threshold.
+type ThresholdError struct {
+	ID   common.Hash // Approval hash of the proposal
+	Have int         // Number of approvals given
+	Need int         // Number of approvals required
+}
+
+// Error implements the standard error interface.
+func (err *ThresholdError) Error() string {
+	return fmt.Sprintf("proposal %x approved by %d of %d required owners", err.ID, err.Have, err.Need)
+}
+
+// Finder lists the wallets to look up the owner and executor accounts in, as
+// accounts.Manager does.
+type Finder interface {
+	Wallets() []accounts.Wallet
+}
+
+// Config contains the settings of a multisig wallet. Exactly one of Executor and
+// Contract must be set.
+type Config struct {
+	Owners    []common.Address // Accounts approving proposals
+	Threshold int              // Number of approvals finalizing a proposal
+	ChainID   *big.Int         // Chain the proposals are signed for
+
+	Executor *common.Address // Account signing approved transactions
+	Contract *common.Address // Contract verifying the approvals on-chain
+
+	// Name and Version of the EIP-712 domain of the approvals, defaulting to
+	// "Multisig" and "1". The domain is bound to the executor or contract address.
+	Name    string
+	Version string
+
+	// Encoder packs the call data executing an approved call on the contract. The
+	// signatures are the concatenated 65 byte [R || S || V] approvals, V being 27
+	// or 28, ordered by ascending owner address. If nil, the call data of
+	//
+	//	execute(address to, uint256 value, bytes data, bytes signatures)
+	//
+	// is packed.
+	Encoder func(call Call, signatures []byte) ([]byte, error)
+}
+
+// Call is the content of a proposal approved by the owners.
+type Call struct {
+	To    common.Address // Zero for contract creations of executors
+	Value *big.Int
+	Data  []byte
+	Nonce uint64 // Nonce of the executor or the contract
+}
+
+// Proposal is a transaction or contract call awaiting or having gathered the
+// approvals of the owners.
+type Proposal struct {
+	ID        common.Hash        // Approval hash signed by the owners
+	Call      Call               // Content of the proposal
+	Tx        *types.Transaction // Proposed unsigned transaction, nil for contract calls
+	Approvers []common.Address   // Owners having approved, in the order they did
+	Signed    *types.Transaction // Executor signed transaction, once approved
+	CallData  []byte             // Call data of the contract, once approved
+	Created   time.Time
+}
+
+// Ready reports whether the proposal gathered the approvals it needs and was
+// finalized.
+func (p *Proposal) Ready() bool {
+	return p.Signed != nil || p.CallData != nil
+}
+
+// Wallet is an accounts.Wallet for an m-of-n multisig account, which is either
+// an executor account or a multisig contract. Only transactions can be signed
+// through the accounts.Wallet interface, and only by executor multisigs, with
+// the approvals the owners' wallets give without further authentication.
+type Wallet struct {
+	config  Config
+	address common.Address          // Executor or contract address
+	owners  map[common.Address]bool // Set of owners
+	encoder func(call Call, signatures []byte) ([]byte, error)
+
+	db     ethdb.KeyValueStore
+	finder Finder
+
+	proposals map[common.Hash]*record // Proposals pending or awaiting removal
+	readyFeed event.Feed              // Event feed to notify finalized proposals
+	scope     event.SubscriptionScope // Subscription scope tracking current live listeners
+	lock      sync.RWMutex            // Protects the proposals
+}
+
+// NewWallet creates a multisig wallet, loading the proposals stored in the
+// database. The finder is used to look up the wallets of the owners and of the
+// executor.
+func NewWallet(config Config, db ethdb.KeyValueStore, finder Finder) (*Wallet, error) {
+	if config.ChainID == nil {
+		return nil, errors.New("missing chain ID")
+	}
+	if (config.Executor == nil) == (config.Contract == nil) {
+		return nil, errors.New("exactly one of executor and contract required")
+	}
+	if config.Threshold < 1 || config.Threshold > len(config.Owners) {
+		return nil, fmt.Errorf("invalid threshold %d of %d owners", config.Threshold, len(config.Owners))
+	}
+	owners := make(map[common.Address]bool, len(config.Owners))
+	for _, owner := range config.Owners {
+		if owners[owner] {
+			return nil, fmt.Errorf("duplicate owner %x", owner)
+		}
+		owners[owner] = true
+	}
+	if config.Name == "" {
+		config.Name = "Multisig"
+	}
+	if config.Version == "" {
+		config.Version = "1"
+	}
+	w := &Wallet{
+		config:    config,
+		owners:    owners,
+		encoder:   config.Encoder,
+		db:        db,
+		finder:    finder,
+		proposals: make(map[common.Hash]*record),
+	}
+	if config.Executor != nil {
+		w.address = *config.Executor
+	} else {
+		w.address = *config.Contract
+	}
+	if w.encoder == nil {
+		parsed, err := abi.JSON(strings.NewReader(executeABI))
+		if err != nil {
+			return nil, err
+		}
+		w.encoder = func(call Call, signatures []byte) ([]byte, error) {
+			return parsed.Pack("execute", call.To, call.Value, call.Data, signatures)
+		}
+	}
+	records, err := readRecords(db, w.address)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		w.proposals[rec.ID] = rec
+	}
+	return w, nil
+}
+
+// Address returns the multisig account: the executor or the contract address.
+func (w *Wallet) Address() common.Address {
+	return w.address
+}
+
+// URL implements accounts.Wallet, returning the URL of the multisig account.
+func (w *Wallet) URL() accounts.URL {
+	return accounts.URL{Scheme: Scheme, Path: fmt.Sprintf("%x", w.address)}
+}
+
+// Status implements accounts.Wallet, returning the threshold and the number of
+// pending proposals.
+func (w *Wallet) Status() (string, error) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	var pending int
+	for _, rec := range w.proposals {
+		if len(rec.Result) == 0 {
+			pending++
+		}
+	}
+	return fmt.Sprintf("%d of %d, %d proposals pending", w.config.Threshold, len(w.config.Owners), pending), nil
+}
+
+// Open implements accounts.Wallet, but is a noop for multisig wallets as the
+// wallets of the owners are opened individually.
+func (w *Wallet) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, but is a noop for multisig wallets.
+func (w *Wallet) Close() error { return nil }
+
+// Accounts implements accounts.Wallet, returning the multisig account.
+func (w *Wallet) Accounts() []accounts.Account {
+	return []accounts.Account{{Address: w.address, URL: w.URL()}}
+}
+
+// Contains implements accounts.Wallet, returning whether the account is the
+// multisig account.
+func (w *Wallet) Contains(account accounts.Account) bool {
+	return account.Address == w.address
+}
+
+// Derive implements accounts.Wallet, but is a noop for multisig wallets as there
+// is no notion of hierarchical account derivation.
+func (w *Wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop for multisig wallets as
+// there is no notion of hierarchical account derivation.
+func (w *Wallet) SelfDerive(bases []accounts.DerivationPath, chain client.ChainStateReader) {
+}
+
+// SignData implements accounts.Wallet, but is not supported as owners only
+// approve transactions and contract calls.
+func (w *Wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, but is not supported as
+// owners only approve transactions and contract calls.
+func (w *Wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignText implements accounts.Wallet, but is not supported as owners only
+// approve transactions and contract calls.
+func (w *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, but is not supported as
+// owners only approve transactions and contract calls.
+func (w *Wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignTx implements accounts.Wallet, proposing the transaction if not done yet
+// and requesting the approvals of the owners whose wallets are available. The
+// transaction signed by the executor is returned once the threshold is met,
+// otherwise a *ThresholdError.
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.signTx(account, nil, tx, chainID)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, identical to SignTx, but the
+// passphrase is used to sign the approved transaction by the executor.
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.signTx(account, &passphrase, tx, chainID)
+}
+
+func (w *Wallet) signTx(account accounts.Account, passphrase *string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if !w.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	if chainID == nil || chainID.Cmp(w.config.ChainID) != 0 {
+		return nil, fmt.Errorf("chain ID %v, multisig configured for %v", chainID, w.config.ChainID)
+	}
+	p, err := w.ProposeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	// Gather the approvals of the owners available without authentication
+	approved := make(map[common.Address]bool)
+	for _, owner := range p.Approvers {
+		approved[owner] = true
+	}
+	for _, owner := range w.config.Owners {
+		if len(approved) >= w.config.Threshold {
+			break
+		}
+		if approved[owner] {
+			continue
+		}
+		if _, err := w.approve(p.ID, owner, nil, false); err != nil {
+			log.Debug("Multisig owner approval unavailable", "id", p.ID, "owner", owner, "err", err)
+			continue
+		}
+		approved[owner] = true
+	}
+	if p, err = w.finalize(p.ID, passphrase); err != nil {
+		return nil, err
+	}
+	return p.Signed, nil
+}
+
+// ProposeTx proposes a transaction of the executor for approval. Proposing a
+// pending transaction again returns its proposal.
+//
+// The owners approve the recipient, value, data and nonce of the transaction,
+// along with its type, gas limit and fee fields. Transactions only differing in
+// the access list conflict with each other.
+func (w *Wallet) ProposeTx(tx *types.Transaction) (*Proposal, error) {
+	if w.config.Executor == nil {
+		return nil, accounts.ErrNotSupported
+	}
+	return w.propose(txCall(tx), tx)
+}
+
+// ProposeCall proposes a call of the multisig contract for approval. Proposing a
+// pending call again returns its proposal.
+func (w *Wallet) ProposeCall(call Call) (*Proposal, error) {
+	if w.config.Contract == nil {
+		return nil, accounts.ErrNotSupported
+	}
+	return w.propose(call, nil)
+}
+
+func (w *Wallet) propose(call Call, tx *types.Transaction) (*Proposal, error) {
+	if call.Value == nil {
+		call.Value = new(big.Int)
+	}
+	id, err := w.typedData(call, tx).Hash()
+	if err != nil {
+		return nil, err
+	}
+	var blob []byte
+	if tx != nil {
+		if blob, err = tx.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if rec, ok := w.proposals[id]; ok {
+		if !bytes.Equal(rec.Tx, blob) {
+			return nil, ErrConflict
+		}
+		return w.proposal(rec)
+	}
+	rec := &record{
+		ID:      id,
+		To:      call.To,
+		Value:   call.Value,
+		Data:    common.CopyBytes(call.Data),
+		Nonce:   call.Nonce,
+		Tx:      blob,
+		Created: uint64(time.Now().Unix()),
+	}
+	if err := writeRecord(w.db, w.address, rec); err != nil {
+		return nil, err
+	}
+	w.proposals[id] = rec
+	log.Info("Multisig proposal created", "wallet", w.address, "id", id, "to", call.To, "nonce", call.Nonce)
+	return w.proposal(rec)
+}
+
+// typedData returns the EIP-712 typed data of a call, which the owners sign. The
+// transaction of executor multisigs adds its type, gas limit and fee fields, the
+// ones not used by its type being zero.
+func (w *Wallet) typedData(call Call, tx *types.Transaction) *typeddata.TypedData {
+	value := call.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	td := &typeddata.TypedData{
+		Types: typeddata.Types{
+			typeddata.DomainType: {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			primaryType: {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: primaryType,
+		Domain: typeddata.Domain{
+			Name:              w.config.Name,
+			Version:           w.config.Version,
+			ChainId:           (*math.HexOrDecimal256)(w.config.ChainID),
+			VerifyingContract: w.address.Hex(),
+		},
+		Message: typeddata.Message{
+			"to":    call.To,
+			"value": value,
+			"data":  call.Data,
+			"nonce": call.Nonce,
+		},
+	}
+	if tx == nil {
+		return td
+	}
+	td.Types[primaryType] = append(td.Types[primaryType],
+		typeddata.Type{Name: "txType", Type: "uint8"},
+		typeddata.Type{Name: "gas", Type: "uint64"},
+		typeddata.Type{Name: "gasPrice", Type: "uint256"},
+		typeddata.Type{Name: "maxFeePerGas", Type: "uint256"},
+		typeddata.Type{Name: "maxPriorityFeePerGas", Type: "uint256"},
+	)
+	gasPrice, feeCap, tipCap := new(big.Int), new(big.Int), new(big.Int)
+	if tx.Type() == types.DynamicFeeTxType {
+		feeCap, tipCap = tx.GasFeeCap(), tx.GasTipCap()
+	} else {
+		gasPrice = tx.GasPrice()
+	}
+	td.Message["txType"] = tx.Type()
+	td.Message["gas"] = tx.Gas()
+	td.Message["gasPrice"] = gasPrice
+	td.Message["maxFeePerGas"] = feeCap
+	td.Message["maxPriorityFeePerGas"] = tipCap
+	return td
+}
+
+// TypedData returns the EIP-712 typed data of a proposal, for owners to approve
+// out of band. The approvals are added with AddApproval. An error is returned if
+// the stored transaction no longer matches the approval hash.
+func (w *Wallet) TypedData(id common.Hash) (*typeddata.TypedData, error) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	rec, ok := w.proposals[id]
+	if !ok {
+		return nil, ErrUnknownProposal
+	}
+	tx, err := recordTx(rec)
+	if err != nil {
+		return nil, err
+	}
+	td := w.typedData(recordCall(rec), tx)
+	if hash, err := td.Hash(); err != nil || hash != id {
+		return nil, errUnapprovedTx
+	}
+	return td, nil
+}
+
+// Proposal returns the proposal with the given approval hash.
+func (w *Wallet) Proposal(id common.Hash) (*Proposal, error) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	rec, ok := w.proposals[id]
+	if !ok {
+		return nil, ErrUnknownProposal
+	}
+	return w.proposal(rec)
+}
+
+// Proposals returns all proposals known to the wallet, oldest first.
+func (w *Wallet) Proposals() []*Proposal {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	proposals := make([]*Proposal, 0, len(w.proposals))
+	for _, rec := range w.proposals {
+		p, err := w.proposal(rec)
+		if err != nil {
+			log.Warn("Invalid multisig proposal", "id", rec.ID, "err", err)
+			continue
+		}
+		proposals = append(proposals, p)
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		if !proposals[i].Created.Equal(proposals[j].Created) {
+			return proposals[i].Created.Before(proposals[j].Created)
+		}
+		return bytes.Compare(proposals[i].ID[:], proposals[j].ID[:]) < 0
+	})
+	return proposals
+}
+
+// Discard removes a proposal, pending or finalized, from the wallet.
+func (w *Wallet) Discard(id common.Hash) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if _, ok := w.proposals[id]; !ok {
+		return ErrUnknownProposal
+	}
+	if err := deleteRecord(w.db, w.address, id); err != nil {
+		return err
+	}
+	delete(w.proposals, id)
+	return nil
+}
+
+// Approve requests the approval of a proposal from the wallet holding the owner
+// account. The proposal is finalized once the threshold is met. If the executor
+// fails to sign the approved transaction, the approval is kept and the error
+// returned, the signing may be retried with Finalize.
+func (w *Wallet) Approve(id common.Hash, owner common.Address) (*Proposal, error) {
+	return w.approve(id, owner, nil, true)
+}
+
+// ApproveWithPassphrase is identical to Approve, but also takes a passphrase to
+// unlock the owner account with.
+func (w *Wallet) ApproveWithPassphrase(id common.Hash, owner common.Address, passphrase string) (*Proposal, error) {
+	return w.approve(id, owner, &passphrase, true)
+}
+
+func (w *Wallet) approve(id common.Hash, owner common.Address, passphrase *string, finalize bool) (*Proposal, error) {
+	if !w.owners[owner] {
+		return nil, ErrNotOwner
+	}
+	td, err := w.TypedData(id)
+	if err != nil {
+		return nil, err
+	}
+	// Request the signature without holding the lock, it may need user interaction
+	account := accounts.Account{Address: owner}
+	wallet, err := w.find(account)
+	if err != nil {
+		return nil, err
+	}
+	var sig []byte
+	if passphrase != nil {
+		sig, err = typeddata.SignWithPassphrase(wallet, account, *passphrase, td)
+	} else {
+		sig, err = typeddata.Sign(wallet, account, td)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return w.addApproval(id, owner, sig, finalize)
+}
+
+// AddApproval adds an owner signature over the typed data of a proposal, given
+// out of band. The proposal is finalized once the threshold is met, as done by
+// Approve.
+func (w *Wallet) AddApproval(id common.Hash, signature []byte) (*Proposal, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	pub, err := crypto.SigToPub(id[:], normalizeSig(signature))
+	if err != nil {
+		return nil, err
+	}
+	owner := crypto.PubkeyToAddress(*pub)
+	if !w.owners[owner] {
+		return nil, ErrNotOwner
+	}
+	return w.addApproval(id, owner, signature, true)
+}
+
+// addApproval verifies and stores the approval of an owner, finalizing the
+// proposal if requested and the threshold is met.
+func (w *Wallet) addApproval(id common.Hash, owner common.Address, signature []byte, finalize bool) (*Proposal, error) {
+	sig := normalizeSig(signature)
+	pub, err := crypto.SigToPub(id[:], sig)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.PubkeyToAddress(*pub) != owner {
+		return nil, fmt.Errorf("approval not signed by owner %x", owner)
+	}
+	w.lock.Lock()
+	rec, ok := w.proposals[id]
+	if !ok {
+		w.lock.Unlock()
+		return nil, ErrUnknownProposal
+	}
+	for _, a := range rec.Approvals {
+		if a.Owner == owner {
+			approvals := len(rec.Approvals)
+			w.lock.Unlock()
+			if finalize && approvals >= w.config.Threshold {
+				return w.finalize(id, nil)
+			}
+			return w.Proposal(id)
+		}
+	}
+	updated := *rec
+	updated.Approvals = append(append([]approval{}, rec.Approvals...), approval{Owner: owner, Signature: sig})
+	if err := writeRecord(w.db, w.address, &updated); err != nil {
+		w.lock.Unlock()
+		return nil, err
+	}
+	w.proposals[id] = &updated
+	w.lock.Unlock()
+
+	log.Info("Multisig proposal approved", "wallet", w.address, "id", id, "owner", owner, "approvals", len(updated.Approvals), "threshold", w.config.Threshold)
+	if finalize && len(updated.Approvals) >= w.config.Threshold {
+		return w.finalize(id, nil)
+	}
+	return w.Proposal(id)
+}
+
+// Finalize completes a proposal whose approvals meet the threshold, signing the
+// transaction by the executor or encoding the call data of the contract. It is
+// done automatically by the approvals meeting the threshold, but may need to be
+// retried if the executor failed to sign. A *ThresholdError is returned if the
+// proposal lacks approvals.
+func (w *Wallet) Finalize(id common.Hash) (*Proposal, error) {
+	return w.finalize(id, nil)
+}
+
+// FinalizeWithPassphrase is identical to Finalize, but also takes a passphrase
+// to unlock the executor account with.
+func (w *Wallet) FinalizeWithPassphrase(id common.Hash, passphrase string) (*Proposal, error) {
+	return w.finalize(id, &passphrase)
+}
+
+func (w *Wallet) finalize(id common.Hash, passphrase *string) (*Proposal, error) {
+	w.lock.Lock()
+	rec, ok := w.proposals[id]
+	if !ok {
+		w.lock.Unlock()
+		return nil, ErrUnknownProposal
+	}
+	if len(rec.Result) > 0 {
+		defer w.lock.Unlock()
+		return w.proposal(rec)
+	}
+	if len(rec.Approvals) < w.config.Threshold {
+		w.lock.Unlock()
+		return nil, &ThresholdError{ID: id, Have: len(rec.Approvals), Need: w.config.Threshold}
+	}
+	w.lock.Unlock()
+
+	// Produce the result without holding the lock, the executor may need user
+	// interaction. Records are never modified in place, so rec stays intact.
+	result, err := w.result(rec, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	w.lock.Lock()
+	if rec, ok = w.proposals[id]; !ok {
+		w.lock.Unlock()
+		return nil, ErrUnknownProposal
+	}
+	if len(rec.Result) > 0 {
+		defer w.lock.Unlock()
+		return w.proposal(rec)
+	}
+	updated := *rec
+	updated.Result = result
+	if err := writeRecord(w.db, w.address, &updated); err != nil {
+		w.lock.Unlock()
+		return nil, err
+	}
+	w.proposals[id] = &updated
+	p, err := w.proposal(&updated)
+	w.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Multisig proposal finalized", "wallet", w.address, "id", id, "approvals", len(updated.Approvals))
+	w.readyFeed.Send(p)
+	return p, nil
+}
+
+// result signs the approved transaction by the executor, or encodes the call
+// data of the contract.
+func (w *Wallet) result(rec *record, passphrase *string) ([]byte, error) {
+	if w.config.Contract != nil {
+		return w.encoder(recordCall(rec), signatures(rec.Approvals))
+	}
+	tx, err := recordTx(rec)
+	if err != nil {
+		return nil, err
+	}
+	// Only sign exactly what the owners approved
+	if id, err := w.typedData(txCall(tx), tx).Hash(); err != nil || id != rec.ID {
+		return nil, errUnapprovedTx
+	}
+	account := accounts.Account{Address: w.address}
+	wallet, err := w.find(account)
+	if err != nil {
+		return nil, err
+	}
+	var signed *types.Transaction
+	if passphrase != nil {
+		signed, err = wallet.SignTxWithPassphrase(account, *passphrase, tx, w.config.ChainID)
+	} else {
+		signed, err = wallet.SignTx(account, tx, w.config.ChainID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("executor signing failed: %w", err)
+	}
+	return signed.MarshalBinary()
+}
+
+// find returns the wallet holding an owner or the executor account. The multisig
+// wallet itself is skipped, as it contains the executor account too but can't
+// sign for it.
+func (w *Wallet) find(account accounts.Account) (accounts.Wallet, error) {
+	for _, wallet := range w.finder.Wallets() {
+		if wallet == accounts.Wallet(w) {
+			continue
+		}
+		if wallet.Contains(account) {
+			return wallet, nil
+		}
+	}
+	return nil, accounts.ErrUnknownAccount
+}
+
+// SubscribeReady creates a subscription to receive the proposals finalized by
+// meeting their threshold.
+func (w *Wallet) SubscribeReady(sink chan<- *Proposal) event.Subscription {
+	return w.scope.Track(w.readyFeed.Subscribe(sink))
+}
+
+// proposal converts a record into its public representation.
+func (w *Wallet) proposal(rec *record) (*Proposal, error) {
+	p := &Proposal{
+		ID:        rec.ID,
+		Call:      recordCall(rec),
+		Approvers: make([]common.Address, len(rec.Approvals)),
+		Created:   time.Unix(int64(rec.Created), 0),
+	}
+	for i, a := range rec.Approvals {
+		p.Approvers[i] = a.Owner
+	}
+	if len(rec.Tx) > 0 {
+		p.Tx = new(types.Transaction)
+		if err := p.Tx.UnmarshalBinary(rec.Tx); err != nil {
+			return nil, err
+		}
+	}
+	if len(rec.Result) > 0 {
+		if w.config.Contract != nil {
+			p.CallData = common.CopyBytes(rec.Result)
+		} else {
+			p.Signed = new(types.Transaction)
+			if err := p.Signed.UnmarshalBinary(rec.Result); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+// txCall returns the call of a transaction proposed to an executor multisig.
+func txCall(tx *types.Transaction) Call {
+	call := Call{Value: tx.Value(), Data: tx.Data(), Nonce: tx.Nonce()}
+	if to := tx.To(); to != nil {
+		call.To = *to
+	}
+	return call
+}
+
+// recordTx decodes the transaction of a proposal record, nil for contract calls.
+func recordTx(rec *record) (*types.Transaction, error) {
+	if len(rec.Tx) == 0 {
+		return nil, nil
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rec.Tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// recordCall returns the call of a proposal record.
+func recordCall(rec *record) Call {
+	return Call{To: rec.To, Value: new(big.Int).Set(rec.Value), Data: common.CopyBytes(rec.Data), Nonce: rec.Nonce}
+}
+
+// signatures concatenates the approvals ordered by ascending owner address, with
+// V in the 27/28 form ecrecover expects.
+func signatures(approvals []approval) []byte {
+	sorted := append([]approval{}, approvals...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Owner[:], sorted[j].Owner[:]) < 0
+	})
+	sigs := make([]byte, 0, len(sorted)*crypto.SignatureLength)
+	for _, a := range sorted {
+		sig := common.CopyBytes(a.Signature)
+		sig[crypto.RecoveryIDOffset] += 27
+		sigs = append(sigs, sig...)
+	}
+	return sigs
+}
+
+// normalizeSig returns a copy of the signature with V being 0 or 1, as wallets
+// may return it in the 27/28 form.
+func normalizeSig(sig []byte) []byte {
+	sig = common.CopyBytes(sig)
+	if len(sig) == crypto.SignatureLength && sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	return sig
+}
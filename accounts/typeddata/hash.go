@@ -0,0 +1,508 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package typeddata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+	"github.com/simplechain-org/client/common/math"
+	"github.com/simplechain-org/client/crypto"
+)
+
+// maxDepth is the maximum nesting of structs and arrays in a message.
+const maxDepth = 64
+
+var (
+	errNotStruct    = errors.New("value is not a struct")
+	errNotArray     = errors.New("value is not an array")
+	errTooDeep      = errors.New("message nested too deep")
+	errMissingValue = errors.New("missing value")
+)
+
+// EncodeType returns the canonical encoding of a struct type: its signature
+// followed by the signatures of all struct types it references, sorted by name.
+//
+// e.g. Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (td *TypedData) EncodeType(primaryType string) string {
+	deps := td.dependencies(primaryType, nil)
+	if len(deps) > 1 {
+		sort.Strings(deps[1:])
+	}
+	var buf strings.Builder
+	for _, dep := range deps {
+		buf.WriteString(dep)
+		buf.WriteByte('(')
+		for i, field := range td.Types[dep] {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(field.Type)
+			buf.WriteByte(' ')
+			buf.WriteString(field.Name)
+		}
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
+
+// dependencies appends the struct types referenced by typ, including itself, to
+// found, unless they are in there already.
+func (td *TypedData) dependencies(typ string, found []string) []string {
+	typ = baseType(typ)
+	for _, dep := range found {
+		if dep == typ {
+			return found
+		}
+	}
+	fields, ok := td.Types[typ]
+	if !ok {
+		return found
+	}
+	found = append(found, typ)
+	for _, field := range fields {
+		found = td.dependencies(field.Type, found)
+	}
+	return found
+}
+
+// TypeHash returns the keccak256 hash of the encoded struct type.
+func (td *TypedData) TypeHash(primaryType string) common.Hash {
+	return crypto.Keccak256Hash([]byte(td.EncodeType(primaryType)))
+}
+
+// EncodeData returns the encoding of a struct value: the type hash followed by
+// the 32 byte encoding of each field. Dynamic values, arrays and nested structs
+// are encoded as the keccak256 hash of their contents.
+func (td *TypedData) EncodeData(primaryType string, data Message) ([]byte, error) {
+	return td.encodeData(primaryType, data, 1)
+}
+
+// HashStruct returns the keccak256 hash of the encoded struct value.
+func (td *TypedData) HashStruct(primaryType string, data Message) (common.Hash, error) {
+	enc, err := td.EncodeData(primaryType, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(enc), nil
+}
+
+// DomainSeparator returns the hash of the domain of the typed data.
+func (td *TypedData) DomainSeparator() (common.Hash, error) {
+	return td.HashStruct(DomainType, td.Domain.Map())
+}
+
+// Payload validates the typed data and returns its encoding as signed by
+// wallets, 0x19 0x01 ‖ domainSeparator ‖ hashStruct(message).
+func (td *TypedData) Payload() ([]byte, error) {
+	if err := td.Validate(); err != nil {
+		return nil, err
+	}
+	domain, err := td.DomainSeparator()
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain: %w", err)
+	}
+	message, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+	payload := make([]byte, 0, 2+2*common.HashLength)
+	payload = append(payload, 0x19, 0x01)
+	payload = append(payload, domain[:]...)
+	return append(payload, message[:]...), nil
+}
+
+// Hash returns the hash signed for the typed data, keccak256 of its payload.
+func (td *TypedData) Hash() (common.Hash, error) {
+	payload, err := td.Payload()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(payload), nil
+}
+
+func (td *TypedData) encodeData(primaryType string, data Message, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return nil, errTooDeep
+	}
+	fields, ok := td.Types[primaryType]
+	if !ok {
+		return nil, fmt.Errorf("undefined type %q", primaryType)
+	}
+	if len(data) > len(fields) {
+		for name := range data {
+			if !hasField(fields, name) {
+				return nil, fmt.Errorf("%s: unknown field %q", primaryType, name)
+			}
+		}
+	}
+	enc := make([]byte, 0, common.HashLength*(len(fields)+1))
+	enc = append(enc, td.TypeHash(primaryType).Bytes()...)
+
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s.%s: %w", primaryType, field.Name, errMissingValue)
+		}
+		fieldEnc, err := td.encodeValue(field.Type, value, depth)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", primaryType, field.Name, err)
+		}
+		enc = append(enc, fieldEnc...)
+	}
+	return enc, nil
+}
+
+// encodeValue returns the 32 byte encoding of a value of the given type.
+func (td *TypedData) encodeValue(typ string, value interface{}, depth int) ([]byte, error) {
+	elem, length, isArray, err := splitArray(typ)
+	if err != nil {
+		return nil, err
+	}
+	if isArray {
+		items, err := arrayItems(value, length)
+		if err != nil {
+			return nil, err
+		}
+		if depth+1 > maxDepth {
+			return nil, errTooDeep
+		}
+		enc := make([]byte, 0, common.HashLength*len(items))
+		for i, item := range items {
+			itemEnc, err := td.encodeValue(elem, item, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			enc = append(enc, itemEnc...)
+		}
+		return crypto.Keccak256(enc), nil
+	}
+	if _, ok := td.Types[typ]; ok {
+		msg, ok := toMessage(value)
+		if !ok {
+			return nil, errNotStruct
+		}
+		enc, err := td.encodeData(typ, msg, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(enc), nil
+	}
+	return encodePrimitive(typ, value)
+}
+
+// encodePrimitive returns the 32 byte encoding of an atomic or dynamic value.
+func encodePrimitive(typ string, value interface{}) ([]byte, error) {
+	switch typ {
+	case "address":
+		addr, err := parseAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		return common.LeftPadBytes(addr.Bytes(), 32), nil
+
+	case "bool":
+		b, err := parseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		enc := make([]byte, 32)
+		if b {
+			enc[31] = 1
+		}
+		return enc, nil
+
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid string value %v", value)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+
+	case "bytes":
+		b, err := parseBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(b), nil
+	}
+	if size, ok := sizedType(typ, "bytes"); ok {
+		b, err := parseBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > size {
+			return nil, fmt.Errorf("%d bytes value exceeds %s", len(b), typ)
+		}
+		return common.RightPadBytes(b, 32), nil
+	}
+	if size, ok := sizedType(typ, "uint"); ok {
+		x, err := parseInteger(value)
+		if err != nil {
+			return nil, err
+		}
+		if x.Sign() < 0 || x.BitLen() > size {
+			return nil, fmt.Errorf("value %v out of %s range", x, typ)
+		}
+		return math.PaddedBigBytes(x, 32), nil
+	}
+	if size, ok := sizedType(typ, "int"); ok {
+		x, err := parseInteger(value)
+		if err != nil {
+			return nil, err
+		}
+		limit := new(big.Int).Lsh(common.Big1, uint(size-1))
+		if x.Cmp(limit) >= 0 || x.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("value %v out of %s range", x, typ)
+		}
+		return math.U256Bytes(new(big.Int).Set(x)), nil
+	}
+	return nil, fmt.Errorf("unknown type %q", typ)
+}
+
+// canonical returns a copy of the typed data with all values of the message in
+// their JSON representation: addresses, bytes and integers as strings, arrays
+// as []interface{} and structs as messages.
+func (td *TypedData) canonical() (*TypedData, error) {
+	// Encode the typed data first to run the full validation of the values
+	if _, err := td.Payload(); err != nil {
+		return nil, err
+	}
+	msg, err := td.canonicalValue(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+	cpy := *td
+	cpy.Message = msg.(Message)
+	return &cpy, nil
+}
+
+func (td *TypedData) canonicalValue(typ string, value interface{}) (interface{}, error) {
+	elem, length, isArray, err := splitArray(typ)
+	if err != nil {
+		return nil, err
+	}
+	if isArray {
+		items, err := arrayItems(value, length)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, len(items))
+		for i, item := range items {
+			if out[i], err = td.canonicalValue(elem, item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	if fields, ok := td.Types[typ]; ok {
+		msg, ok := toMessage(value)
+		if !ok {
+			return nil, errNotStruct
+		}
+		out := make(Message, len(fields))
+		for _, field := range fields {
+			if out[field.Name], err = td.canonicalValue(field.Type, msg[field.Name]); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	switch {
+	case typ == "address":
+		addr, err := parseAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		return addr.Hex(), nil
+	case typ == "bool":
+		return parseBool(value)
+	case typ == "string":
+		return value, nil
+	case strings.HasPrefix(typ, "bytes"):
+		b, err := parseBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return hexutil.Encode(b), nil
+	default:
+		x, err := parseInteger(value)
+		if err != nil {
+			return nil, err
+		}
+		return x.String(), nil
+	}
+}
+
+// hasField reports whether a field of the given name is among fields.
+func hasField(fields []Type, name string) bool {
+	for _, field := range fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// toMessage converts a struct value into a message.
+func toMessage(value interface{}) (Message, bool) {
+	switch v := value.(type) {
+	case Message:
+		return v, true
+	case map[string]interface{}:
+		return Message(v), true
+	}
+	return nil, false
+}
+
+// arrayItems returns the items of an array value, checking the length of
+// fixed size arrays.
+func arrayItems(value interface{}, length int) ([]interface{}, error) {
+	if items, ok := value.([]interface{}); ok {
+		if length >= 0 && len(items) != length {
+			return nil, fmt.Errorf("array of %d items, want %d", len(items), length)
+		}
+		return items, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, errNotArray
+	}
+	if length >= 0 && rv.Len() != length {
+		return nil, fmt.Errorf("array of %d items, want %d", rv.Len(), length)
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, nil
+}
+
+func parseAddress(value interface{}) (common.Address, error) {
+	switch v := value.(type) {
+	case common.Address:
+		return v, nil
+	case *common.Address:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		if common.IsHexAddress(v) {
+			return common.HexToAddress(v), nil
+		}
+	}
+	return common.Address{}, fmt.Errorf("invalid address value %v", value)
+}
+
+func parseBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch v {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid bool value %v", value)
+}
+
+func parseBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case hexutil.Bytes:
+		return v, nil
+	case common.Hash:
+		return v[:], nil
+	case string:
+		b, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytes value %q: %w", v, err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("invalid bytes value %v", value)
+}
+
+// maxSafeFloat is the largest integer float64 values represent exactly.
+const maxSafeFloat = 1 << 53
+
+func parseInteger(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v != nil {
+			return v, nil
+		}
+	case *math.HexOrDecimal256:
+		if v != nil {
+			return (*big.Int)(v), nil
+		}
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int8:
+		return big.NewInt(int64(v)), nil
+	case int16:
+		return big.NewInt(int64(v)), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float64:
+		// JSON numbers decoded without UseNumber, only accept exact integers
+		if v >= -maxSafeFloat && v <= maxSafeFloat && v == float64(int64(v)) {
+			return big.NewInt(int64(v)), nil
+		}
+	case json.Number:
+		return parseIntegerString(string(v))
+	case string:
+		return parseIntegerString(v)
+	}
+	return nil, fmt.Errorf("invalid integer value %v", value)
+}
+
+// parseIntegerString parses a decimal or hex integer, optionally negative.
+func parseIntegerString(s string) (*big.Int, error) {
+	neg := strings.HasPrefix(s, "-")
+	x, ok := math.ParseBig256(strings.TrimPrefix(s, "-"))
+	if !ok || s == "" || s == "-" {
+		return nil, fmt.Errorf("invalid integer value %q", s)
+	}
+	if neg {
+		x.Neg(x)
+	}
+	return x, nil
+}
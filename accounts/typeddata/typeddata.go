@@ -0,0 +1,305 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package typeddata implements hashing and signing of EIP-712 typed structured
+// data.
+//
+// Typed data consists of struct type definitions, a domain separating the
+// signatures of different applications and a message of the primary type. The
+// signed hash is
+//
+//	keccak256(0x19 0x01 ‖ hashStruct(domain) ‖ hashStruct(message))
+//
+// Wallets accept typed data for accounts.MimetypeTypedData signing requests,
+// either in its JSON representation or as the encoded payload returned by
+// TypedData.Payload.
+package typeddata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/common/math"
+)
+
+// DomainType is the name of the struct type defining the fields of the domain.
+const DomainType = "EIP712Domain"
+
+// domainFields are the fields an EIP712Domain may consist of, with their types.
+var domainFields = map[string]string{
+	"name":              "string",
+	"version":           "string",
+	"chainId":           "uint256",
+	"verifyingContract": "address",
+	"salt":              "bytes32",
+}
+
+// identifierRegexp matches valid struct type and field names.
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z_$][a-zA-Z0-9_$]*$`)
+
+// Type is a field of a struct type definition.
+type Type struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types maps the names of struct types to their fields, in declaration order.
+type Types map[string][]Type
+
+// Message is the value of a struct, mapping field names to field values.
+//
+// Integers may be given as *big.Int, Go integers, or decimal or hex strings;
+// addresses as common.Address or hex strings; and bytes as []byte, common.Hash
+// or hex strings. Arrays are slices of their element values and structs nested
+// messages.
+type Message map[string]interface{}
+
+// Domain is the domain of typed data, binding signatures to a specific
+// application, version, chain and contract. Only the fields declared by the
+// EIP712Domain type are set.
+type Domain struct {
+	Name              string                `json:"name,omitempty"`
+	Version           string                `json:"version,omitempty"`
+	ChainId           *math.HexOrDecimal256 `json:"chainId,omitempty"`
+	VerifyingContract string                `json:"verifyingContract,omitempty"`
+	Salt              string                `json:"salt,omitempty"`
+}
+
+// Map returns the domain as a message of the EIP712Domain type.
+func (d *Domain) Map() Message {
+	msg := make(Message)
+	if d.Name != "" {
+		msg["name"] = d.Name
+	}
+	if d.Version != "" {
+		msg["version"] = d.Version
+	}
+	if d.ChainId != nil {
+		msg["chainId"] = (*big.Int)(d.ChainId)
+	}
+	if d.VerifyingContract != "" {
+		msg["verifyingContract"] = d.VerifyingContract
+	}
+	if d.Salt != "" {
+		msg["salt"] = d.Salt
+	}
+	return msg
+}
+
+// TypedData is a typed structured data signing request, as defined by EIP-712.
+type TypedData struct {
+	Types       Types   `json:"types"`
+	PrimaryType string  `json:"primaryType"`
+	Domain      Domain  `json:"domain"`
+	Message     Message `json:"message"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding numbers of the message
+// without loss of precision.
+func (td *TypedData) UnmarshalJSON(input []byte) error {
+	type typedData TypedData
+
+	dec := json.NewDecoder(bytes.NewReader(input))
+	dec.UseNumber()
+
+	var dd typedData
+	if err := dec.Decode(&dd); err != nil {
+		return err
+	}
+	*td = TypedData(dd)
+	return nil
+}
+
+// Validate checks that the type definitions are well formed, that the primary
+// type is defined and that the domain is made up of the standard fields.
+func (td *TypedData) Validate() error {
+	if _, ok := td.Types[DomainType]; !ok {
+		return fmt.Errorf("missing %s type definition", DomainType)
+	}
+	if _, ok := td.Types[td.PrimaryType]; !ok {
+		return fmt.Errorf("primary type %q undefined", td.PrimaryType)
+	}
+	for name, fields := range td.Types {
+		if !identifierRegexp.MatchString(name) || isPrimitive(name) {
+			return fmt.Errorf("invalid type name %q", name)
+		}
+		seen := make(map[string]bool)
+		for _, field := range fields {
+			if !identifierRegexp.MatchString(field.Name) {
+				return fmt.Errorf("%s: invalid field name %q", name, field.Name)
+			}
+			if seen[field.Name] {
+				return fmt.Errorf("%s: duplicate field %q", name, field.Name)
+			}
+			seen[field.Name] = true
+
+			if err := td.validateType(field.Type); err != nil {
+				return fmt.Errorf("%s.%s: %w", name, field.Name, err)
+			}
+		}
+	}
+	for _, field := range td.Types[DomainType] {
+		typ, ok := domainFields[field.Name]
+		if !ok {
+			return fmt.Errorf("%s: unknown field %q", DomainType, field.Name)
+		}
+		if field.Type != typ {
+			return fmt.Errorf("%s.%s: type %s, want %s", DomainType, field.Name, field.Type, typ)
+		}
+	}
+	return nil
+}
+
+// validateType checks that typ names a primitive or defined struct type, or
+// arrays of them.
+func (td *TypedData) validateType(typ string) error {
+	for {
+		elem, length, ok, err := splitArray(typ)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if length == 0 {
+			return fmt.Errorf("zero length array type %q", typ)
+		}
+		typ = elem
+	}
+	if isPrimitive(typ) {
+		return nil
+	}
+	if _, ok := td.Types[typ]; !ok {
+		return fmt.Errorf("undefined type %q", typ)
+	}
+	return nil
+}
+
+// isPrimitive reports whether typ is one of the atomic or dynamic types of
+// EIP-712.
+func isPrimitive(typ string) bool {
+	switch typ {
+	case "address", "bool", "string", "bytes":
+		return true
+	}
+	if size, ok := sizedType(typ, "bytes"); ok {
+		return size >= 1 && size <= 32
+	}
+	if size, ok := sizedType(typ, "uint"); ok {
+		return size >= 8 && size <= 256 && size%8 == 0
+	}
+	if size, ok := sizedType(typ, "int"); ok {
+		return size >= 8 && size <= 256 && size%8 == 0
+	}
+	return false
+}
+
+// sizedType returns the size of a type consisting of the given prefix followed
+// by a canonical decimal number.
+func sizedType(typ string, prefix string) (int, bool) {
+	if !strings.HasPrefix(typ, prefix) {
+		return 0, false
+	}
+	size, err := strconv.Atoi(typ[len(prefix):])
+	if err != nil || strconv.Itoa(size) != typ[len(prefix):] {
+		return 0, false
+	}
+	return size, true
+}
+
+// splitArray splits an array type into its element type and length, which is
+// -1 for dynamic arrays. It reports false for types that aren't arrays.
+func splitArray(typ string) (string, int, bool, error) {
+	if !strings.HasSuffix(typ, "]") {
+		return "", 0, false, nil
+	}
+	open := strings.LastIndexByte(typ, '[')
+	if open <= 0 {
+		return "", 0, false, fmt.Errorf("malformed array type %q", typ)
+	}
+	if open == len(typ)-2 {
+		return typ[:open], -1, true, nil
+	}
+	length, err := strconv.Atoi(typ[open+1 : len(typ)-1])
+	if err != nil || length < 0 || strconv.Itoa(length) != typ[open+1:len(typ)-1] {
+		return "", 0, false, fmt.Errorf("malformed array type %q", typ)
+	}
+	return typ[:open], length, true, nil
+}
+
+// baseType strips all array suffixes off a type.
+func baseType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+// IsPayload reports whether data is an encoded typed data payload, as returned
+// by TypedData.Payload.
+func IsPayload(data []byte) bool {
+	return len(data) == 66 && data[0] == 0x19 && data[1] == 0x01
+}
+
+// ParsePayload converts the data of an accounts.MimetypeTypedData signing
+// request into the encoded payload, the keccak256 hash of which is signed. The
+// data is either a payload already, or the JSON representation of typed data.
+func ParsePayload(data []byte) ([]byte, error) {
+	if IsPayload(data) {
+		return data, nil
+	}
+	td := new(TypedData)
+	if err := json.Unmarshal(data, td); err != nil {
+		return nil, fmt.Errorf("invalid typed data: %w", err)
+	}
+	return td.Payload()
+}
+
+// Sign requests the wallet to sign the typed data with the given account. The
+// typed data is sent in its JSON representation, so that wallets delegating to
+// external signers can display it. The signature is in the [R || S || V]
+// format where V is 0 or 1.
+func Sign(wallet accounts.Wallet, account accounts.Account, td *TypedData) ([]byte, error) {
+	canonical, err := td.canonical()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SignData(account, accounts.MimetypeTypedData, data)
+}
+
+// SignWithPassphrase is identical to Sign, but also takes a passphrase to
+// unlock the account with.
+func SignWithPassphrase(wallet accounts.Wallet, account accounts.Account, passphrase string, td *TypedData) ([]byte, error) {
+	canonical, err := td.canonical()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SignDataWithPassphrase(account, passphrase, accounts.MimetypeTypedData, data)
+}
@@ -0,0 +1,306 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package typeddata
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/math"
+	"github.com/simplechain-org/client/crypto"
+)
+
+// mailJSON is the example of the EIP-712 specification.
+const mailJSON = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func mailTypedData(t *testing.T) *TypedData {
+	td := new(TypedData)
+	if err := json.Unmarshal([]byte(mailJSON), td); err != nil {
+		t.Fatalf("failed to decode typed data: %v", err)
+	}
+	return td
+}
+
+func TestMailHashing(t *testing.T) {
+	td := mailTypedData(t)
+
+	if have, want := td.EncodeType("Mail"), "Mail(Person from,Person to,string contents)Person(string name,address wallet)"; have != want {
+		t.Errorf("encoded type mismatch: have %s, want %s", have, want)
+	}
+	if have, want := td.TypeHash("Mail"), common.HexToHash("0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"); have != want {
+		t.Errorf("type hash mismatch: have %x, want %x", have, want)
+	}
+	domain, err := td.DomainSeparator()
+	if err != nil {
+		t.Fatalf("failed to hash domain: %v", err)
+	}
+	if want := common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"); domain != want {
+		t.Errorf("domain separator mismatch: have %x, want %x", domain, want)
+	}
+	message, err := td.HashStruct("Mail", td.Message)
+	if err != nil {
+		t.Fatalf("failed to hash message: %v", err)
+	}
+	if want := common.HexToHash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"); message != want {
+		t.Errorf("message hash mismatch: have %x, want %x", message, want)
+	}
+	hash, err := td.Hash()
+	if err != nil {
+		t.Fatalf("failed to hash typed data: %v", err)
+	}
+	if want := common.HexToHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"); hash != want {
+		t.Errorf("signing hash mismatch: have %x, want %x", hash, want)
+	}
+	// The signature of the specification's example must be reproduced
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	r, s := common.HexToHash("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"), common.HexToHash("0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562")
+	if !bytes.Equal(sig[:32], r[:]) || !bytes.Equal(sig[32:64], s[:]) || sig[64] != 1 {
+		t.Errorf("signature mismatch: have %x", sig)
+	}
+}
+
+// Tests that typed data built from Go values hashes identically to its JSON form.
+func TestGoValues(t *testing.T) {
+	td := &TypedData{
+		Types: Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "version", Type: "string"}, {Name: "chainId", Type: "uint256"}, {Name: "verifyingContract", Type: "address"}},
+			"Person":       {{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}},
+			"Mail":         {{Name: "from", Type: "Person"}, {Name: "to", Type: "Person"}, {Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain: Domain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(1),
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: Message{
+			"from":     Message{"name": "Cow", "wallet": common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")},
+			"to":       map[string]interface{}{"name": "Bob", "wallet": common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")},
+			"contents": "Hello, Bob!",
+		},
+	}
+	have, err := td.Hash()
+	if err != nil {
+		t.Fatalf("failed to hash typed data: %v", err)
+	}
+	want, _ := mailTypedData(t).Hash()
+	if have != want {
+		t.Errorf("hash mismatch: have %x, want %x", have, want)
+	}
+	// The canonical JSON form sent to signers must hash identically too
+	canonical, err := td.canonical()
+	if err != nil {
+		t.Fatalf("failed to canonicalize typed data: %v", err)
+	}
+	blob, err := json.Marshal(canonical)
+	if err != nil {
+		t.Fatalf("failed to encode typed data: %v", err)
+	}
+	payload, err := ParsePayload(blob)
+	if err != nil {
+		t.Fatalf("failed to parse typed data: %v", err)
+	}
+	if !IsPayload(payload) || crypto.Keccak256Hash(payload) != want {
+		t.Errorf("payload mismatch: have %x", payload)
+	}
+}
+
+func TestArraysAndNesting(t *testing.T) {
+	td := &TypedData{
+		Types: Types{
+			"EIP712Domain": {{Name: "chainId", Type: "uint256"}},
+			"Group": {
+				{Name: "name", Type: "string"},
+				{Name: "members", Type: "Person[]"},
+				{Name: "grid", Type: "int8[2][]"},
+				{Name: "tag", Type: "bytes4"},
+				{Name: "blob", Type: "bytes"},
+				{Name: "open", Type: "bool"},
+			},
+			"Person": {{Name: "name", Type: "string"}, {Name: "wallets", Type: "address[]"}},
+		},
+		PrimaryType: "Group",
+		Domain:      Domain{ChainId: math.NewHexOrDecimal256(5)},
+		Message: Message{
+			"name": "Crew",
+			"members": []interface{}{
+				Message{"name": "Alice", "wallets": []common.Address{common.BytesToAddress([]byte{0x01}), common.BytesToAddress([]byte{0x02})}},
+				Message{"name": "Bob", "wallets": []interface{}{}},
+			},
+			"grid": [][]interface{}{{-1, "0x7f"}, {big.NewInt(-128), "3"}},
+			"tag":  "0x01020304",
+			"blob": []byte{0xde, 0xad},
+			"open": true,
+		},
+	}
+	if have, want := td.EncodeType("Group"), "Group(string name,Person[] members,int8[2][] grid,bytes4 tag,bytes blob,bool open)Person(string name,address[] wallets)"; have != want {
+		t.Fatalf("encoded type mismatch: have %s, want %s", have, want)
+	}
+	// Assemble the expected encoding by hand
+	word := func(b ...byte) []byte { return common.LeftPadBytes(b, 32) }
+	minus := func(x int64) []byte { return math.U256Bytes(big.NewInt(x)) }
+	person := func(name string, wallets ...[]byte) []byte {
+		return crypto.Keccak256(
+			td.TypeHash("Person").Bytes(),
+			crypto.Keccak256([]byte(name)),
+			crypto.Keccak256(wallets...),
+		)
+	}
+	want := crypto.Keccak256(
+		td.TypeHash("Group").Bytes(),
+		crypto.Keccak256([]byte("Crew")),
+		crypto.Keccak256(person("Alice", word(0x01), word(0x02)), person("Bob")),
+		crypto.Keccak256(crypto.Keccak256(minus(-1), word(0x7f)), crypto.Keccak256(minus(-128), word(3))),
+		common.RightPadBytes([]byte{1, 2, 3, 4}, 32),
+		crypto.Keccak256([]byte{0xde, 0xad}),
+		word(1),
+	)
+	have, err := td.HashStruct("Group", td.Message)
+	if err != nil {
+		t.Fatalf("failed to hash message: %v", err)
+	}
+	if !bytes.Equal(have[:], want) {
+		t.Errorf("message hash mismatch: have %x, want %x", have, want)
+	}
+	if _, err := td.Payload(); err != nil {
+		t.Errorf("failed to encode payload: %v", err)
+	}
+}
+
+func TestInvalidTypedData(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(td *TypedData)
+		err    string
+	}{
+		{"no domain type", func(td *TypedData) { delete(td.Types, "EIP712Domain") }, "missing EIP712Domain type definition"},
+		{"no primary type", func(td *TypedData) { td.PrimaryType = "Letter" }, `primary type "Letter" undefined`},
+		{"undefined field type", func(td *TypedData) { td.Types["Person"][1].Type = "Wallet" }, `Person.wallet: undefined type "Wallet"`},
+		{"invalid int size", func(td *TypedData) { td.Types["Person"][1].Type = "uint7" }, `Person.wallet: undefined type "uint7"`},
+		{"malformed array", func(td *TypedData) { td.Types["Person"][1].Type = "address[x]" }, `Person.wallet: malformed array type "address[x]"`},
+		{"duplicate field", func(td *TypedData) { td.Types["Person"][1].Name = "name" }, `Person: duplicate field "name"`},
+		{"primitive type name", func(td *TypedData) { td.Types["uint256"] = nil }, `invalid type name "uint256"`},
+		{"unknown domain field", func(td *TypedData) {
+			td.Types["EIP712Domain"] = append(td.Types["EIP712Domain"], Type{Name: "owner", Type: "address"})
+		}, `EIP712Domain: unknown field "owner"`},
+		{"missing domain value", func(td *TypedData) { td.Domain.Version = "" }, "invalid domain: EIP712Domain.version: missing value"},
+		{"missing value", func(td *TypedData) { delete(td.Message, "contents") }, "invalid message: Mail.contents: missing value"},
+		{"unknown value", func(td *TypedData) { td.Message["cc"] = "Carol" }, `invalid message: Mail: unknown field "cc"`},
+		{"invalid address", func(td *TypedData) { td.Message["to"].(map[string]interface{})["wallet"] = "0x1234" }, "invalid message: Mail.to: Person.wallet: invalid address value 0x1234"},
+		{"struct mismatch", func(td *TypedData) { td.Message["to"] = "Bob" }, "invalid message: Mail.to: value is not a struct"},
+	}
+	for _, test := range tests {
+		td := mailTypedData(t)
+		test.modify(td)
+		_, err := td.Payload()
+		if err == nil {
+			t.Errorf("%s: expected error", test.name)
+			continue
+		}
+		if err.Error() != test.err {
+			t.Errorf("%s: error mismatch: have %q, want %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestPrimitiveRanges(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value interface{}
+		ok    bool
+	}{
+		{"uint8", 255, true},
+		{"uint8", 256, false},
+		{"uint8", -1, false},
+		{"int8", -128, true},
+		{"int8", 128, false},
+		{"int256", "-0x8000000000000000000000000000000000000000000000000000000000000000", true},
+		{"uint256", json.Number("115792089237316195423570985008687907853269984665640564039457584007913129639935"), true},
+		{"uint256", json.Number("1.5"), false},
+		{"uint64", float64(1 << 60), false},
+		{"bytes2", "0x0102", true},
+		{"bytes2", "0x010203", false},
+		{"bytes", "deadbeef", false},
+		{"bool", "yes", false},
+		{"string", 1, false},
+	}
+	for _, test := range tests {
+		_, err := encodePrimitive(test.typ, test.value)
+		if (err == nil) != test.ok {
+			t.Errorf("%s %v: error mismatch: have %v, want ok=%v", test.typ, test.value, err, test.ok)
+		}
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	want, err := mailTypedData(t).Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := ParsePayload([]byte(mailJSON))
+	if err != nil {
+		t.Fatalf("failed to parse JSON typed data: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("payload mismatch: have %x, want %x", have, want)
+	}
+	if have, err = ParsePayload(want); err != nil || !bytes.Equal(have, want) {
+		t.Errorf("payload not passed through: %x, %v", have, err)
+	}
+	if _, err = ParsePayload([]byte(strings.Repeat("x", 66))); err == nil {
+		t.Errorf("expected error parsing garbage")
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/karalabe/usb"
 	"github.com/simplechain-org/client"
 	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/accounts/typeddata"
 	"github.com/simplechain-org/client/common"
 	"github.com/simplechain-org/client/core/types"
 	"github.com/simplechain-org/client/crypto"
@@ -526,7 +527,14 @@ func (w *wallet) signHash(account accounts.Account, hash []byte) ([]byte, error)
 
 // SignData signs keccak256(data). The mimetype parameter describes the type of data being signed
 func (w *wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
-
+	// Typed data may be given in its JSON representation, encode it for the device
+	if mimeType == accounts.MimetypeTypedData {
+		payload, err := typeddata.ParsePayload(data)
+		if err != nil {
+			return nil, err
+		}
+		data = payload
+	}
 	// Unless we are doing 712 signing, simply dispatch to signHash
 	if !(mimeType == accounts.MimetypeTypedData && len(data) == 66 && data[0] == 0x19 && data[1] == 0x01) {
 		return w.signHash(account, crypto.Keccak256(data))
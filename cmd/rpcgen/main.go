@@ -0,0 +1,140 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// rpcgen generates typed Go clients from the OpenRPC documents of JSON-RPC servers.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/simplechain-org/client/cmd/utils"
+	"github.com/simplechain-org/client/internal/flags"
+	"github.com/simplechain-org/client/rpc"
+	"github.com/simplechain-org/client/rpc/rpcgen"
+	"github.com/urfave/cli"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+
+	app *cli.App
+
+	docFlag = cli.StringFlag{
+		Name:  "doc",
+		Usage: "Path to the OpenRPC document to generate the client from, - for STDIN",
+	}
+	urlFlag = cli.StringFlag{
+		Name:  "url",
+		Usage: "Endpoint of a server to retrieve the OpenRPC document from via rpc_discover",
+	}
+	pkgFlag = cli.StringFlag{
+		Name:  "pkg",
+		Usage: "Package name to generate the client into",
+	}
+	typeFlag = cli.StringFlag{
+		Name:  "type",
+		Usage: "Type name of the generated client",
+		Value: "Client",
+	}
+	namespacesFlag = cli.StringFlag{
+		Name:  "namespaces",
+		Usage: "Comma separated namespaces to include (default = all)",
+	}
+	outFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Output file for the generated client (default = stdout)",
+	}
+)
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "typed JSON-RPC client generator")
+	app.Flags = []cli.Flag{
+		docFlag,
+		urlFlag,
+		pkgFlag,
+		typeFlag,
+		namespacesFlag,
+		outFlag,
+	}
+	app.Action = utils.MigrateFlags(rpcgenAction)
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+func rpcgenAction(c *cli.Context) error {
+	utils.CheckExclusive(c, docFlag, urlFlag)
+	if c.GlobalString(pkgFlag.Name) == "" {
+		utils.Fatalf("No destination package specified (--pkg)")
+	}
+	doc := new(rpc.OpenRPCDocument)
+	switch {
+	case c.GlobalString(docFlag.Name) != "":
+		var (
+			blob []byte
+			err  error
+		)
+		if input := c.GlobalString(docFlag.Name); input == "-" {
+			blob, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			blob, err = ioutil.ReadFile(input)
+		}
+		if err != nil {
+			utils.Fatalf("Failed to read input document: %v", err)
+		}
+		if err := json.Unmarshal(blob, doc); err != nil {
+			utils.Fatalf("Failed to parse input document: %v", err)
+		}
+	case c.GlobalString(urlFlag.Name) != "":
+		client, err := rpc.Dial(c.GlobalString(urlFlag.Name))
+		if err != nil {
+			utils.Fatalf("Failed to connect to server: %v", err)
+		}
+		defer client.Close()
+		if err := client.Call(doc, "rpc_discover"); err != nil {
+			utils.Fatalf("Failed to retrieve document: %v", err)
+		}
+	default:
+		utils.Fatalf("No input document specified (--doc or --url)")
+	}
+	var namespaces []string
+	if list := c.GlobalString(namespacesFlag.Name); list != "" {
+		namespaces = strings.Split(list, ",")
+	}
+	code, err := rpcgen.Generate(doc, c.GlobalString(pkgFlag.Name), c.GlobalString(typeFlag.Name), namespaces)
+	if err != nil {
+		utils.Fatalf("Failed to generate client: %v", err)
+	}
+	if !c.GlobalIsSet(outFlag.Name) {
+		fmt.Printf("%s\n", code)
+		return nil
+	}
+	if err := ioutil.WriteFile(c.GlobalString(outFlag.Name), []byte(code), 0600); err != nil {
+		utils.Fatalf("Failed to write client: %v", err)
+	}
+	return nil
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
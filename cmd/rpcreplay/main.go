@@ -0,0 +1,102 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+// rpcreplay replays recorded JSON-RPC traffic against a server and reports the
+// responses differing from the recorded ones.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/simplechain-org/client/cmd/utils"
+	"github.com/simplechain-org/client/internal/flags"
+	"github.com/simplechain-org/client/rpc"
+	"github.com/urfave/cli"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+
+	app *cli.App
+
+	recordingFlag = cli.StringFlag{
+		Name:  "recording",
+		Usage: "Path to the JSONL recording to replay",
+	}
+	urlFlag = cli.StringFlag{
+		Name:  "url",
+		Usage: "Endpoint of the server to replay the recording against",
+	}
+)
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "JSON-RPC traffic replay tool")
+	app.Flags = []cli.Flag{
+		recordingFlag,
+		urlFlag,
+	}
+	app.Action = utils.MigrateFlags(replayAction)
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+func replayAction(c *cli.Context) error {
+	if c.GlobalString(recordingFlag.Name) == "" {
+		utils.Fatalf("No recording specified (--recording)")
+	}
+	if c.GlobalString(urlFlag.Name) == "" {
+		utils.Fatalf("No server specified (--url)")
+	}
+	file, err := os.Open(c.GlobalString(recordingFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to open recording: %v", err)
+	}
+	recording, err := rpc.ReadRecording(file)
+	file.Close()
+	if err != nil {
+		utils.Fatalf("Failed to read recording: %v", err)
+	}
+	client, err := rpc.Dial(c.GlobalString(urlFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to connect to server: %v", err)
+	}
+	defer client.Close()
+
+	result, err := rpc.Replay(context.Background(), client, recording)
+	if err != nil {
+		utils.Fatalf("Failed to replay recording: %v", err)
+	}
+	for _, mismatch := range result.Mismatches {
+		fmt.Printf("conn %d: %s %s\n", mismatch.Conn, mismatch.Method, mismatch.Params)
+		fmt.Printf("  recorded: %s\n", mismatch.Recorded)
+		fmt.Printf("  replayed: %s\n", mismatch.Replayed)
+	}
+	fmt.Printf("Replayed %d calls, skipped %d, %d mismatches\n", result.Calls, result.Skipped, len(result.Mismatches))
+	if len(result.Mismatches) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
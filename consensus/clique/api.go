@@ -123,6 +123,92 @@ func (api *API) Discard(address common.Address) {
 	delete(api.clique.proposals, address)
 }
 
+// GetSignerHistory returns the timeline of signer authorization changes within
+// the given block range (inclusive, defaulting to the entire chain), along with
+// the signers whose votes passed each change.
+func (api *API) GetSignerHistory(from, to *rpc.BlockNumber) ([]*SignerChange, error) {
+	first, last, err := api.blockRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	hist, err := api.clique.history(api.chain, first, last)
+	if err != nil {
+		return nil, err
+	}
+	return hist.changes, nil
+}
+
+// GetVoteTallies returns the votes cast within the given block range (inclusive,
+// defaulting to the entire chain) grouped by epoch, along with the tallies left
+// open at the end of each epoch.
+func (api *API) GetVoteTallies(from, to *rpc.BlockNumber) ([]*EpochTally, error) {
+	first, last, err := api.blockRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	hist, err := api.clique.history(api.chain, first, last)
+	if err != nil {
+		return nil, err
+	}
+	return hist.epochs, nil
+}
+
+// GetPendingVotes retrieves the votes not yet passed or discarded at the given
+// block, grouped by the signer that cast them.
+func (api *API) GetPendingVotes(number *rpc.BlockNumber) (map[common.Address][]*Vote, error) {
+	// Retrieve the requested block number (or current if none requested)
+	var header *types.Header
+	if number == nil || *number == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+	// Ensure we have an actually valid block and return the votes from its snapshot
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	snap, err := api.clique.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[common.Address][]*Vote)
+	for _, vote := range snap.Votes {
+		pending[vote.Signer] = append(pending[vote.Signer], vote)
+	}
+	return pending, nil
+}
+
+// blockRange resolves an optional block range, defaulting to the genesis and the
+// current head.
+func (api *API) blockRange(from, to *rpc.BlockNumber) (uint64, uint64, error) {
+	first, last := uint64(0), api.chain.CurrentHeader().Number.Uint64()
+	if from != nil && *from != rpc.LatestBlockNumber {
+		if *from < 0 {
+			return 0, 0, errInvalidHistoryRange
+		}
+		first = uint64(from.Int64())
+	} else if from != nil {
+		first = last
+	}
+	if to != nil && *to != rpc.LatestBlockNumber {
+		if *to < 0 {
+			return 0, 0, errInvalidHistoryRange
+		}
+		last = uint64(to.Int64())
+	}
+	return first, last, nil
+}
+
+// Liveness returns the sealing activity of each signer over the given number of
+// recent blocks, or over the tracked window if none is given: the blocks sealed
+// in-turn and out-of-turn, the in-turn slots missed and the last block sealed.
+func (api *API) Liveness(window *hexutil.Uint64) (*LivenessReport, error) {
+	if window == nil {
+		return api.clique.tracker.update(api.clique, api.chain)
+	}
+	return api.clique.liveness(api.chain, uint64(*window))
+}
+
 type status struct {
 	InturnPercent float64                `json:"inturnPercent"`
 	SigningStatus map[common.Address]int `json:"sealerActivity"`
@@ -177,6 +177,10 @@ type Clique struct {
 	signatures *lru.ARCCache // Signatures of recent blocks to speed up mining
 
 	proposals map[common.Address]bool // Current list of proposals we are pushing
+	tracker   *livenessTracker        // Sealing activity of the signers over recent blocks
+	trackOnce sync.Once               // Ensures the liveness metrics are refreshed by a single loop
+	closeOnce sync.Once               // Ensures the quit channel is only closed once
+	quit      chan struct{}           // Channel to stop the liveness metrics refresh
 
 	signer common.Address // Ethereum address of the signing key
 	signFn SignerFn       // Signer function to authorize hashes with
@@ -194,6 +198,9 @@ func New(config *params.CliqueConfig, db ethdb.Database) *Clique {
 	if conf.Epoch == 0 {
 		conf.Epoch = epochLength
 	}
+	if conf.LivenessWindow == 0 {
+		conf.LivenessWindow = defaultLivenessWindow
+	}
 	// Allocate the snapshot caches and create the engine
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	signatures, _ := lru.NewARC(inmemorySignatures)
@@ -204,6 +211,8 @@ func New(config *params.CliqueConfig, db ethdb.Database) *Clique {
 		recents:    recents,
 		signatures: signatures,
 		proposals:  make(map[common.Address]bool),
+		tracker:    newLivenessTracker(conf.LivenessWindow),
+		quit:       make(chan struct{}),
 	}
 }
 
@@ -690,8 +699,9 @@ func (c *Clique) SealHash(header *types.Header) common.Hash {
 	return SealHash(header)
 }
 
-// Close implements consensus.Engine. It's a noop for clique as there are no background threads.
+// Close implements consensus.Engine, stopping the liveness tracking if started.
 func (c *Clique) Close() error {
+	c.closeOnce.Do(func() { close(c.quit) })
 	return nil
 }
 
@@ -0,0 +1,147 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/consensus"
+	"github.com/simplechain-org/client/core/types"
+)
+
+// maxHistoryBlocks is the maximum number of blocks a single history query may
+// walk, as every block has its authorization votes replayed.
+const maxHistoryBlocks = 100000
+
+// errInvalidHistoryRange is returned if a history query is requested for an
+// empty or oversized block range.
+var errInvalidHistoryRange = errors.New("invalid history range")
+
+// SignerChange is a change in the set of authorized signers, along with the
+// signers whose votes passed it.
+type SignerChange struct {
+	Block     uint64           `json:"block"`     // Block number the change took effect in
+	Hash      common.Hash      `json:"hash"`      // Block hash the change took effect in
+	Address   common.Address   `json:"address"`   // Account whose authorization changed
+	Authorize bool             `json:"authorize"` // Whether the account was authorized or kicked
+	Voters    []common.Address `json:"voters"`    // Signers whose votes passed the change
+}
+
+// EpochTally is the voting activity within a single epoch, limited to the blocks
+// of the queried range.
+type EpochTally struct {
+	Epoch uint64                   `json:"epoch"` // Checkpoint block opening the epoch
+	First uint64                   `json:"first"` // First block of the epoch within the range
+	Last  uint64                   `json:"last"`  // Last block of the epoch within the range
+	Votes []*Vote                  `json:"votes"` // Votes cast, including later superseded ones
+	Tally map[common.Address]Tally `json:"tally"` // Open tallies after the last block
+}
+
+// history is the authorization history of a block range.
+type history struct {
+	changes []*SignerChange
+	epochs  []*EpochTally
+}
+
+// history replays the authorization votes of the given block range (inclusive)
+// of the canonical chain, collecting the signer changes and the per-epoch vote
+// activity.
+func (c *Clique) history(chain consensus.ChainHeaderReader, from, to uint64) (*history, error) {
+	// The genesis block carries no votes, nothing to replay if it's all queried
+	if from == 0 {
+		if to == 0 {
+			return new(history), nil
+		}
+		from = 1
+	}
+	if from > to || to-from >= maxHistoryBlocks {
+		return nil, fmt.Errorf("%w: [%d, %d], at most %d blocks", errInvalidHistoryRange, from, to, maxHistoryBlocks)
+	}
+	parent := chain.GetHeaderByNumber(from - 1)
+	if parent == nil {
+		return nil, errUnknownBlock
+	}
+	snap, err := c.snapshot(chain, parent.Number.Uint64(), parent.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		hist  = new(history)
+		epoch *EpochTally
+	)
+	for number := from; number <= to; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+		if header.ParentHash != snap.Hash {
+			return nil, consensus.ErrUnknownAncestor
+		}
+		checkpoint := number%c.config.Epoch == 0
+		if epoch == nil || checkpoint {
+			epoch = &EpochTally{Epoch: number - number%c.config.Epoch, First: number}
+			hist.epochs = append(hist.epochs, epoch)
+		}
+		signer, err := ecrecover(header, c.signatures)
+		if err != nil {
+			return nil, err
+		}
+		// Votes pending from before a checkpoint are discarded by it
+		pending := snap.Votes
+		if checkpoint {
+			pending = nil
+		}
+		authorize := bytes.Equal(header.Nonce[:], nonceAuthVote)
+		if snap.validVote(header.Coinbase, authorize) {
+			epoch.Votes = append(epoch.Votes, &Vote{
+				Signer:    signer,
+				Block:     number,
+				Address:   header.Coinbase,
+				Authorize: authorize,
+			})
+		}
+		next, err := snap.apply([]*types.Header{header})
+		if err != nil {
+			return nil, err
+		}
+		// If the vote passed, credit everyone who voted along
+		_, before := snap.Signers[header.Coinbase]
+		if _, after := next.Signers[header.Coinbase]; before != after {
+			change := &SignerChange{
+				Block:     number,
+				Hash:      header.Hash(),
+				Address:   header.Coinbase,
+				Authorize: after,
+			}
+			for _, vote := range pending {
+				if vote.Address == header.Coinbase && vote.Authorize == after && vote.Signer != signer {
+					change.Voters = append(change.Voters, vote.Signer)
+				}
+			}
+			change.Voters = append(change.Voters, signer)
+			hist.changes = append(hist.changes, change)
+		}
+		snap = next
+
+		epoch.Last = number
+		epoch.Tally = snap.Tally
+	}
+	return hist, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/params"
+	"github.com/simplechain-org/client/rpc"
+)
+
+// testerChain is a header chain implementing consensus.ChainHeaderReader.
+type testerChain struct {
+	config  *params.ChainConfig
+	headers []*types.Header
+}
+
+func (c *testerChain) Config() *params.ChainConfig  { return c.config }
+func (c *testerChain) CurrentHeader() *types.Header { return c.headers[len(c.headers)-1] }
+
+func (c *testerChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.GetHeaderByNumber(number); header != nil && header.Hash() == hash {
+		return header
+	}
+	return nil
+}
+
+func (c *testerChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+	return c.headers[number]
+}
+
+func (c *testerChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header
+		}
+	}
+	return nil
+}
+
+// testerSigners is a set of signer keys, sorted by address.
+type testerSigners struct {
+	keys  []*ecdsa.PrivateKey
+	addrs []common.Address
+}
+
+// newTesterSigners generates n signer keys, sorted by address like the signers
+// of a snapshot.
+func newTesterSigners(n int) *testerSigners {
+	s := new(testerSigners)
+	for i := 0; i < n; i++ {
+		key, _ := crypto.GenerateKey()
+		s.keys = append(s.keys, key)
+	}
+	sort.Slice(s.keys, func(i, j int) bool {
+		a, b := crypto.PubkeyToAddress(s.keys[i].PublicKey), crypto.PubkeyToAddress(s.keys[j].PublicKey)
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+	for _, key := range s.keys {
+		s.addrs = append(s.addrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return s
+}
+
+// newTesterChain creates a chain whose genesis authorizes the given signers.
+func newTesterChain(signers []common.Address) *testerChain {
+	extra := make([]byte, extraVanity)
+	for _, signer := range signers {
+		extra = append(extra, signer[:]...)
+	}
+	genesis := &types.Header{
+		Number:     big.NewInt(0),
+		Difficulty: big.NewInt(1),
+		GasLimit:   params.GenesisGasLimit,
+		Extra:      append(extra, make([]byte, extraSeal)...),
+	}
+	return &testerChain{config: params.AllCliqueProtocolChanges, headers: []*types.Header{genesis}}
+}
+
+// seal extends the chain by a block sealed with the given key, voting on the
+// beneficiary unless it is the zero address.
+func (c *testerChain) seal(t *testing.T, key *ecdsa.PrivateKey, beneficiary common.Address, authorize bool) *types.Header {
+	parent := c.CurrentHeader()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   beneficiary,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Difficulty: diffNoTurn,
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + 1,
+		Extra:      make([]byte, extraVanity+extraSeal),
+	}
+	if authorize {
+		copy(header.Nonce[:], nonceAuthVote)
+	}
+	sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to seal block %d: %v", header.Number, err)
+	}
+	copy(header.Extra[extraVanity:], sig)
+	c.headers = append(c.headers, header)
+	return header
+}
+
+// Tests that the history, tally and pending vote APIs report the votes cast on
+// a chain, including one only consisting of its genesis block.
+func TestVotingHistory(t *testing.T) {
+	var (
+		signers  = newTesterSigners(3)
+		newcomer = common.HexToAddress("0xdeadbeef")
+		chain    = newTesterChain(signers.addrs)
+		api      = &API{chain: chain, clique: New(params.AllCliqueProtocolChanges.Clique, rawdb.NewMemoryDatabase())}
+	)
+	// A chain without blocks past the genesis has nothing to report
+	if changes, err := api.GetSignerHistory(nil, nil); err != nil || len(changes) != 0 {
+		t.Fatalf("genesis history mismatch: have %v, %v", changes, err)
+	}
+	if tallies, err := api.GetVoteTallies(nil, nil); err != nil || len(tallies) != 0 {
+		t.Fatalf("genesis tallies mismatch: have %v, %v", tallies, err)
+	}
+	if pending, err := api.GetPendingVotes(nil); err != nil || len(pending) != 0 {
+		t.Fatalf("genesis pending votes mismatch: have %v, %v", pending, err)
+	}
+	// Authorize a newcomer by majority, then start kicking the first signer
+	chain.seal(t, signers.keys[0], newcomer, true)
+	passed := chain.seal(t, signers.keys[1], newcomer, true)
+	chain.seal(t, signers.keys[2], signers.addrs[0], false)
+
+	changes, err := api.GetSignerHistory(nil, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve signer history: %v", err)
+	}
+	want := []*SignerChange{{
+		Block:     2,
+		Hash:      passed.Hash(),
+		Address:   newcomer,
+		Authorize: true,
+		Voters:    signers.addrs[:2],
+	}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("signer history mismatch: have %+v, want %+v", changes, want)
+	}
+	tallies, err := api.GetVoteTallies(nil, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve vote tallies: %v", err)
+	}
+	if len(tallies) != 1 || tallies[0].Epoch != 0 || tallies[0].First != 1 || tallies[0].Last != 3 {
+		t.Fatalf("epoch tallies mismatch: have %+v", tallies)
+	}
+	if len(tallies[0].Votes) != 3 {
+		t.Errorf("vote count mismatch: have %d, want 3", len(tallies[0].Votes))
+	}
+	if tally := tallies[0].Tally; len(tally) != 1 || tally[signers.addrs[0]] != (Tally{Authorize: false, Votes: 1}) {
+		t.Errorf("open tally mismatch: have %v", tally)
+	}
+	// Restricting the range to the last block only reports its vote
+	from, to := rpc.BlockNumber(3), rpc.LatestBlockNumber
+	if tallies, err = api.GetVoteTallies(&from, &to); err != nil || len(tallies) != 1 || len(tallies[0].Votes) != 1 {
+		t.Errorf("ranged tallies mismatch: have %v, %v", tallies, err)
+	}
+	if changes, err = api.GetSignerHistory(&from, &to); err != nil || len(changes) != 0 {
+		t.Errorf("ranged history mismatch: have %v, %v", changes, err)
+	}
+	pending, err := api.GetPendingVotes(nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve pending votes: %v", err)
+	}
+	wantPending := map[common.Address][]*Vote{
+		signers.addrs[2]: {{Signer: signers.addrs[2], Block: 3, Address: signers.addrs[0], Authorize: false}},
+	}
+	if !reflect.DeepEqual(pending, wantPending) {
+		t.Errorf("pending votes mismatch: have %v, want %v", pending, wantPending)
+	}
+}
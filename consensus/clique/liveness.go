@@ -0,0 +1,292 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/consensus"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/log"
+	"github.com/simplechain-org/client/metrics"
+)
+
+const (
+	// defaultLivenessWindow is the number of recent blocks the liveness of the
+	// signers is tracked over, unless configured otherwise.
+	defaultLivenessWindow = 1024
+
+	// livenessRefreshInterval is the time between two refreshes of the liveness
+	// gauges of the signers.
+	livenessRefreshInterval = 8 * time.Second
+)
+
+// SignerLiveness is the sealing activity of a single signer within a window of
+// blocks.
+type SignerLiveness struct {
+	InTurn    uint64 `json:"inturn"`    // Number of blocks sealed in-turn
+	OutOfTurn uint64 `json:"outOfTurn"` // Number of blocks sealed out-of-turn
+	Missed    uint64 `json:"missed"`    // Number of in-turn slots sealed by someone else
+	LastSeen  uint64 `json:"lastSeen"`  // Last block sealed within the window, 0 if none
+}
+
+// LivenessReport is the sealing activity of the signers within a window of blocks.
+type LivenessReport struct {
+	From    uint64                             `json:"from"`    // First block of the window
+	To      uint64                             `json:"to"`      // Last block of the window
+	Signers map[common.Address]*SignerLiveness `json:"signers"` // Activity of current and past signers
+}
+
+// livenessRecord is the sealing outcome of a single block.
+type livenessRecord struct {
+	number uint64
+	sealer common.Address // Signer that sealed the block
+	inturn common.Address // Signer whose turn it was to seal the block
+}
+
+// livenessTracker incrementally follows the chain head, keeping the sealing
+// outcome of the latest blocks within its window.
+type livenessTracker struct {
+	window  uint64
+	records []livenessRecord // Contiguous records of the window in ascending order
+	snap    *Snapshot        // Snapshot at the last record
+	gauges  map[common.Address][]metrics.Gauge
+
+	lock sync.Mutex
+}
+
+// newLivenessTracker creates a liveness tracker over the given number of blocks.
+func newLivenessTracker(window uint64) *livenessTracker {
+	return &livenessTracker{
+		window: window,
+		gauges: make(map[common.Address][]metrics.Gauge),
+	}
+}
+
+// update syncs the tracker up to the current head of the chain and returns the
+// liveness report of the signers. If metrics are enabled, the gauges of the
+// signers are updated too.
+func (t *livenessTracker) update(c *Clique, chain consensus.ChainHeaderReader) (*LivenessReport, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	head := chain.CurrentHeader()
+	if head == nil {
+		return nil, errUnknownBlock
+	}
+	// Gather the headers not yet tracked, bailing out on reaching a tracked one
+	var headers []*types.Header
+	for header := head; ; {
+		number := header.Number.Uint64()
+		if t.snap != nil && number == t.snap.Number && header.Hash() == t.snap.Hash {
+			break
+		}
+		if number == 0 || uint64(len(headers)) >= t.window {
+			// Too far behind or reorged beyond the tracked blocks, restart afresh
+			t.records, t.snap = nil, nil
+			break
+		}
+		headers = append(headers, header)
+		if header = chain.GetHeader(header.ParentHash, number-1); header == nil {
+			return nil, consensus.ErrUnknownAncestor
+		}
+	}
+	if t.snap == nil && len(headers) > 0 {
+		oldest := headers[len(headers)-1]
+		snap, err := c.snapshot(chain, oldest.Number.Uint64()-1, oldest.ParentHash, nil)
+		if err != nil {
+			return nil, err
+		}
+		t.snap = snap
+	}
+	// Replay the new headers in ascending order
+	for i := len(headers) - 1; i >= 0; i-- {
+		records, snap, err := trackBlock(t.snap, headers[i], t.records)
+		if err != nil {
+			t.records, t.snap = nil, nil
+			return nil, err
+		}
+		t.records, t.snap = records, snap
+	}
+	if uint64(len(t.records)) > t.window {
+		t.records = t.records[uint64(len(t.records))-t.window:]
+	}
+	report := newLivenessReport(t.snap, t.records)
+	if metrics.Enabled {
+		t.updateGauges(report)
+	}
+	return report, nil
+}
+
+// TrackLiveness starts refreshing the liveness gauges of the signers in the
+// background, off the sealing path, until the engine is closed. It's a noop if
+// metrics are disabled or the tracking was already started.
+func (c *Clique) TrackLiveness(chain consensus.ChainHeaderReader) {
+	if metrics.Enabled {
+		c.trackOnce.Do(func() { go c.livenessLoop(chain) })
+	}
+}
+
+// livenessLoop periodically syncs the liveness tracker up to the head of the
+// chain, refreshing the gauges of the signers, until the engine is closed.
+func (c *Clique) livenessLoop(chain consensus.ChainHeaderReader) {
+	ticker := time.NewTicker(livenessRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.tracker.update(c, chain); err != nil {
+			log.Debug("Failed to update signer liveness", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// updateGauges publishes the liveness report of the signers as metrics gauges,
+// unregistering those of signers no longer reported.
+func (t *livenessTracker) updateGauges(report *LivenessReport) {
+	for signer, liveness := range report.Signers {
+		gauges, ok := t.gauges[signer]
+		if !ok {
+			for _, name := range livenessGaugeNames(signer) {
+				gauges = append(gauges, metrics.GetOrRegisterGauge(name, nil))
+			}
+			t.gauges[signer] = gauges
+		}
+		gauges[0].Update(int64(liveness.InTurn))
+		gauges[1].Update(int64(liveness.OutOfTurn))
+		gauges[2].Update(int64(liveness.Missed))
+		gauges[3].Update(int64(liveness.LastSeen))
+	}
+	for signer := range t.gauges {
+		if _, ok := report.Signers[signer]; !ok {
+			for _, name := range livenessGaugeNames(signer) {
+				metrics.DefaultRegistry.Unregister(name)
+			}
+			delete(t.gauges, signer)
+		}
+	}
+}
+
+// livenessGaugeNames returns the names of the in-turn, out-of-turn, missed and
+// last seen gauges of a signer.
+func livenessGaugeNames(signer common.Address) []string {
+	prefix := fmt.Sprintf("clique/liveness/%s/", signer.Hex())
+	return []string{prefix + "inturn", prefix + "outofturn", prefix + "missed", prefix + "lastseen"}
+}
+
+// trackBlock records the sealing outcome of a header on top of the snapshot of
+// its parent, returning the extended records and the snapshot of the header.
+func trackBlock(snap *Snapshot, header *types.Header, records []livenessRecord) ([]livenessRecord, *Snapshot, error) {
+	signers := snap.signers()
+	if len(signers) == 0 {
+		return nil, nil, errUnauthorizedSigner
+	}
+	number := header.Number.Uint64()
+	sealer, err := ecrecover(header, snap.sigcache)
+	if err != nil {
+		return nil, nil, err
+	}
+	next, err := snap.apply([]*types.Header{header})
+	if err != nil {
+		return nil, nil, err
+	}
+	records = append(records, livenessRecord{
+		number: number,
+		sealer: sealer,
+		inturn: signers[number%uint64(len(signers))],
+	})
+	return records, next, nil
+}
+
+// liveness computes the liveness report over the given number of blocks up to
+// the current head, without touching the tracked state.
+func (c *Clique) liveness(chain consensus.ChainHeaderReader, window uint64) (*LivenessReport, error) {
+	head := chain.CurrentHeader()
+	if head == nil {
+		return nil, errUnknownBlock
+	}
+	if window == 0 || window > maxHistoryBlocks {
+		return nil, fmt.Errorf("%w: window of %d blocks, at most %d", errInvalidHistoryRange, window, maxHistoryBlocks)
+	}
+	headers := make([]*types.Header, 0, window)
+	for header := head; header.Number.Uint64() > 0 && uint64(len(headers)) < window; {
+		headers = append(headers, header)
+		if header = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1); header == nil {
+			return nil, consensus.ErrUnknownAncestor
+		}
+	}
+	if len(headers) == 0 {
+		snap, err := c.snapshot(chain, 0, head.Hash(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return newLivenessReport(snap, nil), nil
+	}
+	oldest := headers[len(headers)-1]
+	snap, err := c.snapshot(chain, oldest.Number.Uint64()-1, oldest.ParentHash, nil)
+	if err != nil {
+		return nil, err
+	}
+	var records []livenessRecord
+	for i := len(headers) - 1; i >= 0; i-- {
+		if records, snap, err = trackBlock(snap, headers[i], records); err != nil {
+			return nil, err
+		}
+	}
+	return newLivenessReport(snap, records), nil
+}
+
+// newLivenessReport aggregates the sealing records of a window into the liveness
+// of each signer, including the current signers that sealed nothing.
+func newLivenessReport(snap *Snapshot, records []livenessRecord) *LivenessReport {
+	report := &LivenessReport{Signers: make(map[common.Address]*SignerLiveness)}
+	if snap != nil {
+		for signer := range snap.Signers {
+			report.Signers[signer] = new(SignerLiveness)
+		}
+		report.From, report.To = snap.Number, snap.Number
+	}
+	if len(records) > 0 {
+		report.From = records[0].number
+	}
+	get := func(signer common.Address) *SignerLiveness {
+		liveness, ok := report.Signers[signer]
+		if !ok {
+			liveness = new(SignerLiveness)
+			report.Signers[signer] = liveness
+		}
+		return liveness
+	}
+	for _, record := range records {
+		sealer := get(record.sealer)
+		sealer.LastSeen = record.number
+		if record.sealer == record.inturn {
+			sealer.InTurn++
+		} else {
+			sealer.OutOfTurn++
+			get(record.inturn).Missed++
+		}
+	}
+	return report
+}
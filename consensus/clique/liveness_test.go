@@ -0,0 +1,120 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/metrics"
+	"github.com/simplechain-org/client/params"
+)
+
+// Tests that the per-signer sealing counters account for the in-turn slots
+// missed by an offline signer, both over the whole chain and a trailing window.
+func TestSignerLiveness(t *testing.T) {
+	var (
+		signers = newTesterSigners(3)
+		chain   = newTesterChain(signers.addrs)
+		engine  = New(&params.CliqueConfig{Epoch: 30000, LivenessWindow: 3}, rawdb.NewMemoryDatabase())
+		api     = &API{chain: chain, clique: engine}
+	)
+	// The last signer is offline, the others take over its slots when allowed
+	a, b, c := signers.addrs[0], signers.addrs[1], signers.addrs[2]
+	for i := 0; i < 3; i++ {
+		chain.seal(t, signers.keys[1], common.Address{}, false) // in-turn on block 1, out-of-turn later
+		chain.seal(t, signers.keys[0], common.Address{}, false) // out-of-turn, except on block 6
+	}
+	// Blocks 1-6 were due to b, c, a, b, c, a and sealed by b, a, b, a, b, a
+	report, err := engine.liveness(chain, maxHistoryBlocks)
+	if err != nil {
+		t.Fatalf("failed to compute liveness: %v", err)
+	}
+	want := map[common.Address]*SignerLiveness{
+		a: {InTurn: 1, OutOfTurn: 2, Missed: 1, LastSeen: 6},
+		b: {InTurn: 1, OutOfTurn: 2, Missed: 1, LastSeen: 5},
+		c: {Missed: 2},
+	}
+	if report.From != 1 || report.To != 6 || !reflect.DeepEqual(report.Signers, want) {
+		t.Errorf("chain liveness mismatch: have %d-%d %v, want 1-6 %v", report.From, report.To, report.Signers, want)
+	}
+	// The configured window only covers blocks 4-6
+	want = map[common.Address]*SignerLiveness{
+		a: {InTurn: 1, OutOfTurn: 1, LastSeen: 6},
+		b: {OutOfTurn: 1, Missed: 1, LastSeen: 5},
+		c: {Missed: 1},
+	}
+	report, err = api.Liveness(nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve tracked liveness: %v", err)
+	}
+	if report.From != 4 || report.To != 6 || !reflect.DeepEqual(report.Signers, want) {
+		t.Errorf("tracked liveness mismatch: have %d-%d %v, want 4-6 %v", report.From, report.To, report.Signers, want)
+	}
+	// Extending the chain slides the tracked window incrementally
+	chain.seal(t, signers.keys[1], common.Address{}, false) // block 7 due to b
+	want = map[common.Address]*SignerLiveness{
+		a: {InTurn: 1, LastSeen: 6},
+		b: {InTurn: 1, OutOfTurn: 1, LastSeen: 7},
+		c: {Missed: 1},
+	}
+	if report, err = api.Liveness(nil); err != nil {
+		t.Fatalf("failed to retrieve tracked liveness: %v", err)
+	}
+	if report.From != 5 || report.To != 7 || !reflect.DeepEqual(report.Signers, want) {
+		t.Errorf("slid liveness mismatch: have %d-%d %v, want 5-7 %v", report.From, report.To, report.Signers, want)
+	}
+	if tracked, _ := engine.liveness(chain, 3); !reflect.DeepEqual(tracked, report) {
+		t.Errorf("incremental liveness mismatch: have %v, want %v", report, tracked)
+	}
+}
+
+// Tests that the liveness gauges are only maintained once tracking is started
+// explicitly, not as a side effect of retrieving the APIs.
+func TestTrackLiveness(t *testing.T) {
+	defer func(enabled bool) { metrics.Enabled = enabled }(metrics.Enabled)
+	metrics.Enabled = true
+
+	var (
+		signers = newTesterSigners(2)
+		chain   = newTesterChain(signers.addrs)
+		engine  = New(&params.CliqueConfig{Epoch: 30000}, rawdb.NewMemoryDatabase())
+	)
+	defer engine.Close()
+
+	chain.seal(t, signers.keys[1], common.Address{}, false)
+	gauge := livenessGaugeNames(signers.addrs[1])[0]
+	defer metrics.DefaultRegistry.Unregister(gauge)
+
+	engine.APIs(chain)
+	time.Sleep(50 * time.Millisecond)
+	if metrics.DefaultRegistry.Get(gauge) != nil {
+		t.Fatalf("liveness tracked without being started")
+	}
+	engine.TrackLiveness(chain)
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if g, ok := metrics.DefaultRegistry.Get(gauge).(metrics.Gauge); ok && g.Value() == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("liveness gauge %s not refreshed", gauge)
+		}
+	}
+}
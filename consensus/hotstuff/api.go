@@ -0,0 +1,140 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hotstuff
+
+import (
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+	"github.com/simplechain-org/client/consensus"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/rpc"
+)
+
+// API is a user facing RPC API to inspect the validators of the HotStuff
+// scheme and to feed votes into the local proposer.
+type API struct {
+	chain    consensus.ChainHeaderReader
+	hotstuff *HotStuff
+}
+
+// header retrieves the requested header (or current if none requested).
+func (api *API) header(number *rpc.BlockNumber) (*types.Header, error) {
+	var header *types.Header
+	if number == nil || *number == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	return header, nil
+}
+
+// GetSnapshot retrieves the validator snapshot at a given block.
+func (api *API) GetSnapshot(number *rpc.BlockNumber) (*Snapshot, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return nil, err
+	}
+	return api.hotstuff.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil)
+}
+
+// GetSnapshotAtHash retrieves the validator snapshot at a given block.
+func (api *API) GetSnapshotAtHash(hash common.Hash) (*Snapshot, error) {
+	header := api.chain.GetHeaderByHash(hash)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	return api.hotstuff.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil)
+}
+
+// GetValidators retrieves the validator set in effect after the specified block.
+func (api *API) GetValidators(number *rpc.BlockNumber) ([]common.Address, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := extractExtra(api.hotstuff.config, header)
+	if err != nil {
+		return nil, err
+	}
+	return ext.Validators, nil
+}
+
+// GetValidatorsAtHash retrieves the validator set in effect after the specified block.
+func (api *API) GetValidatorsAtHash(hash common.Hash) ([]common.Address, error) {
+	header := api.chain.GetHeaderByHash(hash)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	ext, err := extractExtra(api.hotstuff.config, header)
+	if err != nil {
+		return nil, err
+	}
+	return ext.Validators, nil
+}
+
+// GetProposer returns the proposer that sealed the specified block.
+func (api *API) GetProposer(number *rpc.BlockNumber) (common.Address, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return api.hotstuff.Author(header)
+}
+
+// GetLeader returns the validator expected to propose on top of the current
+// head in the given view, by default the one following the head's view.
+func (api *API) GetLeader(view *hexutil.Uint64) (common.Address, error) {
+	header := api.chain.CurrentHeader()
+	if header == nil {
+		return common.Address{}, errUnknownBlock
+	}
+	if view == nil {
+		ext, err := extractExtra(api.hotstuff.config, header)
+		if err != nil {
+			return common.Address{}, err
+		}
+		next := hexutil.Uint64(ext.View + 1)
+		view = &next
+	}
+	return api.hotstuff.Leader(api.chain, header, uint64(*view))
+}
+
+// GetView returns the view number the specified block was proposed in.
+func (api *API) GetView(number *rpc.BlockNumber) (hexutil.Uint64, error) {
+	header, err := api.header(number)
+	if err != nil {
+		return 0, err
+	}
+	ext, err := extractExtra(api.hotstuff.config, header)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(ext.View), nil
+}
+
+// SubmitVote injects a validator's vote on the given block, to be aggregated
+// into the certificate of its child.
+func (api *API) SubmitVote(hash common.Hash, validator common.Address, vote hexutil.Bytes) error {
+	header := api.chain.GetHeaderByHash(hash)
+	if header == nil {
+		return errUnknownBlock
+	}
+	return api.hotstuff.AddVote(api.chain, header, validator, vote)
+}
@@ -0,0 +1,937 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package hotstuff implements the header rules of the HotStuff BFT consensus
+// engine.
+//
+// Every non-genesis block carries, inside its extra-data, the proposer of the
+// block, the validator set in effect for the next block, the view it was
+// proposed in and the quorum certificate of the previous validator set
+// certifying the parent block (chained HotStuff). Votes are either secp256k1 or
+// aggregated BLS signatures, as selected by the crypto configuration.
+package hotstuff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/consensus"
+	"github.com/simplechain-org/client/consensus/misc"
+	"github.com/simplechain-org/client/core/state"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/crypto/bls"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/log"
+	"github.com/simplechain-org/client/params"
+	"github.com/simplechain-org/client/rlp"
+	"github.com/simplechain-org/client/rpc"
+	"github.com/simplechain-org/client/trie"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	checkpointInterval = 1024 // Number of blocks after which to save the validator snapshot to the database
+	inmemorySnapshots  = 128  // Number of recent validator snapshots to keep in memory
+	inmemorySignatures = 4096 // Number of recent block signatures to keep in memory
+	inmemoryVotes      = 128  // Number of recent blocks to keep collected votes for
+)
+
+// HotStuff protocol constants.
+var (
+	epochLength = uint64(30000) // Default number of blocks after which the validator set may change
+
+	uncleHash = types.CalcUncleHash(nil) // Always Keccak256(RLP([])) as uncles are meaningless outside of PoW.
+
+	defaultDifficulty = big.NewInt(1) // Block difficulty, irrelevant in a BFT setting
+)
+
+// Various error messages to mark blocks invalid. These should be private to
+// prevent engine specific errors from being referenced in the remainder of the
+// codebase, inherently breaking if the engine is swapped out. Please put common
+// error types into the consensus package.
+var (
+	// errUnknownBlock is returned when the list of validators is requested for a
+	// block that is not part of the local blockchain.
+	errUnknownBlock = errors.New("unknown block")
+
+	// errUnsupportedCrypto is returned if the configured signature scheme isn't
+	// implemented by the engine.
+	errUnsupportedCrypto = errors.New("unsupported hotstuff crypto scheme")
+
+	// errUnknownLeaderRotation is returned if the configured leader rotation
+	// strategy isn't registered.
+	errUnknownLeaderRotation = errors.New("unknown leader rotation")
+
+	// errMissingVanity is returned if a block's extra-data section is shorter than
+	// 32 bytes, which is required to store the validator vanity.
+	errMissingVanity = errors.New("extra-data 32 byte vanity prefix missing")
+
+	// errMissingSignature is returned if a block's extra-data section doesn't seem
+	// to contain a 65 byte secp256k1 signature.
+	errMissingSignature = errors.New("extra-data 65 byte signature suffix missing")
+
+	// errInvalidMixDigest is returned if a block's mix digest isn't the HotStuff digest.
+	errInvalidMixDigest = errors.New("invalid hotstuff mix digest")
+
+	// errInvalidNonce is returned if a block's nonce is non-zero.
+	errInvalidNonce = errors.New("non-zero nonce")
+
+	// errInvalidUncleHash is returned if a block contains an non-empty uncle list.
+	errInvalidUncleHash = errors.New("non empty uncle hash")
+
+	// errInvalidDifficulty is returned if the difficulty of a block isn't 1.
+	errInvalidDifficulty = errors.New("invalid difficulty")
+
+	// errInvalidTimestamp is returned if the timestamp of a block is not greater
+	// than the previous block's timestamp.
+	errInvalidTimestamp = errors.New("invalid timestamp")
+
+	// errInvalidValidatorChain is returned if a validator snapshot is attempted to
+	// be advanced via out-of-range or non-contiguous headers.
+	errInvalidValidatorChain = errors.New("invalid validator chain")
+
+	// errInvalidView is returned if a block's view doesn't advance past its parent's.
+	errInvalidView = errors.New("invalid view")
+
+	// errMismatchingValidators is returned if a non-epoch block changes the
+	// validator set (or their public keys) of its parent.
+	errMismatchingValidators = errors.New("mismatching validator set on non-epoch block")
+
+	// errInvalidProposer is returned if a block is proposed by someone else than
+	// the leader of its view.
+	errInvalidProposer = errors.New("invalid proposer")
+
+	// errInvalidSigner is returned if the seal of a block wasn't created by its
+	// proposer.
+	errInvalidSigner = errors.New("seal not created by proposer")
+
+	// errInvalidQuorumCert is returned if a block's quorum certificate is missing
+	// or certifies something else than its parent block.
+	errInvalidQuorumCert = errors.New("quorum certificate not certifying parent")
+
+	// errUnauthorizedValidator is returned if a vote is cast by a non-validator.
+	errUnauthorizedValidator = errors.New("unauthorized validator")
+
+	// errUnauthorizedSigner is returned if a vote is requested without a signer
+	// being authorized.
+	errUnauthorizedSigner = errors.New("no signer authorized")
+
+	// errMissingVotingKey is returned if a BLS vote is requested without a BLS
+	// key being authorized.
+	errMissingVotingKey = errors.New("no BLS voting key authorized")
+)
+
+// SignerFn hashes and signs the data to be signed by a backing account.
+type SignerFn func(signer accounts.Account, mimeType string, message []byte) ([]byte, error)
+
+// ecrecover extracts the Ethereum account address from a sealed header.
+func ecrecover(header *types.Header, sigcache *lru.ARCCache) (common.Address, error) {
+	// If the signature's already cached, return that
+	hash := header.Hash()
+	if address, known := sigcache.Get(hash); known {
+		return address.(common.Address), nil
+	}
+	// Retrieve the signature from the header extra-data
+	if len(header.Extra) < types.HotStuffExtraSeal {
+		return common.Address{}, errMissingSignature
+	}
+	signature := header.Extra[len(header.Extra)-types.HotStuffExtraSeal:]
+
+	// Recover the public key and the Ethereum address
+	signer, err := recoverAddress(SealHash(header), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	sigcache.Add(hash, signer)
+	return signer, nil
+}
+
+// recoverAddress recovers the address that signed the given digest.
+func recoverAddress(digest common.Hash, signature []byte) (common.Address, error) {
+	pubkey, err := crypto.Ecrecover(digest.Bytes(), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var signer common.Address
+	copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+	return signer, nil
+}
+
+// HotStuff is the HotStuff BFT consensus engine. It verifies and produces the
+// HotStuff header format, while vote collection is fed in from the outside.
+type HotStuff struct {
+	config   *params.HotStuffConfig // Consensus engine configuration parameters
+	db       ethdb.Database         // Database to store and retrieve validator snapshots
+	rotation LeaderRotation         // Leader election strategy, nil if misconfigured
+
+	recents    *lru.ARCCache // Snapshots for recent block to speed up reorgs
+	signatures *lru.ARCCache // Signatures of recent blocks to speed up mining
+	votes      *lru.ARCCache // Votes collected for recent blocks, keyed by block hash
+
+	signer common.Address // Ethereum address of the signing key
+	signFn SignerFn       // Signer function to authorize hashes with
+	blsKey *bls.SecretKey // BLS key to vote with if the crypto scheme is BLS
+	lock   sync.RWMutex   // Protects the signer fields and the vote sets
+}
+
+// New creates a HotStuff consensus engine.
+func New(config *params.HotStuffConfig, db ethdb.Database) *HotStuff {
+	// Allocate the snapshot and vote caches and create the engine
+	recents, _ := lru.NewARC(inmemorySnapshots)
+	signatures, _ := lru.NewARC(inmemorySignatures)
+	votes, _ := lru.NewARC(inmemoryVotes)
+
+	// Resolve the leader rotation, misconfigurations are reported on verification
+	rotation, err := NewLeaderRotation(config.LeaderRotation)
+	if err != nil {
+		log.Error("Unknown HotStuff leader rotation", "name", config.LeaderRotation)
+	}
+	return &HotStuff{
+		config:     withDefaults(config),
+		db:         db,
+		rotation:   rotation,
+		recents:    recents,
+		signatures: signatures,
+		votes:      votes,
+	}
+}
+
+// Author implements consensus.Engine, returning the Ethereum address recovered
+// from the seal in the header's extra-data section.
+func (h *HotStuff) Author(header *types.Header) (common.Address, error) {
+	return ecrecover(header, h.signatures)
+}
+
+// VerifyHeader checks whether a header conforms to the consensus rules.
+func (h *HotStuff) VerifyHeader(chain consensus.ChainHeaderReader, header *types.Header, seal bool) error {
+	return h.verifyHeader(chain, header, nil)
+}
+
+// VerifyHeaders is similar to VerifyHeader, but verifies a batch of headers. The
+// method returns a quit channel to abort the operations and a results channel to
+// retrieve the async verifications (the order is that of the input slice).
+func (h *HotStuff) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*types.Header, seals []bool) (chan<- struct{}, <-chan error) {
+	abort := make(chan struct{})
+	results := make(chan error, len(headers))
+
+	go func() {
+		for i, header := range headers {
+			err := h.verifyHeader(chain, header, headers[:i])
+
+			select {
+			case <-abort:
+				return
+			case results <- err:
+			}
+		}
+	}()
+	return abort, results
+}
+
+// verifyHeader checks whether a header conforms to the consensus rules. The
+// caller may optionally pass in a batch of parents (ascending order) to avoid
+// looking those up from the database. This is useful for concurrently verifying
+// a batch of new headers.
+func (h *HotStuff) verifyHeader(chain consensus.ChainHeaderReader, header *types.Header, parents []*types.Header) error {
+	if header.Number == nil {
+		return errUnknownBlock
+	}
+	if !h.supportedCrypto() {
+		return errUnsupportedCrypto
+	}
+	if h.rotation == nil {
+		return errUnknownLeaderRotation
+	}
+	number := header.Number.Uint64()
+
+	// Don't waste time checking blocks from the future
+	if header.Time > uint64(time.Now().Unix()) {
+		return consensus.ErrFutureBlock
+	}
+	// Check that the extra-data contains both the vanity and signature
+	if len(header.Extra) < types.HotStuffExtraVanity {
+		return errMissingVanity
+	}
+	if len(header.Extra) < types.HotStuffExtraVanity+types.HotStuffExtraSeal {
+		return errMissingSignature
+	}
+	ext, err := extractExtra(h.config, header)
+	if err != nil {
+		return err
+	}
+	// The genesis block only needs a well formed validator list with proven keys
+	if number == 0 {
+		return ext.VerifyProofs()
+	}
+	// Ensure that the mix digest identifies the block as a HotStuff one
+	if header.MixDigest != types.HotStuffDigest {
+		return errInvalidMixDigest
+	}
+	// Nonces are meaningless in HotStuff, enforce zeroes
+	if header.Nonce != (types.BlockNonce{}) {
+		return errInvalidNonce
+	}
+	// Ensure that the block doesn't contain any uncles which are meaningless in BFT
+	if header.UncleHash != uncleHash {
+		return errInvalidUncleHash
+	}
+	if header.Difficulty == nil || header.Difficulty.Cmp(defaultDifficulty) != 0 {
+		return errInvalidDifficulty
+	}
+	// Verify that the gas limit is <= 2^63-1
+	cap := uint64(0x7fffffffffffffff)
+	if header.GasLimit > cap {
+		return fmt.Errorf("invalid gasLimit: have %v, max %v", header.GasLimit, cap)
+	}
+	// If all checks passed, validate any special fields for hard forks
+	if err := misc.VerifyForkHashes(chain.Config(), header, false); err != nil {
+		return err
+	}
+	// All basic checks passed, verify cascading fields
+	return h.verifyCascadingFields(chain, header, ext, parents)
+}
+
+// verifyCascadingFields verifies all the header fields that are not standalone,
+// rather depend on a batch of previous headers. The caller may optionally pass
+// in a batch of parents (ascending order) to avoid looking those up from the
+// database. This is useful for concurrently verifying a batch of new headers.
+func (h *HotStuff) verifyCascadingFields(chain consensus.ChainHeaderReader, header *types.Header, ext *types.HotStuffExtra, parents []*types.Header) error {
+	number := header.Number.Uint64()
+
+	parent := getAncestor(chain, header, parents)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	if parent.Time >= header.Time {
+		return errInvalidTimestamp
+	}
+	// Verify that the gasUsed is <= gasLimit
+	if header.GasUsed > header.GasLimit {
+		return fmt.Errorf("invalid gasUsed: have %d, gasLimit %d", header.GasUsed, header.GasLimit)
+	}
+	if !chain.Config().IsLondon(header.Number) {
+		// Verify BaseFee not present before EIP-1559 fork.
+		if header.BaseFee != nil {
+			return fmt.Errorf("invalid baseFee before fork: have %d, want <nil>", header.BaseFee)
+		}
+		if err := misc.VerifyGaslimit(parent.GasLimit, header.GasLimit); err != nil {
+			return err
+		}
+	} else if err := misc.VerifyEip1559Header(chain.Config(), parent, header); err != nil {
+		// Verify the header's EIP-1559 attributes.
+		return err
+	}
+	parentExtra, err := extractExtra(h.config, parent)
+	if err != nil {
+		return err
+	}
+	// Views must strictly advance and the validator set may only change on epochs
+	if ext.View <= parentExtra.View {
+		return errInvalidView
+	}
+	if !equalValidatorSets(ext, parentExtra) {
+		if number%h.config.Epoch != 0 {
+			return errMismatchingValidators
+		}
+		// Keys of a new validator set are aggregated later on, prove them first
+		if err := ext.VerifyProofs(); err != nil {
+			return err
+		}
+	}
+	// Ensure the block was proposed by the leader of its view
+	if ext.Proposer != h.leader(chain, ext.View, parent, trimParents(parents), parentExtra.Validators) {
+		return errInvalidProposer
+	}
+	// Verify the parent certificate, the genesis block is never voted on
+	if number == 1 {
+		if ext.QuorumCert != nil {
+			return errInvalidQuorumCert
+		}
+	} else {
+		grandparent := getAncestor(chain, parent, trimParents(parents))
+		if grandparent == nil {
+			return consensus.ErrUnknownAncestor
+		}
+		grandparentExtra, err := extractExtra(h.config, grandparent)
+		if err != nil {
+			return err
+		}
+		if err := verifyQuorumCert(h.config, ext.QuorumCert, parentExtra.View, parent.Hash(), grandparentExtra.Validators, grandparentExtra.PublicKeys); err != nil {
+			return err
+		}
+	}
+	// All basic checks passed, verify the seal and return
+	return h.verifySeal(header, ext)
+}
+
+// getAncestor retrieves the parent of a header, either from the explicitly
+// passed batch of parents (ascending order) or from the database.
+func getAncestor(chain consensus.ChainHeaderReader, header *types.Header, parents []*types.Header) *types.Header {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return nil
+	}
+	var parent *types.Header
+	if len(parents) > 0 {
+		parent = parents[len(parents)-1]
+	} else {
+		parent = chain.GetHeader(header.ParentHash, number-1)
+	}
+	if parent == nil || parent.Number.Uint64() != number-1 || parent.Hash() != header.ParentHash {
+		return nil
+	}
+	return parent
+}
+
+// trimParents drops the last header from a batch of parents, if any.
+func trimParents(parents []*types.Header) []*types.Header {
+	if len(parents) == 0 {
+		return nil
+	}
+	return parents[:len(parents)-1]
+}
+
+// verifySeal checks whether the seal contained in the header was created by
+// the proposer announced in its extra-data.
+func (h *HotStuff) verifySeal(header *types.Header, ext *types.HotStuffExtra) error {
+	signer, err := ecrecover(header, h.signatures)
+	if err != nil {
+		return err
+	}
+	if signer != ext.Proposer {
+		return errInvalidSigner
+	}
+	if header.Signer != (common.Address{}) && header.Signer != signer {
+		return errInvalidSigner
+	}
+	return nil
+}
+
+// snapshot retrieves the validator snapshot at a given point in time.
+func (h *HotStuff) snapshot(chain consensus.ChainHeaderReader, number uint64, hash common.Hash, parents []*types.Header) (*Snapshot, error) {
+	// Search for a snapshot in memory or on disk for checkpoints
+	var (
+		headers []*types.Header
+		snap    *Snapshot
+	)
+	for snap == nil {
+		// If an in-memory snapshot was found, use that
+		if s, ok := h.recents.Get(hash); ok {
+			snap = s.(*Snapshot)
+			break
+		}
+		// If an on-disk checkpoint snapshot can be found, use that
+		if number%checkpointInterval == 0 {
+			if s, err := LoadSnapshot(h.config, h.db, hash); err == nil {
+				log.Trace("Loaded validator snapshot from disk", "number", number, "hash", hash)
+				snap = s
+				break
+			}
+		}
+		// No snapshot for this header, gather the header and move backward
+		var header *types.Header
+		if len(parents) > 0 {
+			// If we have explicit parents, pick from there (enforced)
+			header = parents[len(parents)-1]
+			if header.Hash() != hash || header.Number.Uint64() != number {
+				return nil, consensus.ErrUnknownAncestor
+			}
+			parents = parents[:len(parents)-1]
+		} else {
+			// No explicit parents (or no more left), reach out to the database
+			header = chain.GetHeader(hash, number)
+			if header == nil {
+				return nil, consensus.ErrUnknownAncestor
+			}
+		}
+		// If we're at the genesis, snapshot the initial state. Alternatively if we
+		// have piled up more headers than allowed to be reorged (chain reinit from
+		// a freezer), consider the header trusted and snapshot it.
+		if number == 0 || len(headers) > params.FullImmutabilityThreshold {
+			var parent *types.Header
+			if number > 0 {
+				parent = chain.GetHeader(header.ParentHash, number-1)
+			}
+			s, err := NewSnapshot(h.config, header, parent)
+			if err != nil {
+				return nil, err
+			}
+			snap = s
+			snap.Recents = recentHeaders(chain, header, nil, snap.rotation.Window())
+			if err := snap.Store(h.db); err != nil {
+				return nil, err
+			}
+			log.Info("Stored checkpoint snapshot to disk", "number", number, "hash", hash)
+			break
+		}
+		headers = append(headers, header)
+		number, hash = number-1, header.ParentHash
+	}
+	// Previous snapshot found, apply any pending headers on top of it
+	for i := 0; i < len(headers)/2; i++ {
+		headers[i], headers[len(headers)-1-i] = headers[len(headers)-1-i], headers[i]
+	}
+	snap, err := snap.Apply(headers)
+	if err != nil {
+		return nil, err
+	}
+	h.recents.Add(snap.Hash, snap)
+
+	// If we've generated a new checkpoint snapshot, save to disk
+	if snap.Number%checkpointInterval == 0 && len(headers) > 0 {
+		if err = snap.Store(h.db); err != nil {
+			return nil, err
+		}
+		log.Trace("Stored validator snapshot to disk", "number", snap.Number, "hash", snap.Hash)
+	}
+	return snap, err
+}
+
+// VerifyUncles implements consensus.Engine, always returning an error for any
+// uncles as this consensus mechanism doesn't permit uncles.
+func (h *HotStuff) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
+	if len(block.Uncles()) > 0 {
+		return errors.New("uncles not allowed")
+	}
+	return nil
+}
+
+// Prepare implements consensus.Engine, preparing all the consensus fields of the
+// header for running the transactions on top. The parent block must already be
+// certified by a quorum of votes submitted to the engine.
+func (h *HotStuff) Prepare(chain consensus.ChainHeaderReader, header *types.Header) error {
+	number := header.Number.Uint64()
+	parent := chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	parentExtra, err := extractExtra(h.config, parent)
+	if err != nil {
+		return err
+	}
+	h.lock.RLock()
+	signer := h.signer
+	h.lock.RUnlock()
+
+	ext := &types.HotStuffExtra{
+		Proposer:   signer,
+		Validators: parentExtra.Validators,
+		View:       parentExtra.View + 1,
+		PublicKeys: parentExtra.PublicKeys,
+		Proofs:     parentExtra.Proofs,
+	}
+	// Aggregate the collected votes on the parent into its certificate
+	if number > 1 {
+		grandparent := chain.GetHeader(parent.ParentHash, number-2)
+		if grandparent == nil {
+			return consensus.ErrUnknownAncestor
+		}
+		grandparentExtra, err := extractExtra(h.config, grandparent)
+		if err != nil {
+			return err
+		}
+		if ext.QuorumCert, err = h.aggregateVotes(parentExtra.View, parent.Hash(), grandparentExtra.Validators); err != nil {
+			return err
+		}
+	}
+	header.Nonce = types.BlockNonce{}
+	header.Difficulty = new(big.Int).Set(defaultDifficulty)
+	header.MixDigest = types.HotStuffDigest
+
+	ext.Vanity = header.Extra
+	if len(ext.Vanity) > types.HotStuffExtraVanity {
+		ext.Vanity = ext.Vanity[:types.HotStuffExtraVanity]
+	}
+	if header.Extra, err = ext.MarshalBinary(); err != nil {
+		return err
+	}
+	// Ensure the timestamp moves forward
+	if header.Time <= parent.Time {
+		header.Time = parent.Time + 1
+	}
+	return nil
+}
+
+// Finalize implements consensus.Engine, ensuring no uncles are set, nor block
+// rewards given.
+func (h *HotStuff) Finalize(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header) {
+	// No block rewards in BFT, so the state remains as is and uncles are dropped
+	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
+	header.UncleHash = types.CalcUncleHash(nil)
+}
+
+// FinalizeAndAssemble implements consensus.Engine, ensuring no uncles are set,
+// nor block rewards given, and returns the final block.
+func (h *HotStuff) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header, receipts []*types.Receipt) (*types.Block, error) {
+	// Finalize block
+	h.Finalize(chain, header, state, txs, uncles)
+
+	// Assemble and return the final block for sealing
+	return types.NewBlock(header, txs, nil, receipts, trie.NewStackTrie(nil)), nil
+}
+
+// Authorize injects a private key into the consensus engine to propose new
+// blocks and vote with.
+func (h *HotStuff) Authorize(signer common.Address, signFn SignerFn) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.signer = signer
+	h.signFn = signFn
+}
+
+// AuthorizeBLS injects a BLS secret key into the consensus engine to vote with
+// if the crypto scheme is BLS. Blocks are still sealed with the signer set via
+// Authorize.
+func (h *HotStuff) AuthorizeBLS(key *bls.SecretKey) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.blsKey = key
+}
+
+// Seal implements consensus.Engine, attempting to create a sealed block using
+// the local signing credentials.
+func (h *HotStuff) Seal(chain consensus.ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
+	header := block.Header()
+
+	// Sealing the genesis block is not supported
+	number := header.Number.Uint64()
+	if number == 0 {
+		return errUnknownBlock
+	}
+	// Don't hold the signer fields for the entire sealing procedure
+	h.lock.RLock()
+	signer, signFn := h.signer, h.signFn
+	h.lock.RUnlock()
+
+	// Bail out if we're not the leader of the view
+	if h.rotation == nil {
+		return errUnknownLeaderRotation
+	}
+	parent := chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	parentExtra, err := extractExtra(h.config, parent)
+	if err != nil {
+		return err
+	}
+	ext, err := extractExtra(h.config, header)
+	if err != nil {
+		return err
+	}
+	if ext.Proposer != signer || h.leader(chain, ext.View, parent, nil, parentExtra.Validators) != signer {
+		return errInvalidProposer
+	}
+	// Sign all the things!
+	sighash, err := signFn(accounts.Account{Address: signer}, accounts.MimetypeHotStuff, HotStuffRLP(header))
+	if err != nil {
+		return err
+	}
+	copy(header.Extra[len(header.Extra)-types.HotStuffExtraSeal:], sighash)
+
+	// Wait until sealing is terminated or the block timestamp is reached.
+	delay := time.Unix(int64(header.Time), 0).Sub(time.Now()) // nolint: gosimple
+	log.Trace("Waiting for slot to propose", "delay", common.PrettyDuration(delay))
+	go func() {
+		select {
+		case <-stop:
+			return
+		case <-time.After(delay):
+		}
+
+		select {
+		case results <- block.WithSeal(header):
+		default:
+			log.Warn("Sealing result is not read by miner", "sealhash", SealHash(header))
+		}
+	}()
+
+	return nil
+}
+
+// Vote signs the vote digest of the given header with the local credentials,
+// returning the vote to be gossiped to the next leader. Depending on the crypto
+// scheme, the vote is signed by the authorized signer or BLS key.
+func (h *HotStuff) Vote(header *types.Header) ([]byte, error) {
+	ext, err := extractExtra(h.config, header)
+	if err != nil {
+		return nil, err
+	}
+	h.lock.RLock()
+	signer, signFn, blsKey := h.signer, h.signFn, h.blsKey
+	h.lock.RUnlock()
+
+	if h.config.Crypto == params.HotStuffCryptoBLS {
+		if blsKey == nil {
+			return nil, errMissingVotingKey
+		}
+		digest := types.HotStuffVoteHash(ext.View, header.Hash())
+		return bls.Sign(blsKey, digest[:]).Bytes(), nil
+	}
+	if signFn == nil {
+		return nil, errUnauthorizedSigner
+	}
+	return signFn(accounts.Account{Address: signer}, accounts.MimetypeHotStuff, types.HotStuffVoteRLP(ext.View, header.Hash()))
+}
+
+// AddVote verifies a vote cast by a validator on the given header and stores it
+// for the certificate of the next block.
+func (h *HotStuff) AddVote(chain consensus.ChainHeaderReader, header *types.Header, validator common.Address, vote []byte) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return errUnknownBlock
+	}
+	parent := chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	ext, err := extractExtra(h.config, header)
+	if err != nil {
+		return err
+	}
+	parentExtra, err := extractExtra(h.config, parent)
+	if err != nil {
+		return err
+	}
+	index := validatorIndex(parentExtra.Validators, validator)
+	if index < 0 {
+		return errUnauthorizedValidator
+	}
+	var publicKey []byte
+	if len(parentExtra.PublicKeys) > 0 {
+		publicKey = parentExtra.PublicKeys[index]
+	}
+	if err := types.VerifyQuorumVote(h.config.Crypto, ext.View, header.Hash(), validator, publicKey, vote); err != nil {
+		return err
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	hash := header.Hash()
+	votes, ok := h.votes.Get(hash)
+	if !ok {
+		votes = make(map[common.Address][]byte)
+		h.votes.Add(hash, votes)
+	}
+	votes.(map[common.Address][]byte)[validator] = common.CopyBytes(vote)
+	return nil
+}
+
+// aggregateVotes assembles the certificate of a block from the votes collected
+// for it.
+func (h *HotStuff) aggregateVotes(view uint64, hash common.Hash, validators []common.Address) (*types.QuorumCert, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	var collected map[common.Address][]byte
+	if votes, ok := h.votes.Get(hash); ok {
+		collected = votes.(map[common.Address][]byte)
+	}
+	return types.NewQuorumCert(h.config.Crypto, view, hash, validators, collected)
+}
+
+// verifyQuorumCert checks that the certificate was issued for the block with the
+// given view and hash by a quorum of the voters.
+func verifyQuorumCert(config *params.HotStuffConfig, qc *types.QuorumCert, view uint64, hash common.Hash, voters []common.Address, publicKeys [][]byte) error {
+	if qc == nil || qc.View != view || qc.BlockHash != hash {
+		return errInvalidQuorumCert
+	}
+	return qc.Verify(config.Crypto, voters, publicKeys)
+}
+
+// extractExtra decodes the extra-data of a header, requiring validator public
+// keys if the crypto scheme is BLS.
+func extractExtra(config *params.HotStuffConfig, header *types.Header) (*types.HotStuffExtra, error) {
+	if config.Crypto == params.HotStuffCryptoBLS {
+		return types.ExtractKeyedHotStuffExtra(header)
+	}
+	return types.ExtractHotStuffExtra(header)
+}
+
+// Leader returns the validator responsible for proposing the child of the given
+// parent header in the given view, as elected by the configured leader rotation.
+func (h *HotStuff) Leader(chain consensus.ChainHeaderReader, parent *types.Header, view uint64) (common.Address, error) {
+	if h.rotation == nil {
+		return common.Address{}, errUnknownLeaderRotation
+	}
+	ext, err := extractExtra(h.config, parent)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return h.leader(chain, view, parent, nil, ext.Validators), nil
+}
+
+// leader elects the proposer of the child of parent in the given view. The caller
+// may optionally pass in a batch of the parent's ancestors (ascending order) to
+// avoid looking those up from the database.
+func (h *HotStuff) leader(chain consensus.ChainHeaderReader, view uint64, parent *types.Header, parents []*types.Header, validators []common.Address) common.Address {
+	return h.rotation.Leader(view, validators, recentHeaders(chain, parent, parents, h.rotation.Window()))
+}
+
+// recentHeaders gathers up to n headers ending at the given one, in ascending
+// order, either from the explicitly passed batch of ancestors (ascending order)
+// or from the database.
+func recentHeaders(chain consensus.ChainHeaderReader, header *types.Header, parents []*types.Header, n int) []*types.Header {
+	var headers []*types.Header
+	for header != nil && len(headers) < n {
+		headers = append(headers, header)
+		header = getAncestor(chain, header, parents)
+		parents = trimParents(parents)
+	}
+	for i := 0; i < len(headers)/2; i++ {
+		headers[i], headers[len(headers)-1-i] = headers[len(headers)-1-i], headers[i]
+	}
+	return headers
+}
+
+// leader returns the validator responsible for proposing in the given view in
+// round robin order.
+func leader(view uint64, validators []common.Address) common.Address {
+	if len(validators) == 0 {
+		return common.Address{}
+	}
+	return validators[view%uint64(len(validators))]
+}
+
+// validatorIndex returns the position of address in the validator list, or -1
+// if it's not part of it.
+func validatorIndex(validators []common.Address, address common.Address) int {
+	for i, validator := range validators {
+		if validator == address {
+			return i
+		}
+	}
+	return -1
+}
+
+// equalValidatorSets reports whether two extra-data announce identical validator
+// sets, including their public keys.
+func equalValidatorSets(a, b *types.HotStuffExtra) bool {
+	if len(a.Validators) != len(b.Validators) || len(a.PublicKeys) != len(b.PublicKeys) {
+		return false
+	}
+	for i := range a.Validators {
+		if a.Validators[i] != b.Validators[i] {
+			return false
+		}
+	}
+	for i := range a.PublicKeys {
+		if !bytes.Equal(a.PublicKeys[i], b.PublicKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// supportedCrypto reports whether the configured signature scheme is implemented.
+func (h *HotStuff) supportedCrypto() bool {
+	switch h.config.Crypto {
+	case "", params.HotStuffCryptoSecp256k1, params.HotStuffCryptoBLS:
+		return true
+	}
+	return false
+}
+
+// CalcDifficulty is the difficulty adjustment algorithm. HotStuff blocks are
+// final once certified, so the difficulty is constant.
+func (h *HotStuff) CalcDifficulty(chain consensus.ChainHeaderReader, time uint64, parent *types.Header) *big.Int {
+	return new(big.Int).Set(defaultDifficulty)
+}
+
+// SealHash returns the hash of a block prior to it being sealed.
+func (h *HotStuff) SealHash(header *types.Header) common.Hash {
+	return SealHash(header)
+}
+
+// Close implements consensus.Engine. It's a noop for hotstuff as there are no background threads.
+func (h *HotStuff) Close() error {
+	return nil
+}
+
+// APIs implements consensus.Engine, returning the user facing RPC API to inspect
+// validators and feed votes into the engine.
+func (h *HotStuff) APIs(chain consensus.ChainHeaderReader) []rpc.API {
+	return []rpc.API{{
+		Namespace: "hotstuff",
+		Version:   "1.0",
+		Service:   &API{chain: chain, hotstuff: h},
+		Public:    false,
+	}}
+}
+
+// SealHash returns the hash of a block prior to it being sealed.
+func SealHash(header *types.Header) (hash common.Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	encodeSigHeader(hasher, header)
+	hasher.(crypto.KeccakState).Read(hash[:])
+	return hash
+}
+
+// HotStuffRLP returns the rlp bytes which needs to be signed by the proposer.
+// The RLP to sign consists of the entire header apart from the 65 byte seal
+// contained at the end of the extra data.
+//
+// Note, the method requires the extra data to be at least 65 bytes, otherwise it
+// panics.
+func HotStuffRLP(header *types.Header) []byte {
+	b := new(bytes.Buffer)
+	encodeSigHeader(b, header)
+	return b.Bytes()
+}
+
+func encodeSigHeader(w io.Writer, header *types.Header) {
+	enc := []interface{}{
+		header.ParentHash,
+		header.UncleHash,
+		header.Coinbase,
+		header.Root,
+		header.TxHash,
+		header.ReceiptHash,
+		header.Bloom,
+		header.Difficulty,
+		header.Number,
+		header.GasLimit,
+		header.GasUsed,
+		header.Time,
+		header.Extra[:len(header.Extra)-types.HotStuffExtraSeal], // Yes, this will panic if extra is too short
+		header.MixDigest,
+		header.Nonce,
+	}
+	if header.BaseFee != nil || header.Signer != (common.Address{}) {
+		baseFee := header.BaseFee
+		if baseFee == nil {
+			baseFee = new(big.Int)
+		}
+		enc = append(enc, baseFee)
+	}
+	if header.Signer != (common.Address{}) {
+		enc = append(enc, header.Signer)
+	}
+	if err := rlp.Encode(w, enc); err != nil {
+		panic("can't encode: " + err.Error())
+	}
+}
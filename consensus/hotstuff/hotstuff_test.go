@@ -0,0 +1,392 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hotstuff
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client/accounts"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/crypto/bls"
+	"github.com/simplechain-org/client/params"
+)
+
+// testerChain is a header store implementing consensus.ChainHeaderReader.
+type testerChain struct {
+	config  *params.ChainConfig
+	headers []*types.Header
+}
+
+func (c *testerChain) Config() *params.ChainConfig  { return c.config }
+func (c *testerChain) CurrentHeader() *types.Header { return c.headers[len(c.headers)-1] }
+
+func (c *testerChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if number < uint64(len(c.headers)) && c.headers[number].Hash() == hash {
+		return c.headers[number]
+	}
+	return nil
+}
+
+func (c *testerChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number < uint64(len(c.headers)) {
+		return c.headers[number]
+	}
+	return nil
+}
+
+func (c *testerChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header
+		}
+	}
+	return nil
+}
+
+// testerValidators is a set of validator secp256k1 and BLS keys indexed by address.
+type testerValidators struct {
+	keys    map[common.Address]*ecdsa.PrivateKey
+	blsKeys map[common.Address]*bls.SecretKey
+}
+
+func newTesterValidators(n int) (*testerValidators, []common.Address) {
+	v := &testerValidators{
+		keys:    make(map[common.Address]*ecdsa.PrivateKey),
+		blsKeys: make(map[common.Address]*bls.SecretKey),
+	}
+	addrs := make([]common.Address, n)
+	for i := 0; i < n; i++ {
+		key, _ := crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(key.PublicKey)
+		v.keys[addrs[i]] = key
+		v.blsKeys[addrs[i]], _ = bls.GenerateKey(nil)
+	}
+	return v, addrs
+}
+
+func (v *testerValidators) signFn(addr common.Address) SignerFn {
+	return func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(message), v.keys[addr])
+	}
+}
+
+// authorize switches the engine over to the keys of the given validator.
+func (v *testerValidators) authorize(engine *HotStuff, addr common.Address) {
+	engine.Authorize(addr, v.signFn(addr))
+	engine.AuthorizeBLS(v.blsKeys[addr])
+}
+
+func newTesterConfig() *params.ChainConfig {
+	config := *params.TestChainConfig
+	config.LondonBlock = nil
+	config.Ethash = nil
+	config.HotStuff = &params.HotStuffConfig{Epoch: 10}
+	return &config
+}
+
+func newTesterGenesis(validators []common.Address) *types.Header {
+	extra := make([]byte, types.HotStuffExtraVanity)
+	for _, validator := range validators {
+		extra = append(extra, validator[:]...)
+	}
+	return newTesterGenesisWithExtra(append(extra, make([]byte, types.HotStuffExtraSeal)...))
+}
+
+// newTesterKeyedGenesis creates a genesis header announcing the BLS public keys
+// of the validators too.
+func newTesterKeyedGenesis(t *testing.T, keys *testerValidators, validators []common.Address) *types.Header {
+	ext := &types.HotStuffExtra{Validators: validators}
+	for _, validator := range validators {
+		ext.PublicKeys = append(ext.PublicKeys, keys.blsKeys[validator].PublicKey().Bytes())
+		ext.Proofs = append(ext.Proofs, bls.PopProve(keys.blsKeys[validator]).Bytes())
+	}
+	extra, err := ext.MarshalGenesis()
+	if err != nil {
+		t.Fatalf("failed to encode genesis extra: %v", err)
+	}
+	return newTesterGenesisWithExtra(extra)
+}
+
+func newTesterGenesisWithExtra(extra []byte) *types.Header {
+	return &types.Header{
+		Number:     big.NewInt(0),
+		Difficulty: big.NewInt(1),
+		GasLimit:   params.GenesisGasLimit,
+		Time:       uint64(time.Now().Unix()) - 1000,
+		Extra:      extra,
+	}
+}
+
+// mine extends the chain by a single block proposed by the leader of the next
+// view and certified by the given number of voters.
+func mine(t *testing.T, engine *HotStuff, chain *testerChain, keys *testerValidators, voters int) *types.Header {
+	return mineWithExtra(t, engine, chain, keys, voters, nil)
+}
+
+// mineWithExtra is like mine, but allows modifying the consensus fields of the
+// block before it is sealed.
+func mineWithExtra(t *testing.T, engine *HotStuff, chain *testerChain, keys *testerValidators, voters int, modify func(ext *types.HotStuffExtra)) *types.Header {
+	parent := chain.CurrentHeader()
+	if parent.Number.Uint64() > 0 {
+		grandparent := chain.GetHeaderByNumber(parent.Number.Uint64() - 1)
+		ext, err := extractExtra(engine.config, grandparent)
+		if err != nil {
+			t.Fatalf("failed to decode grandparent extra: %v", err)
+		}
+		for _, validator := range ext.Validators[:voters] {
+			keys.authorize(engine, validator)
+			vote, err := engine.Vote(parent)
+			if err != nil {
+				t.Fatalf("failed to vote: %v", err)
+			}
+			if err := engine.AddVote(chain, parent, validator, vote); err != nil {
+				t.Fatalf("failed to add vote: %v", err)
+			}
+		}
+	}
+	parentExtra, err := extractExtra(engine.config, parent)
+	if err != nil {
+		t.Fatalf("failed to decode parent extra: %v", err)
+	}
+	proposer, err := engine.Leader(chain, parent, parentExtra.View+1)
+	if err != nil {
+		t.Fatalf("failed to elect leader: %v", err)
+	}
+	keys.authorize(engine, proposer)
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + 1,
+		UncleHash:  uncleHash,
+	}
+	if err := engine.Prepare(chain, header); err != nil {
+		t.Fatalf("failed to prepare header %d: %v", header.Number, err)
+	}
+	if modify != nil {
+		ext, err := extractExtra(engine.config, header)
+		if err != nil {
+			t.Fatalf("failed to decode prepared extra: %v", err)
+		}
+		modify(ext)
+		if header.Extra, err = ext.MarshalBinary(); err != nil {
+			t.Fatalf("failed to encode modified extra: %v", err)
+		}
+	}
+	results := make(chan *types.Block, 1)
+	if err := engine.Seal(chain, types.NewBlockWithHeader(header), results, nil); err != nil {
+		t.Fatalf("failed to seal header %d: %v", header.Number, err)
+	}
+	sealed := (<-results).Header()
+	chain.headers = append(chain.headers, sealed)
+	return sealed
+}
+
+// Tests that a chain produced by the engine passes verification by a fresh one.
+func TestSealAndVerify(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	testSealAndVerify(t, config, keys, newTesterGenesis(validators))
+}
+
+// Tests that a chain certified with aggregated BLS votes passes verification.
+func TestSealAndVerifyBLS(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	config.HotStuff.Crypto = params.HotStuffCryptoBLS
+	testSealAndVerify(t, config, keys, newTesterKeyedGenesis(t, keys, validators))
+}
+
+func testSealAndVerify(t *testing.T, config *params.ChainConfig, keys *testerValidators, genesis *types.Header) {
+	chain := &testerChain{config: config, headers: []*types.Header{genesis}}
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	for i := 0; i < 12; i++ {
+		mine(t, engine, chain, keys, 3)
+	}
+	verifier := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	_, results := verifier.VerifyHeaders(&testerChain{config: config, headers: chain.headers[:1]}, chain.headers[1:], nil)
+	for i := 1; i < len(chain.headers); i++ {
+		if err := <-results; err != nil {
+			t.Fatalf("header %d: verification failed: %v", i, err)
+		}
+	}
+	for i, header := range chain.headers[1:] {
+		author, err := verifier.Author(header)
+		if err != nil {
+			t.Fatalf("header %d: failed to retrieve author: %v", i+1, err)
+		}
+		ext, _ := extractExtra(verifier.config, header)
+		if author != ext.Proposer {
+			t.Errorf("header %d: author mismatch: have %x, want %x", i+1, author, ext.Proposer)
+		}
+	}
+	// Snapshots must follow the same certificates
+	snap, err := NewSnapshot(config.HotStuff, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create genesis snapshot: %v", err)
+	}
+	if _, err := snap.Apply(chain.headers[1:]); err != nil {
+		t.Fatalf("failed to apply headers: %v", err)
+	}
+}
+
+// Tests that blocks without a quorum certificate for their parent are rejected.
+func TestInsufficientVotes(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	chain := &testerChain{config: config, headers: []*types.Header{newTesterGenesis(validators)}}
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	mine(t, engine, chain, keys, 0)
+	mine(t, engine, chain, keys, 3)
+
+	// Producing a block with only two votes out of four must fail
+	parent := chain.CurrentHeader()
+	for _, validator := range validators[:2] {
+		keys.authorize(engine, validator)
+		vote, _ := engine.Vote(parent)
+		if err := engine.AddVote(chain, parent, validator, vote); err != nil {
+			t.Fatalf("failed to add vote: %v", err)
+		}
+	}
+	header := &types.Header{ParentHash: parent.Hash(), Number: big.NewInt(3), GasLimit: parent.GasLimit, Time: parent.Time + 1}
+	if err := engine.Prepare(chain, header); !errors.Is(err, types.ErrInsufficientQuorum) {
+		t.Fatalf("prepare error mismatch: have %v, want %v", err, types.ErrInsufficientQuorum)
+	}
+	// Votes by non-validators or on behalf of someone else must be refused
+	outsider, _ := newTesterValidators(1)
+	for addr := range outsider.keys {
+		outsider.authorize(engine, addr)
+	}
+	vote, _ := engine.Vote(parent)
+	if err := engine.AddVote(chain, parent, validators[2], vote); !errors.Is(err, types.ErrInvalidQuorumVote) {
+		t.Fatalf("forged vote error mismatch: have %v, want %v", err, types.ErrInvalidQuorumVote)
+	}
+	if err := engine.AddVote(chain, parent, common.Address{0x01}, vote); !errors.Is(err, errUnauthorizedValidator) {
+		t.Fatalf("outsider vote error mismatch: have %v, want %v", err, errUnauthorizedValidator)
+	}
+	// Blocks carrying a certificate of some other block must fail verification
+	mine(t, engine, chain, keys, 3)
+	mineWithExtra(t, engine, chain, keys, 3, func(ext *types.HotStuffExtra) {
+		ext.QuorumCert.BlockHash = common.Hash{}
+	})
+	verifier := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	base := &testerChain{config: config, headers: chain.headers[:4]}
+	if err := verifier.VerifyHeader(base, chain.headers[4], true); !errors.Is(err, errInvalidQuorumCert) {
+		t.Fatalf("certificate error mismatch: have %v, want %v", err, errInvalidQuorumCert)
+	}
+}
+
+// Tests that votes are refused instead of panicking if no keys are authorized.
+func TestVoteUnauthorized(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	if _, err := engine.Vote(newTesterGenesis(validators)); !errors.Is(err, errUnauthorizedSigner) {
+		t.Fatalf("vote error mismatch: have %v, want %v", err, errUnauthorizedSigner)
+	}
+	config.HotStuff.Crypto = params.HotStuffCryptoBLS
+	engine = New(config.HotStuff, rawdb.NewMemoryDatabase())
+	if _, err := engine.Vote(newTesterKeyedGenesis(t, keys, validators)); !errors.Is(err, errMissingVotingKey) {
+		t.Fatalf("BLS vote error mismatch: have %v, want %v", err, errMissingVotingKey)
+	}
+}
+
+// Tests that epoch blocks announcing a BLS key without a proof of possession of
+// its secret key, as needed for a rogue key attack, are rejected.
+func TestRogueKeyEpoch(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	config.HotStuff.Crypto = params.HotStuffCryptoBLS
+	chain := &testerChain{config: config, headers: []*types.Header{newTesterKeyedGenesis(t, keys, validators)}}
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	for i := 0; i < 9; i++ {
+		mine(t, engine, chain, keys, 3)
+	}
+	verifier := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	base := &testerChain{config: config, headers: chain.headers[:10]}
+
+	// Rotating the key of a validator along with its proof is fine
+	fresh, _ := bls.GenerateKey(nil)
+	rotated := mineWithExtra(t, engine, chain, keys, 3, func(ext *types.HotStuffExtra) {
+		ext.PublicKeys[3], ext.Proofs[3] = fresh.PublicKey().Bytes(), bls.PopProve(fresh).Bytes()
+	})
+	if err := verifier.VerifyHeader(base, rotated, true); err != nil {
+		t.Fatalf("rotated key rejected: %v", err)
+	}
+	chain.headers = chain.headers[:10]
+
+	// Announcing a key without proving possession of its secret key is not
+	rogue, _ := bls.GenerateKey(nil)
+	header := mineWithExtra(t, engine, chain, keys, 3, func(ext *types.HotStuffExtra) {
+		ext.PublicKeys[3] = rogue.PublicKey().Bytes()
+	})
+	if err := verifier.VerifyHeader(base, header, true); !errors.Is(err, types.ErrInvalidProofOfPossession) {
+		t.Fatalf("rogue key error mismatch: have %v, want %v", err, types.ErrInvalidProofOfPossession)
+	}
+	genesis, err := NewSnapshot(config.HotStuff, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create genesis snapshot: %v", err)
+	}
+	if _, err := genesis.Apply(chain.headers[1:]); !errors.Is(err, types.ErrInvalidProofOfPossession) {
+		t.Fatalf("rogue key snapshot error mismatch: have %v, want %v", err, types.ErrInvalidProofOfPossession)
+	}
+}
+
+// Tests that tampered headers are rejected by the verifier.
+func TestVerifyTampered(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	chain := &testerChain{config: config, headers: []*types.Header{newTesterGenesis(validators)}}
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	for i := 0; i < 3; i++ {
+		mine(t, engine, chain, keys, 3)
+	}
+	verifier := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	base := &testerChain{config: config, headers: chain.headers[:3]}
+
+	tests := []struct {
+		name   string
+		tamper func(header *types.Header)
+		err    error
+	}{
+		{"mix digest", func(h *types.Header) { h.MixDigest = common.Hash{} }, errInvalidMixDigest},
+		{"nonce", func(h *types.Header) { h.Nonce = types.EncodeNonce(1) }, errInvalidNonce},
+		{"difficulty", func(h *types.Header) { h.Difficulty = big.NewInt(2) }, errInvalidDifficulty},
+		{"timestamp", func(h *types.Header) { h.Time = base.CurrentHeader().Time }, errInvalidTimestamp},
+		{"seal", func(h *types.Header) { h.GasUsed = 1 }, errInvalidSigner},
+	}
+	for _, tt := range tests {
+		header := types.CopyHeader(chain.headers[3])
+		tt.tamper(header)
+		if err := verifier.VerifyHeader(base, header, true); !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hotstuff
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+)
+
+// Names of the built-in leader rotation strategies, selectable through the
+// LeaderRotation field of the HotStuff configuration.
+const (
+	RoundRobinRotationName = "roundrobin"
+	ReputationRotationName = "reputation"
+	StakeRotationName      = "stake"
+)
+
+// LeaderRotation is a leader election strategy, deciding which validator is
+// responsible for proposing the block of a view. Implementations must be
+// deterministic, as every node (and any off-chain monitor) has to arrive at
+// the same leader from the same inputs.
+type LeaderRotation interface {
+	// Window returns the number of recent committed headers the strategy needs
+	// to elect a leader, zero if it doesn't depend on the chain history.
+	Window() int
+
+	// Leader returns the validator proposing in the given view. The validators are
+	// the set in effect for the block being proposed and recent holds up to Window
+	// committed headers in ascending order, the last one being the parent of the
+	// block being proposed.
+	Leader(view uint64, validators []common.Address, recent []*types.Header) common.Address
+}
+
+var (
+	rotationsLock sync.RWMutex
+	rotations     = map[string]LeaderRotation{
+		"":                     RoundRobinRotation{},
+		RoundRobinRotationName: RoundRobinRotation{},
+		ReputationRotationName: new(ReputationRotation),
+	}
+)
+
+// RegisterLeaderRotation makes a leader rotation strategy available under the
+// given name, replacing any previous one. Stake weighted rotation has no
+// built-in registration as the stakes are specific to the chain, so it needs
+// to be registered as StakeRotationName before the engine is created.
+func RegisterLeaderRotation(name string, rotation LeaderRotation) {
+	rotationsLock.Lock()
+	defer rotationsLock.Unlock()
+
+	rotations[name] = rotation
+}
+
+// NewLeaderRotation returns the leader rotation strategy registered under the
+// given name, the empty name being an alias for round robin.
+func NewLeaderRotation(name string) (LeaderRotation, error) {
+	rotationsLock.RLock()
+	defer rotationsLock.RUnlock()
+
+	rotation, ok := rotations[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownLeaderRotation, name)
+	}
+	return rotation, nil
+}
+
+// RoundRobinRotation elects the validators in turn, by view number.
+type RoundRobinRotation struct{}
+
+// Window implements LeaderRotation, round robin needs no chain history.
+func (RoundRobinRotation) Window() int { return 0 }
+
+// Leader implements LeaderRotation, returning the validator at the position of
+// the view modulo the validator count.
+func (RoundRobinRotation) Leader(view uint64, validators []common.Address, recent []*types.Header) common.Address {
+	return leader(view, validators)
+}
+
+// ReputationRotation elects leaders among the validators that recently took part
+// in consensus, either by proposing a committed block or by having their vote
+// aggregated into a certificate, skipping the most recent proposers. Crashed
+// validators thus stop being elected, without any one of the live ones being
+// favored. If no validator qualifies, the strategy falls back to round robin.
+type ReputationRotation struct {
+	History int // Number of recent committed headers to consider (default = 20)
+	Exclude int // Number of latest proposers to skip (default = f, the tolerated faults)
+}
+
+// Window implements LeaderRotation, returning the configured history length.
+func (r *ReputationRotation) Window() int {
+	if r.History <= 0 {
+		return 20
+	}
+	return r.History
+}
+
+// Leader implements LeaderRotation, electing in turn by view among the active
+// validators that didn't propose the latest blocks.
+func (r *ReputationRotation) Leader(view uint64, validators []common.Address, recent []*types.Header) common.Address {
+	if len(validators) == 0 {
+		return common.Address{}
+	}
+	if window := r.Window(); len(recent) > window {
+		recent = recent[len(recent)-window:]
+	}
+	// Decode the history, the genesis block may come in either layout
+	extras := make([]*types.HotStuffExtra, len(recent))
+	for i, header := range recent {
+		ext, err := types.ExtractHotStuffExtra(header)
+		if err != nil {
+			ext, err = types.ExtractKeyedHotStuffExtra(header)
+		}
+		if err == nil {
+			extras[i] = ext
+		}
+	}
+	// Collect the proposers and voters of the history, the certificate of each
+	// block being signed by the validator set announced by its grandparent
+	active := make(map[common.Address]bool)
+	for i, ext := range extras {
+		if ext == nil || recent[i].Number.Sign() == 0 {
+			continue
+		}
+		active[ext.Proposer] = true
+		if ext.QuorumCert == nil || i < 2 || extras[i-2] == nil {
+			continue
+		}
+		voters := extras[i-2].Validators
+		if indices, err := ext.QuorumCert.SignerIndices(len(voters)); err == nil {
+			for _, index := range indices {
+				active[voters[index]] = true
+			}
+		}
+	}
+	// Skip the latest proposers to spread the load across the active validators
+	exclude := r.Exclude
+	if exclude <= 0 {
+		exclude = (len(validators) - 1) / 3
+	}
+	for i := len(extras) - 1; i >= 0 && exclude > 0; i-- {
+		if extras[i] == nil || recent[i].Number.Sign() == 0 {
+			continue
+		}
+		delete(active, extras[i].Proposer)
+		exclude--
+	}
+	candidates := make([]common.Address, 0, len(validators))
+	for _, validator := range validators {
+		if active[validator] {
+			candidates = append(candidates, validator)
+		}
+	}
+	if len(candidates) == 0 {
+		return leader(view, validators)
+	}
+	return leader(view, candidates)
+}
+
+// StakeRotation elects leaders with a probability proportional to their stake,
+// drawing pseudo-randomly but deterministically from the view number.
+// Validators without stake are never elected, unless none of them has any, in
+// which case the strategy falls back to round robin.
+type StakeRotation struct {
+	stakes map[common.Address]*big.Int
+}
+
+// NewStakeRotation creates a stake weighted leader rotation from the stakes of
+// the validators.
+func NewStakeRotation(stakes map[common.Address]*big.Int) *StakeRotation {
+	cpy := make(map[common.Address]*big.Int, len(stakes))
+	for validator, stake := range stakes {
+		if stake != nil && stake.Sign() > 0 {
+			cpy[validator] = new(big.Int).Set(stake)
+		}
+	}
+	return &StakeRotation{stakes: cpy}
+}
+
+// Window implements LeaderRotation, stake weighting needs no chain history.
+func (r *StakeRotation) Window() int { return 0 }
+
+// Leader implements LeaderRotation, electing the validator whose cumulative stake
+// range, in validator order, contains the view's draw.
+func (r *StakeRotation) Leader(view uint64, validators []common.Address, recent []*types.Header) common.Address {
+	total := new(big.Int)
+	for _, validator := range validators {
+		if stake, ok := r.stakes[validator]; ok {
+			total.Add(total, stake)
+		}
+	}
+	if total.Sign() == 0 {
+		return leader(view, validators)
+	}
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], view)
+	draw := new(big.Int).SetBytes(crypto.Keccak256(seed[:]))
+	draw.Mod(draw, total)
+
+	cumulative := new(big.Int)
+	for _, validator := range validators {
+		if stake, ok := r.stakes[validator]; ok {
+			if cumulative.Add(cumulative, stake).Cmp(draw) > 0 {
+				return validator
+			}
+		}
+	}
+	return common.Address{} // Unreachable, the draw is below the total
+}
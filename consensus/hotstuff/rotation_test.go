@@ -0,0 +1,158 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hotstuff
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+)
+
+var rotationValidators = []common.Address{{0x0a}, {0x0b}, {0x0c}, {0x0d}}
+
+// newRotationHeader creates an unsealed header carrying the given consensus fields.
+func newRotationHeader(t *testing.T, number int64, ext *types.HotStuffExtra) *types.Header {
+	ext.Validators = rotationValidators
+	extra, err := ext.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode extra: %v", err)
+	}
+	return &types.Header{Number: big.NewInt(number), Extra: extra}
+}
+
+func TestLeaderRotationRegistry(t *testing.T) {
+	for _, name := range []string{"", RoundRobinRotationName, ReputationRotationName} {
+		if _, err := NewLeaderRotation(name); err != nil {
+			t.Errorf("built-in rotation %q missing: %v", name, err)
+		}
+	}
+	if _, err := NewLeaderRotation("bogus"); !errors.Is(err, errUnknownLeaderRotation) {
+		t.Errorf("unknown rotation error mismatch: have %v, want %v", err, errUnknownLeaderRotation)
+	}
+	stake := NewStakeRotation(map[common.Address]*big.Int{rotationValidators[2]: big.NewInt(1)})
+	RegisterLeaderRotation("test-stake", stake)
+	if have, err := NewLeaderRotation("test-stake"); err != nil || have != stake {
+		t.Errorf("registered rotation mismatch: have %v (%v), want %v", have, err, stake)
+	}
+}
+
+func TestRoundRobinRotation(t *testing.T) {
+	var rotation RoundRobinRotation
+	for view := uint64(0); view < 8; view++ {
+		if have, want := rotation.Leader(view, rotationValidators, nil), rotationValidators[view%4]; have != want {
+			t.Errorf("view %d: leader mismatch: have %x, want %x", view, have, want)
+		}
+	}
+}
+
+func TestReputationRotation(t *testing.T) {
+	genesis, err := (&types.HotStuffExtra{Validators: rotationValidators}).MarshalGenesis()
+	if err != nil {
+		t.Fatalf("failed to encode genesis extra: %v", err)
+	}
+	recent := []*types.Header{
+		{Number: big.NewInt(0), Extra: genesis},
+		newRotationHeader(t, 1, &types.HotStuffExtra{Proposer: rotationValidators[1], View: 1}),
+		newRotationHeader(t, 2, &types.HotStuffExtra{Proposer: rotationValidators[2], View: 2, QuorumCert: &types.QuorumCert{View: 1, Signers: []byte{0x07}}}),
+	}
+	rotation := new(ReputationRotation)
+
+	// Validators 0, 1 and 2 are active, 2 being skipped as the latest proposer
+	for view, want := range map[uint64]common.Address{4: rotationValidators[0], 5: rotationValidators[1], 6: rotationValidators[0]} {
+		if have := rotation.Leader(view, rotationValidators, recent); have != want {
+			t.Errorf("view %d: leader mismatch: have %x, want %x", view, have, want)
+		}
+	}
+	// Without any history, the rotation falls back to round robin
+	if have := rotation.Leader(3, rotationValidators, recent[:1]); have != rotationValidators[3] {
+		t.Errorf("fallback leader mismatch: have %x, want %x", have, rotationValidators[3])
+	}
+	// A short history window only sees the latest proposer, which is skipped
+	short := &ReputationRotation{History: 1}
+	if have := short.Leader(5, rotationValidators, recent); have != rotationValidators[1] {
+		t.Errorf("short window leader mismatch: have %x, want %x", have, rotationValidators[1])
+	}
+}
+
+func TestStakeRotation(t *testing.T) {
+	rotation := NewStakeRotation(map[common.Address]*big.Int{
+		rotationValidators[0]: big.NewInt(1),
+		rotationValidators[1]: big.NewInt(3),
+		rotationValidators[2]: big.NewInt(0),
+	})
+	elected := make(map[common.Address]int)
+	for view := uint64(0); view < 4000; view++ {
+		elected[rotation.Leader(view, rotationValidators, nil)]++
+	}
+	if elected[rotationValidators[2]] != 0 || elected[rotationValidators[3]] != 0 {
+		t.Errorf("validators without stake elected: %v", elected)
+	}
+	if ratio := float64(elected[rotationValidators[1]]) / float64(elected[rotationValidators[0]]); ratio < 2.5 || ratio > 3.5 {
+		t.Errorf("election ratio mismatch: have %.2f, want ~3", ratio)
+	}
+	// Without any stake, the rotation falls back to round robin
+	if have := NewStakeRotation(nil).Leader(2, rotationValidators, nil); have != rotationValidators[2] {
+		t.Errorf("fallback leader mismatch: have %x, want %x", have, rotationValidators[2])
+	}
+}
+
+// Tests that the engine and the snapshots agree on reputation based leaders.
+func TestReputationRotationEngine(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	config.HotStuff.LeaderRotation = ReputationRotationName
+	chain := &testerChain{config: config, headers: []*types.Header{newTesterGenesis(validators)}}
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	for i := 0; i < 8; i++ {
+		mine(t, engine, chain, keys, 3)
+	}
+	verifier := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	_, results := verifier.VerifyHeaders(&testerChain{config: config, headers: chain.headers[:1]}, chain.headers[1:], nil)
+	for i := 1; i < len(chain.headers); i++ {
+		if err := <-results; err != nil {
+			t.Fatalf("header %d: verification failed: %v", i, err)
+		}
+	}
+	genesis, err := NewSnapshot(config.HotStuff, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create genesis snapshot: %v", err)
+	}
+	snap, err := genesis.Apply(chain.headers[1:])
+	if err != nil {
+		t.Fatalf("failed to apply headers: %v", err)
+	}
+	db := rawdb.NewMemoryDatabase()
+	if err := snap.Store(db); err != nil {
+		t.Fatalf("failed to store snapshot: %v", err)
+	}
+	loaded, err := LoadSnapshot(config.HotStuff, db, snap.Hash)
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	want, err := engine.Leader(chain, chain.CurrentHeader(), snap.View+1)
+	if err != nil {
+		t.Fatalf("failed to elect leader: %v", err)
+	}
+	if have := loaded.Leader(snap.View + 1); have != want {
+		t.Errorf("snapshot leader mismatch: have %x, want %x", have, want)
+	}
+}
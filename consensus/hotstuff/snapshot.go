@@ -0,0 +1,233 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hotstuff
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/log"
+	"github.com/simplechain-org/client/params"
+)
+
+// Snapshot is the state of the validator set at a given point in time.
+type Snapshot struct {
+	config   *params.HotStuffConfig // Consensus engine parameters to fine tune behavior
+	rotation LeaderRotation         // Leader election strategy of the validators
+
+	Number     uint64           `json:"number"`               // Block number where the snapshot was created
+	Hash       common.Hash      `json:"hash"`                 // Block hash where the snapshot was created
+	View       uint64           `json:"view"`                 // View of the block where the snapshot was created
+	Validators []common.Address `json:"validators"`           // Validator set in effect for the next block
+	Voters     []common.Address `json:"voters"`               // Validator set voting on the snapshot block
+	PublicKeys [][]byte         `json:"publicKeys,omitempty"` // BLS public keys of the validators
+	VoterKeys  [][]byte         `json:"voterKeys,omitempty"`  // BLS public keys of the voters
+	Since      uint64           `json:"since"`                // Block number that installed the current validator set
+	Recents    []*types.Header  `json:"recents,omitempty"`    // Latest headers needed for leader election
+}
+
+// NewSnapshot creates a snapshot trusting the given header and its parent, the
+// latter being needed to know who votes on the header. The parent is ignored
+// for the genesis block.
+func NewSnapshot(config *params.HotStuffConfig, header *types.Header, parent *types.Header) (*Snapshot, error) {
+	config = withDefaults(config)
+
+	rotation, err := NewLeaderRotation(config.LeaderRotation)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := extractExtra(config, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := ext.VerifyProofs(); err != nil {
+		return nil, err
+	}
+	snap := &Snapshot{
+		config:     config,
+		rotation:   rotation,
+		Number:     header.Number.Uint64(),
+		Hash:       header.Hash(),
+		View:       ext.View,
+		Validators: ext.Validators,
+		PublicKeys: ext.PublicKeys,
+		Since:      header.Number.Uint64(),
+	}
+	if snap.Number > 0 {
+		if parent == nil || parent.Hash() != header.ParentHash {
+			return nil, errInvalidValidatorChain
+		}
+		parentExtra, err := extractExtra(config, parent)
+		if err != nil {
+			return nil, err
+		}
+		if err := parentExtra.VerifyProofs(); err != nil {
+			return nil, err
+		}
+		snap.Voters, snap.VoterKeys = parentExtra.Validators, parentExtra.PublicKeys
+		snap.remember(parent)
+	}
+	snap.remember(header)
+	return snap, nil
+}
+
+// withDefaults fills in any missing consensus parameters with their defaults.
+func withDefaults(config *params.HotStuffConfig) *params.HotStuffConfig {
+	conf := *config
+	if conf.Epoch == 0 {
+		conf.Epoch = epochLength
+	}
+	return &conf
+}
+
+// LoadSnapshot loads an existing snapshot from the database.
+func LoadSnapshot(config *params.HotStuffConfig, db ethdb.KeyValueReader, hash common.Hash) (*Snapshot, error) {
+	blob, err := db.Get(append([]byte("hotstuff-"), hash[:]...))
+	if err != nil {
+		return nil, err
+	}
+	snap := new(Snapshot)
+	if err := json.Unmarshal(blob, snap); err != nil {
+		return nil, err
+	}
+	snap.config = withDefaults(config)
+	if snap.rotation, err = NewLeaderRotation(config.LeaderRotation); err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// Store inserts the snapshot into the database.
+func (s *Snapshot) Store(db ethdb.KeyValueWriter) error {
+	blob, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return db.Put(append([]byte("hotstuff-"), s.Hash[:]...), blob)
+}
+
+// copy creates a deep copy of the snapshot.
+func (s *Snapshot) copy() *Snapshot {
+	cpy := *s
+	cpy.Validators = append([]common.Address(nil), s.Validators...)
+	cpy.Voters = append([]common.Address(nil), s.Voters...)
+	cpy.PublicKeys = append([][]byte(nil), s.PublicKeys...)
+	cpy.VoterKeys = append([][]byte(nil), s.VoterKeys...)
+	cpy.Recents = append([]*types.Header(nil), s.Recents...)
+	return &cpy
+}
+
+// Apply creates a new snapshot by applying the given headers to the original
+// one, verifying the proposer, seal and parent certificate of each of them.
+func (s *Snapshot) Apply(headers []*types.Header) (*Snapshot, error) {
+	// Allow passing in no headers for cleaner code
+	if len(headers) == 0 {
+		return s, nil
+	}
+	// Sanity check that the headers can be applied
+	for i := 0; i < len(headers)-1; i++ {
+		if headers[i+1].Number.Uint64() != headers[i].Number.Uint64()+1 || headers[i+1].ParentHash != headers[i].Hash() {
+			return nil, errInvalidValidatorChain
+		}
+	}
+	if headers[0].Number.Uint64() != s.Number+1 || headers[0].ParentHash != s.Hash {
+		return nil, errInvalidValidatorChain
+	}
+	// Iterate through the headers and create a new snapshot
+	snap := s.copy()
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for i, header := range headers {
+		number := header.Number.Uint64()
+
+		ext, err := extractExtra(snap.config, header)
+		if err != nil {
+			return nil, err
+		}
+		if ext.View <= snap.View {
+			return nil, errInvalidView
+		}
+		if ext.Proposer != snap.Leader(ext.View) {
+			return nil, errInvalidProposer
+		}
+		signer, err := recoverAddress(SealHash(header), ext.Seal)
+		if err != nil {
+			return nil, err
+		}
+		if signer != ext.Proposer {
+			return nil, errInvalidSigner
+		}
+		// Verify the certificate of the previous block, the genesis is never voted on
+		if snap.Number == 0 {
+			if ext.QuorumCert != nil {
+				return nil, errInvalidQuorumCert
+			}
+		} else if err := verifyQuorumCert(snap.config, ext.QuorumCert, snap.View, snap.Hash, snap.Voters, snap.VoterKeys); err != nil {
+			return nil, err
+		}
+		// Track validator set transitions, which are only allowed on epoch blocks
+		if !equalValidatorSets(ext, &types.HotStuffExtra{Validators: snap.Validators, PublicKeys: snap.PublicKeys}) {
+			if number%snap.config.Epoch != 0 {
+				return nil, errMismatchingValidators
+			}
+			if err := ext.VerifyProofs(); err != nil {
+				return nil, err
+			}
+			snap.Since = number
+		}
+		snap.Number, snap.Hash, snap.View = number, header.Hash(), ext.View
+		snap.Voters, snap.Validators = snap.Validators, ext.Validators
+		snap.VoterKeys, snap.PublicKeys = snap.PublicKeys, ext.PublicKeys
+		snap.remember(header)
+
+		// If we're taking too much time (ecrecover), notify the user once a while
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Reconstructing validator history", "processed", i, "total", len(headers), "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if time.Since(start) > 8*time.Second {
+		log.Info("Reconstructed validator history", "processed", len(headers), "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return snap, nil
+}
+
+// remember appends a header to the recent history, dropping those no longer
+// needed by the leader rotation.
+func (s *Snapshot) remember(header *types.Header) {
+	window := s.rotation.Window()
+	if window == 0 {
+		return
+	}
+	s.Recents = append(s.Recents, header)
+	if len(s.Recents) > window {
+		s.Recents = s.Recents[len(s.Recents)-window:]
+	}
+}
+
+// Leader returns the validator responsible for proposing the block following
+// the snapshot in the given view.
+func (s *Snapshot) Leader(view uint64) common.Address {
+	return s.rotation.Leader(view, s.Validators, s.Recents)
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hotstuff
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/rpc"
+)
+
+// Tests that validator snapshots follow set transitions on epoch blocks and
+// survive a database round trip.
+func TestSnapshotTransitions(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	chain := &testerChain{config: config, headers: []*types.Header{newTesterGenesis(validators)}}
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	for i := 1; i < 10; i++ {
+		mine(t, engine, chain, keys, 3)
+	}
+	// Drop the last validator on the epoch block and keep going with the rest
+	mineWithExtra(t, engine, chain, keys, 3, func(ext *types.HotStuffExtra) {
+		ext.Validators = ext.Validators[:3]
+	})
+	for i := 11; i < 14; i++ {
+		mine(t, engine, chain, keys, 3)
+	}
+	genesis, err := NewSnapshot(config.HotStuff, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create genesis snapshot: %v", err)
+	}
+	snap, err := genesis.Apply(chain.headers[1:])
+	if err != nil {
+		t.Fatalf("failed to apply headers: %v", err)
+	}
+	if snap.Number != 13 || snap.Hash != chain.CurrentHeader().Hash() {
+		t.Errorf("snapshot head mismatch: have #%d [%x], want #13 [%x]", snap.Number, snap.Hash, chain.CurrentHeader().Hash())
+	}
+	if !reflect.DeepEqual(snap.Validators, validators[:3]) {
+		t.Errorf("validators mismatch: have %x, want %x", snap.Validators, validators[:3])
+	}
+	if snap.Since != 10 {
+		t.Errorf("transition block mismatch: have %d, want 10", snap.Since)
+	}
+	db := rawdb.NewMemoryDatabase()
+	if err := snap.Store(db); err != nil {
+		t.Fatalf("failed to store snapshot: %v", err)
+	}
+	loaded, err := LoadSnapshot(config.HotStuff, db, snap.Hash)
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, snap) {
+		t.Errorf("loaded snapshot mismatch: have %+v, want %+v", loaded, snap)
+	}
+	// The engine should reconstruct the same snapshot through its API
+	number := rpc.LatestBlockNumber
+	api := &API{chain: chain, hotstuff: New(config.HotStuff, rawdb.NewMemoryDatabase())}
+	if have, err := api.GetSnapshot(&number); err != nil {
+		t.Fatalf("failed to retrieve snapshot: %v", err)
+	} else if !reflect.DeepEqual(have, snap) {
+		t.Errorf("API snapshot mismatch: have %+v, want %+v", have, snap)
+	}
+}
+
+// Tests that snapshots reject validator changes outside of epoch blocks.
+func TestSnapshotMidEpochTransition(t *testing.T) {
+	keys, validators := newTesterValidators(4)
+	config := newTesterConfig()
+	chain := &testerChain{config: config, headers: []*types.Header{newTesterGenesis(validators)}}
+
+	engine := New(config.HotStuff, rawdb.NewMemoryDatabase())
+	mine(t, engine, chain, keys, 3)
+	mineWithExtra(t, engine, chain, keys, 3, func(ext *types.HotStuffExtra) {
+		ext.Validators = ext.Validators[1:]
+	})
+	genesis, err := NewSnapshot(config.HotStuff, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create genesis snapshot: %v", err)
+	}
+	if _, err := genesis.Apply(chain.headers[1:]); !errors.Is(err, errMismatchingValidators) {
+		t.Fatalf("error mismatch: have %v, want %v", err, errMismatchingValidators)
+	}
+	if _, err := genesis.Apply(chain.headers[2:]); !errors.Is(err, errInvalidValidatorChain) {
+		t.Fatalf("error mismatch: have %v, want %v", err, errInvalidValidatorChain)
+	}
+}
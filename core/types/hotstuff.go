@@ -3,8 +3,13 @@ package types
 // HotStuff
 import (
 	"errors"
+	"fmt"
+	"io"
+
 	"github.com/simplechain-org/client/common"
 	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/crypto/bls"
+	"github.com/simplechain-org/client/rlp"
 )
 
 var (
@@ -13,7 +18,7 @@ var (
 	HotStuffDigest = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
 
 	// HotStuffExtraVanity (Genesis): 32B+initialSigners+65B
-	// (Non-genesis): 32B+Proposer+Validators+View+Signature+65B
+	// (Non-genesis): 32B+Proposer+Validators+View+QuorumCert[+PublicKeys+Proofs]+65B
 	HotStuffExtraVanity = crypto.DigestLength // Fixed number of extra-data bytes reserved for validator vanity
 
 	// ErrInvalidHotStuffHeaderExtra is returned if the length of extra-data is less than 32 bytes
@@ -21,6 +26,268 @@ var (
 
 	// HotStuffExtraSeal Fixed number of extra-data bytes reserved for validator seal
 	HotStuffExtraSeal = crypto.SignatureLength
+
+	// ErrInvalidProofOfPossession is returned if a validator BLS public key isn't
+	// accompanied by a valid proof of possession of its secret key.
+	ErrInvalidProofOfPossession = errors.New("invalid BLS proof of possession")
 )
 
+// HotStuffExtra is the decoded form of a HotStuff header's extra-data. The
+// Proposer, Validators, View, QuorumCert, PublicKeys and Proofs fields are RLP
+// encoded between the vanity prefix and the seal suffix; the genesis block
+// instead carries the raw concatenation of the initial validator addresses, each
+// one followed by its BLS public key and proof of possession on networks voting
+// with BLS signatures.
+type HotStuffExtra struct {
+	Vanity     []byte           // Free form validator vanity, at most 32 bytes
+	Proposer   common.Address   // Validator proposing the block in this view
+	Validators []common.Address // Validator set in effect for the next block
+	View       uint64           // View number the block was proposed in
+	QuorumCert *QuorumCert      // Certificate of the parent block, nil for block 1
+	PublicKeys [][]byte         // BLS public keys of the validators, empty for secp256k1
+	Proofs     [][]byte         // BLS proofs of possession of the public keys
+	Seal       []byte           // Proposer seal over the rest of the header
+}
+
+// hotStuffExtraRLP is the RLP encoded middle section of the extra-data.
+type hotStuffExtraRLP struct {
+	Proposer   common.Address
+	Validators []common.Address
+	View       uint64
+	QuorumCert *QuorumCert `rlp:"nil"`
+	PublicKeys [][]byte    `rlp:"optional"`
+	Proofs     [][]byte    `rlp:"optional"`
+}
+
+// EncodeRLP implements rlp.Encoder, encoding the consensus fields sitting
+// between the vanity and the seal.
+func (e *HotStuffExtra) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, &hotStuffExtraRLP{
+		Proposer:   e.Proposer,
+		Validators: e.Validators,
+		View:       e.View,
+		QuorumCert: e.QuorumCert,
+		PublicKeys: e.PublicKeys,
+		Proofs:     e.Proofs,
+	})
+}
+
+// DecodeRLP implements rlp.Decoder, decoding the consensus fields sitting
+// between the vanity and the seal.
+func (e *HotStuffExtra) DecodeRLP(s *rlp.Stream) error {
+	var dec hotStuffExtraRLP
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	e.Proposer, e.Validators, e.View = dec.Proposer, dec.Validators, dec.View
+	e.QuorumCert, e.PublicKeys, e.Proofs = dec.QuorumCert, dec.PublicKeys, dec.Proofs
+	return nil
+}
+
+// MarshalBinary assembles the full extra-data of a non-genesis header. A
+// missing seal is filled with zeroes, ready to be signed.
+func (e *HotStuffExtra) MarshalBinary() ([]byte, error) {
+	payload, err := rlp.EncodeToBytes(e)
+	if err != nil {
+		return nil, err
+	}
+	return e.assemble(payload)
+}
+
+// UnmarshalBinary parses the full extra-data of a non-genesis header.
+func (e *HotStuffExtra) UnmarshalBinary(data []byte) error {
+	payload, err := e.split(data)
+	if err != nil {
+		return err
+	}
+	if err := rlp.DecodeBytes(payload, e); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidHotStuffHeaderExtra, err)
+	}
+	return nil
+}
+
+// MarshalGenesis assembles the extra-data of a genesis header, which only
+// carries the initial validators and, if set, their BLS public keys and proofs
+// of possession.
+func (e *HotStuffExtra) MarshalGenesis() ([]byte, error) {
+	if len(e.PublicKeys) != 0 && len(e.PublicKeys) != len(e.Validators) {
+		return nil, fmt.Errorf("%w: %d public keys for %d validators", ErrInvalidHotStuffHeaderExtra, len(e.PublicKeys), len(e.Validators))
+	}
+	if len(e.Proofs) != len(e.PublicKeys) {
+		return nil, fmt.Errorf("%w: %d proofs for %d public keys", ErrInvalidHotStuffHeaderExtra, len(e.Proofs), len(e.PublicKeys))
+	}
+	payload := make([]byte, 0, len(e.Validators)*(common.AddressLength+bls.PublicKeyLength+bls.SignatureLength))
+	for i, validator := range e.Validators {
+		payload = append(payload, validator[:]...)
+		if len(e.PublicKeys) != 0 {
+			if len(e.PublicKeys[i]) != bls.PublicKeyLength {
+				return nil, fmt.Errorf("%w: public key not %d bytes", ErrInvalidHotStuffHeaderExtra, bls.PublicKeyLength)
+			}
+			if len(e.Proofs[i]) != bls.SignatureLength {
+				return nil, fmt.Errorf("%w: proof not %d bytes", ErrInvalidHotStuffHeaderExtra, bls.SignatureLength)
+			}
+			payload = append(payload, e.PublicKeys[i]...)
+			payload = append(payload, e.Proofs[i]...)
+		}
+	}
+	return e.assemble(payload)
+}
+
+// UnmarshalGenesis parses the extra-data of a genesis header carrying only
+// validator addresses.
+func (e *HotStuffExtra) UnmarshalGenesis(data []byte) error {
+	return e.unmarshalGenesis(data, false)
+}
+
+// UnmarshalKeyedGenesis parses the extra-data of a genesis header carrying a BLS
+// public key and its proof of possession after each validator address.
+func (e *HotStuffExtra) UnmarshalKeyedGenesis(data []byte) error {
+	return e.unmarshalGenesis(data, true)
+}
+
+func (e *HotStuffExtra) unmarshalGenesis(data []byte, keyed bool) error {
+	payload, err := e.split(data)
+	if err != nil {
+		return err
+	}
+	entry := common.AddressLength
+	if keyed {
+		entry += bls.PublicKeyLength + bls.SignatureLength
+	}
+	if len(payload)%entry != 0 {
+		return fmt.Errorf("%w: validator list not a multiple of %d bytes", ErrInvalidHotStuffHeaderExtra, entry)
+	}
+	e.Proposer, e.View, e.QuorumCert, e.PublicKeys, e.Proofs = common.Address{}, 0, nil, nil, nil
+	e.Validators = make([]common.Address, len(payload)/entry)
+	for i := range e.Validators {
+		copy(e.Validators[i][:], payload[i*entry:])
+		if keyed {
+			key := payload[i*entry+common.AddressLength:]
+			e.PublicKeys = append(e.PublicKeys, common.CopyBytes(key[:bls.PublicKeyLength]))
+			e.Proofs = append(e.Proofs, common.CopyBytes(key[bls.PublicKeyLength:bls.PublicKeyLength+bls.SignatureLength]))
+		}
+	}
+	return nil
+}
+
+// assemble wraps the payload into the vanity prefix and the seal suffix.
+func (e *HotStuffExtra) assemble(payload []byte) ([]byte, error) {
+	if len(e.Vanity) > HotStuffExtraVanity {
+		return nil, fmt.Errorf("%w: vanity longer than %d bytes", ErrInvalidHotStuffHeaderExtra, HotStuffExtraVanity)
+	}
+	if len(e.Seal) != 0 && len(e.Seal) != HotStuffExtraSeal {
+		return nil, fmt.Errorf("%w: seal not %d bytes", ErrInvalidHotStuffHeaderExtra, HotStuffExtraSeal)
+	}
+	enc := make([]byte, HotStuffExtraVanity, HotStuffExtraVanity+len(payload)+HotStuffExtraSeal)
+	copy(enc, e.Vanity)
+	enc = append(enc, payload...)
+	if len(e.Seal) == 0 {
+		return append(enc, make([]byte, HotStuffExtraSeal)...), nil
+	}
+	return append(enc, e.Seal...), nil
+}
+
+// split strips the vanity prefix and the seal suffix off the extra-data,
+// returning the payload in between.
+func (e *HotStuffExtra) split(data []byte) ([]byte, error) {
+	if len(data) < HotStuffExtraVanity+HotStuffExtraSeal {
+		return nil, ErrInvalidHotStuffHeaderExtra
+	}
+	e.Vanity = common.CopyBytes(data[:HotStuffExtraVanity])
+	e.Seal = common.CopyBytes(data[len(data)-HotStuffExtraSeal:])
+	return data[HotStuffExtraVanity : len(data)-HotStuffExtraSeal], nil
+}
+
+// Validate checks that the extra-data is structurally sound: a non-empty
+// validator set free of duplicates, well sized vanity and seal, and either no
+// public keys or a well sized one per validator, each with a well sized proof of
+// possession. The proofs themselves are checked by VerifyProofs.
+func (e *HotStuffExtra) Validate() error {
+	if len(e.Vanity) > HotStuffExtraVanity {
+		return fmt.Errorf("%w: vanity longer than %d bytes", ErrInvalidHotStuffHeaderExtra, HotStuffExtraVanity)
+	}
+	if len(e.Seal) != 0 && len(e.Seal) != HotStuffExtraSeal {
+		return fmt.Errorf("%w: seal not %d bytes", ErrInvalidHotStuffHeaderExtra, HotStuffExtraSeal)
+	}
+	if len(e.Validators) == 0 {
+		return fmt.Errorf("%w: empty validator set", ErrInvalidHotStuffHeaderExtra)
+	}
+	seen := make(map[common.Address]struct{}, len(e.Validators))
+	for _, validator := range e.Validators {
+		if _, ok := seen[validator]; ok {
+			return fmt.Errorf("%w: duplicate validator %x", ErrInvalidHotStuffHeaderExtra, validator)
+		}
+		seen[validator] = struct{}{}
+	}
+	if len(e.PublicKeys) != 0 && len(e.PublicKeys) != len(e.Validators) {
+		return fmt.Errorf("%w: %d public keys for %d validators", ErrInvalidHotStuffHeaderExtra, len(e.PublicKeys), len(e.Validators))
+	}
+	if len(e.Proofs) != len(e.PublicKeys) {
+		return fmt.Errorf("%w: %d proofs for %d public keys", ErrInvalidHotStuffHeaderExtra, len(e.Proofs), len(e.PublicKeys))
+	}
+	for i, key := range e.PublicKeys {
+		if len(key) != bls.PublicKeyLength {
+			return fmt.Errorf("%w: public key not %d bytes", ErrInvalidHotStuffHeaderExtra, bls.PublicKeyLength)
+		}
+		if len(e.Proofs[i]) != bls.SignatureLength {
+			return fmt.Errorf("%w: proof not %d bytes", ErrInvalidHotStuffHeaderExtra, bls.SignatureLength)
+		}
+	}
+	return nil
+}
+
+// VerifyProofs checks the proof of possession of every validator public key,
+// which must hold before the keys are aggregated to verify quorum certificates.
+// It is expensive, so only needed when a validator set is first accepted.
+func (e *HotStuffExtra) VerifyProofs() error {
+	if len(e.Proofs) != len(e.PublicKeys) {
+		return fmt.Errorf("%w: %d proofs for %d public keys", ErrInvalidProofOfPossession, len(e.Proofs), len(e.PublicKeys))
+	}
+	for i, key := range e.PublicKeys {
+		pk, err := bls.PublicKeyFromBytes(key)
+		if err != nil {
+			return fmt.Errorf("%w: public key of %x: %v", ErrInvalidProofOfPossession, e.Validators[i], err)
+		}
+		proof, err := bls.SignatureFromBytes(e.Proofs[i])
+		if err != nil {
+			return fmt.Errorf("%w: proof of %x: %v", ErrInvalidProofOfPossession, e.Validators[i], err)
+		}
+		if !bls.PopVerify(pk, proof) {
+			return fmt.Errorf("%w: validator %x", ErrInvalidProofOfPossession, e.Validators[i])
+		}
+	}
+	return nil
+}
+
+// ExtractHotStuffExtra decodes and validates the extra-data of a HotStuff
+// header, picking the genesis or regular layout based on the block number.
+func ExtractHotStuffExtra(h *Header) (*HotStuffExtra, error) {
+	return extractHotStuffExtra(h, false)
+}
+
+// ExtractKeyedHotStuffExtra is like ExtractHotStuffExtra, but for networks
+// voting with BLS signatures, where every validator set must come with the
+// public keys of its members.
+func ExtractKeyedHotStuffExtra(h *Header) (*HotStuffExtra, error) {
+	return extractHotStuffExtra(h, true)
+}
+
+func extractHotStuffExtra(h *Header, keyed bool) (*HotStuffExtra, error) {
+	extra := new(HotStuffExtra)
+	if h.Number != nil && h.Number.Sign() == 0 {
+		if err := extra.unmarshalGenesis(h.Extra, keyed); err != nil {
+			return nil, err
+		}
+	} else if err := extra.UnmarshalBinary(h.Extra); err != nil {
+		return nil, err
+	}
+	if err := extra.Validate(); err != nil {
+		return nil, err
+	}
+	if keyed && len(extra.PublicKeys) != len(extra.Validators) {
+		return nil, fmt.Errorf("%w: missing validator public keys", ErrInvalidHotStuffHeaderExtra)
+	}
+	return extra, nil
+}
+
 // /HotStuff
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/crypto/bls"
+)
+
+func TestHotStuffExtraEncoding(t *testing.T) {
+	extra := &HotStuffExtra{
+		Vanity:     bytes.Repeat([]byte{0x01}, HotStuffExtraVanity),
+		Proposer:   common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Validators: []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111"), common.HexToAddress("0x2222222222222222222222222222222222222222")},
+		View:       42,
+		QuorumCert: &QuorumCert{View: 41, BlockHash: common.Hash{0x01}, Signers: []byte{0x07}, Signature: []byte{0xde, 0xad, 0xbe, 0xef}},
+		Seal:       bytes.Repeat([]byte{0x02}, HotStuffExtraSeal),
+	}
+	enc, err := extra.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode extra: %v", err)
+	}
+	if !bytes.Equal(enc[:HotStuffExtraVanity], extra.Vanity) || !bytes.Equal(enc[len(enc)-HotStuffExtraSeal:], extra.Seal) {
+		t.Fatalf("vanity or seal misplaced: %x", enc)
+	}
+	dec, err := ExtractHotStuffExtra(&Header{Number: big.NewInt(1), Extra: enc})
+	if err != nil {
+		t.Fatalf("failed to decode extra: %v", err)
+	}
+	if !reflect.DeepEqual(dec, extra) {
+		t.Errorf("extra mismatch: have %+v, want %+v", dec, extra)
+	}
+	// Block 1 carries no certificate, which must survive the round trip as nil
+	extra.QuorumCert = nil
+	if enc, err = extra.MarshalBinary(); err != nil {
+		t.Fatalf("failed to encode extra: %v", err)
+	}
+	if dec, err = ExtractHotStuffExtra(&Header{Number: big.NewInt(1), Extra: enc}); err != nil {
+		t.Fatalf("failed to decode extra: %v", err)
+	}
+	if dec.QuorumCert != nil || dec.PublicKeys != nil {
+		t.Errorf("empty fields not preserved: certificate %v, keys %x", dec.QuorumCert, dec.PublicKeys)
+	}
+}
+
+func TestHotStuffGenesisExtraEncoding(t *testing.T) {
+	extra := &HotStuffExtra{
+		Validators: []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111"), common.HexToAddress("0x2222222222222222222222222222222222222222")},
+	}
+	enc, err := extra.MarshalGenesis()
+	if err != nil {
+		t.Fatalf("failed to encode extra: %v", err)
+	}
+	if want := HotStuffExtraVanity + 2*common.AddressLength + HotStuffExtraSeal; len(enc) != want {
+		t.Fatalf("genesis extra length mismatch: have %d, want %d", len(enc), want)
+	}
+	dec, err := ExtractHotStuffExtra(&Header{Number: big.NewInt(0), Extra: enc})
+	if err != nil {
+		t.Fatalf("failed to decode extra: %v", err)
+	}
+	if !reflect.DeepEqual(dec.Validators, extra.Validators) {
+		t.Errorf("validators mismatch: have %x, want %x", dec.Validators, extra.Validators)
+	}
+}
+
+func TestHotStuffKeyedGenesisExtraEncoding(t *testing.T) {
+	extra := &HotStuffExtra{
+		Validators: []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111"), common.HexToAddress("0x2222222222222222222222222222222222222222")},
+	}
+	for range extra.Validators {
+		sk, _ := bls.GenerateKey(nil)
+		extra.PublicKeys = append(extra.PublicKeys, sk.PublicKey().Bytes())
+		extra.Proofs = append(extra.Proofs, bls.PopProve(sk).Bytes())
+	}
+	enc, err := extra.MarshalGenesis()
+	if err != nil {
+		t.Fatalf("failed to encode extra: %v", err)
+	}
+	header := &Header{Number: big.NewInt(0), Extra: enc}
+	dec, err := ExtractKeyedHotStuffExtra(header)
+	if err != nil {
+		t.Fatalf("failed to decode extra: %v", err)
+	}
+	if !reflect.DeepEqual(dec.Validators, extra.Validators) || !reflect.DeepEqual(dec.PublicKeys, extra.PublicKeys) || !reflect.DeepEqual(dec.Proofs, extra.Proofs) {
+		t.Errorf("validator set mismatch: have %x %x %x, want %x %x %x", dec.Validators, dec.PublicKeys, dec.Proofs, extra.Validators, extra.PublicKeys, extra.Proofs)
+	}
+	if err := dec.VerifyProofs(); err != nil {
+		t.Errorf("failed to verify proofs of possession: %v", err)
+	}
+	// Proofs not matching their public keys must be rejected
+	dec.Proofs[0], dec.Proofs[1] = dec.Proofs[1], dec.Proofs[0]
+	if err := dec.VerifyProofs(); !errors.Is(err, ErrInvalidProofOfPossession) {
+		t.Errorf("swapped proofs error mismatch: have %v, want %v", err, ErrInvalidProofOfPossession)
+	}
+	// Unkeyed extra-data must be rejected where keys are required
+	plain, _ := (&HotStuffExtra{Validators: extra.Validators}).MarshalGenesis()
+	if _, err := ExtractKeyedHotStuffExtra(&Header{Number: big.NewInt(0), Extra: plain}); !errors.Is(err, ErrInvalidHotStuffHeaderExtra) {
+		t.Errorf("unkeyed genesis error mismatch: have %v, want %v", err, ErrInvalidHotStuffHeaderExtra)
+	}
+}
+
+func TestHotStuffExtraValidation(t *testing.T) {
+	addr := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	duplicate, _ := (&HotStuffExtra{Validators: []common.Address{addr, addr}}).MarshalBinary()
+	empty, _ := (&HotStuffExtra{Proposer: addr}).MarshalBinary()
+	shortKey, _ := (&HotStuffExtra{Validators: []common.Address{addr}, PublicKeys: [][]byte{{0x01}}, Proofs: [][]byte{make([]byte, bls.SignatureLength)}}).MarshalBinary()
+	noProof, _ := (&HotStuffExtra{Validators: []common.Address{addr}, PublicKeys: [][]byte{make([]byte, bls.PublicKeyLength)}}).MarshalBinary()
+
+	tests := []struct {
+		number int64
+		extra  []byte
+	}{
+		{1, make([]byte, HotStuffExtraVanity+HotStuffExtraSeal-1)}, // too short
+		{1, make([]byte, HotStuffExtraVanity+HotStuffExtraSeal+3)}, // payload not RLP
+		{0, make([]byte, HotStuffExtraVanity+HotStuffExtraSeal+3)}, // genesis list not address aligned
+		{0, make([]byte, HotStuffExtraVanity+HotStuffExtraSeal)},   // genesis without validators
+		{1, duplicate},
+		{1, empty},
+		{1, shortKey},
+		{1, noProof},
+	}
+	for i, tt := range tests {
+		_, err := ExtractHotStuffExtra(&Header{Number: big.NewInt(tt.number), Extra: tt.extra})
+		if !errors.Is(err, ErrInvalidHotStuffHeaderExtra) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, ErrInvalidHotStuffHeaderExtra)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package types
+
+// HotStuff
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/crypto/bls"
+	"github.com/simplechain-org/client/params"
+	"github.com/simplechain-org/client/rlp"
+)
+
+var (
+	// ErrUnsupportedQuorumCrypto is returned if a quorum certificate is handled
+	// with a signature scheme that isn't implemented.
+	ErrUnsupportedQuorumCrypto = errors.New("unsupported quorum certificate crypto scheme")
+
+	// ErrInvalidQuorumCert is returned if a quorum certificate is malformed or
+	// its signature doesn't match the signers it claims.
+	ErrInvalidQuorumCert = errors.New("invalid quorum certificate")
+
+	// ErrInsufficientQuorum is returned if a quorum certificate doesn't carry the
+	// votes of 2f+1 validators.
+	ErrInsufficientQuorum = errors.New("insufficient votes for quorum")
+
+	// ErrInvalidQuorumVote is returned if a single vote isn't a valid signature of
+	// the validator casting it.
+	ErrInvalidQuorumVote = errors.New("invalid quorum vote")
+)
+
+// QuorumCert is a HotStuff quorum certificate, proving that a quorum of the
+// validator set voted for a block in a given view.
+//
+// Signers is a bitmap over the validator set, bit i (least significant first)
+// of byte i/8 marking the i-th validator as a signer. Depending on the scheme,
+// Signature is either the concatenation of the 65 byte secp256k1 votes of the
+// signers in validator order, or the 96 byte BLS aggregate of their votes.
+type QuorumCert struct {
+	View      uint64      // View of the certified block
+	BlockHash common.Hash // Hash of the certified block
+	Signers   []byte      // Bitmap of the validators whose votes are included
+	Signature []byte      // Votes of the signers, concatenated or aggregated
+}
+
+// HotStuffVoteRLP returns the rlp bytes which need to be signed by a validator to
+// vote for the block with the given view and hash.
+func HotStuffVoteRLP(view uint64, hash common.Hash) []byte {
+	enc, err := rlp.EncodeToBytes([]interface{}{view, hash})
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	return enc
+}
+
+// HotStuffVoteHash returns the digest validators sign to vote for a block.
+func HotStuffVoteHash(view uint64, hash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(HotStuffVoteRLP(view, hash))
+}
+
+// HotStuffQuorumSize returns the number of votes needed out of n validators to
+// tolerate f = (n-1)/3 byzantine ones.
+func HotStuffQuorumSize(n int) int {
+	return 2*n/3 + 1
+}
+
+// SigHash returns the vote digest signed by the validators in the certificate.
+func (qc *QuorumCert) SigHash() common.Hash {
+	return HotStuffVoteHash(qc.View, qc.BlockHash)
+}
+
+// SignerIndices decodes the signer bitmap against a validator set of size n,
+// returning the indices of the signers in ascending order.
+func (qc *QuorumCert) SignerIndices(n int) ([]int, error) {
+	if len(qc.Signers) != (n+7)/8 {
+		return nil, fmt.Errorf("%w: signer bitmap of %d bytes for %d validators", ErrInvalidQuorumCert, len(qc.Signers), n)
+	}
+	var indices []int
+	for i := 0; i < len(qc.Signers)*8; i++ {
+		if qc.Signers[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		if i >= n {
+			return nil, fmt.Errorf("%w: signer %d out of range", ErrInvalidQuorumCert, i)
+		}
+		indices = append(indices, i)
+	}
+	return indices, nil
+}
+
+// Verify checks that the certificate carries valid votes of a quorum of the
+// given validator set, using the signature scheme selected by the HotStuff
+// crypto configuration. The BLS public keys must be aligned with the
+// validators, have their proofs of possession verified (see
+// HotStuffExtra.VerifyProofs) and are ignored by the secp256k1 scheme.
+func (qc *QuorumCert) Verify(scheme string, validators []common.Address, publicKeys [][]byte) error {
+	indices, err := qc.SignerIndices(len(validators))
+	if err != nil {
+		return err
+	}
+	if len(indices) < HotStuffQuorumSize(len(validators)) {
+		return ErrInsufficientQuorum
+	}
+	digest := qc.SigHash()
+
+	switch scheme {
+	case "", params.HotStuffCryptoSecp256k1:
+		if len(qc.Signature) != len(indices)*crypto.SignatureLength {
+			return fmt.Errorf("%w: signature length %d for %d signers", ErrInvalidQuorumCert, len(qc.Signature), len(indices))
+		}
+		for i, index := range indices {
+			vote := qc.Signature[i*crypto.SignatureLength : (i+1)*crypto.SignatureLength]
+			if err := verifySecp256k1Vote(digest, validators[index], vote); err != nil {
+				return fmt.Errorf("%w: vote of %x", ErrInvalidQuorumCert, validators[index])
+			}
+		}
+		return nil
+
+	case params.HotStuffCryptoBLS:
+		if len(publicKeys) != len(validators) {
+			return fmt.Errorf("%w: %d public keys for %d validators", ErrInvalidQuorumCert, len(publicKeys), len(validators))
+		}
+		signature, err := bls.SignatureFromBytes(qc.Signature)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidQuorumCert, err)
+		}
+		keys := make([]*bls.PublicKey, len(indices))
+		for i, index := range indices {
+			if keys[i], err = bls.PublicKeyFromBytes(publicKeys[index]); err != nil {
+				return fmt.Errorf("%w: public key of %x: %v", ErrInvalidQuorumCert, validators[index], err)
+			}
+		}
+		if !bls.FastAggregateVerify(keys, digest[:], signature) {
+			return fmt.Errorf("%w: aggregated signature mismatch", ErrInvalidQuorumCert)
+		}
+		return nil
+	}
+	return ErrUnsupportedQuorumCrypto
+}
+
+// NewQuorumCert assembles the certificate of a block from the votes collected
+// for it, keyed by validator. Votes of non-validators are ignored, the rest are
+// assumed to have been checked with VerifyQuorumVote.
+func NewQuorumCert(scheme string, view uint64, hash common.Hash, validators []common.Address, votes map[common.Address][]byte) (*QuorumCert, error) {
+	qc := &QuorumCert{
+		View:      view,
+		BlockHash: hash,
+		Signers:   make([]byte, (len(validators)+7)/8),
+	}
+	var (
+		signers int
+		sigs    []*bls.Signature
+	)
+	for i, validator := range validators {
+		vote, ok := votes[validator]
+		if !ok {
+			continue
+		}
+		switch scheme {
+		case "", params.HotStuffCryptoSecp256k1:
+			qc.Signature = append(qc.Signature, vote...)
+		case params.HotStuffCryptoBLS:
+			sig, err := bls.SignatureFromBytes(vote)
+			if err != nil {
+				return nil, fmt.Errorf("%w: vote of %x: %v", ErrInvalidQuorumVote, validator, err)
+			}
+			sigs = append(sigs, sig)
+		default:
+			return nil, ErrUnsupportedQuorumCrypto
+		}
+		qc.Signers[i/8] |= 1 << (i % 8)
+		signers++
+	}
+	if signers < HotStuffQuorumSize(len(validators)) {
+		return nil, ErrInsufficientQuorum
+	}
+	if scheme == params.HotStuffCryptoBLS {
+		agg, err := bls.AggregateSignatures(sigs)
+		if err != nil {
+			return nil, err
+		}
+		qc.Signature = agg.Bytes()
+	}
+	return qc, nil
+}
+
+// VerifyQuorumVote checks that a single vote on the block with the given view and
+// hash was cast by the validator, whose BLS public key is only needed by the BLS
+// scheme.
+func VerifyQuorumVote(scheme string, view uint64, hash common.Hash, validator common.Address, publicKey []byte, vote []byte) error {
+	digest := HotStuffVoteHash(view, hash)
+
+	switch scheme {
+	case "", params.HotStuffCryptoSecp256k1:
+		return verifySecp256k1Vote(digest, validator, vote)
+
+	case params.HotStuffCryptoBLS:
+		key, err := bls.PublicKeyFromBytes(publicKey)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidQuorumVote, err)
+		}
+		signature, err := bls.SignatureFromBytes(vote)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidQuorumVote, err)
+		}
+		if !bls.Verify(key, digest[:], signature) {
+			return ErrInvalidQuorumVote
+		}
+		return nil
+	}
+	return ErrUnsupportedQuorumCrypto
+}
+
+// verifySecp256k1Vote checks that the vote is a secp256k1 signature of the digest
+// created by the validator.
+func verifySecp256k1Vote(digest common.Hash, validator common.Address, vote []byte) error {
+	if len(vote) != crypto.SignatureLength {
+		return ErrInvalidQuorumVote
+	}
+	pubkey, err := crypto.Ecrecover(digest[:], vote)
+	if err != nil {
+		return ErrInvalidQuorumVote
+	}
+	if !bytes.Equal(crypto.Keccak256(pubkey[1:])[12:], validator[:]) {
+		return ErrInvalidQuorumVote
+	}
+	return nil
+}
+
+// /HotStuff
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/crypto/bls"
+	"github.com/simplechain-org/client/params"
+)
+
+// quorumTester is a validator set with both secp256k1 and BLS keys.
+type quorumTester struct {
+	validators []common.Address
+	publicKeys [][]byte
+	keys       []*ecdsa.PrivateKey
+	blsKeys    []*bls.SecretKey
+}
+
+func newQuorumTester(n int) *quorumTester {
+	qt := new(quorumTester)
+	for i := 0; i < n; i++ {
+		key, _ := crypto.GenerateKey()
+		blsKey, _ := bls.GenerateKey(nil)
+
+		qt.validators = append(qt.validators, crypto.PubkeyToAddress(key.PublicKey))
+		qt.publicKeys = append(qt.publicKeys, blsKey.PublicKey().Bytes())
+		qt.keys = append(qt.keys, key)
+		qt.blsKeys = append(qt.blsKeys, blsKey)
+	}
+	return qt
+}
+
+// votes casts the votes of the validators with the given indices.
+func (qt *quorumTester) votes(scheme string, view uint64, hash common.Hash, signers ...int) map[common.Address][]byte {
+	digest := HotStuffVoteHash(view, hash)
+	votes := make(map[common.Address][]byte)
+	for _, i := range signers {
+		if scheme == params.HotStuffCryptoBLS {
+			votes[qt.validators[i]] = bls.Sign(qt.blsKeys[i], digest[:]).Bytes()
+		} else {
+			votes[qt.validators[i]], _ = crypto.Sign(digest[:], qt.keys[i])
+		}
+	}
+	return votes
+}
+
+func TestHotStuffQuorumSize(t *testing.T) {
+	for n, want := range map[int]int{1: 1, 2: 2, 3: 3, 4: 3, 5: 4, 6: 5, 7: 5, 10: 7} {
+		if have := HotStuffQuorumSize(n); have != want {
+			t.Errorf("quorum of %d mismatch: have %d, want %d", n, have, want)
+		}
+	}
+}
+
+func TestQuorumCertSecp256k1(t *testing.T) {
+	testQuorumCert(t, params.HotStuffCryptoSecp256k1)
+}
+
+func TestQuorumCertBLS(t *testing.T) {
+	testQuorumCert(t, params.HotStuffCryptoBLS)
+}
+
+func testQuorumCert(t *testing.T, scheme string) {
+	qt := newQuorumTester(10)
+	hash := common.Hash{0x01}
+
+	qc, err := NewQuorumCert(scheme, 7, hash, qt.validators, qt.votes(scheme, 7, hash, 0, 2, 3, 5, 6, 8, 9))
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if want := []byte{0x6d, 0x03}; !bytes.Equal(qc.Signers, want) {
+		t.Fatalf("signer bitmap mismatch: have %x, want %x", qc.Signers, want)
+	}
+	if err := qc.Verify(scheme, qt.validators, qt.publicKeys); err != nil {
+		t.Fatalf("valid certificate rejected: %v", err)
+	}
+	for i, index := range []int{0, 2, 3, 5, 6, 8, 9} {
+		if err := VerifyQuorumVote(scheme, 7, hash, qt.validators[index], qt.publicKeys[index], qt.votes(scheme, 7, hash, index)[qt.validators[index]]); err != nil {
+			t.Errorf("vote %d rejected: %v", i, err)
+		}
+	}
+	if _, err := NewQuorumCert(scheme, 7, hash, qt.validators, qt.votes(scheme, 7, hash, 0, 1, 2, 3, 4, 5)); !errors.Is(err, ErrInsufficientQuorum) {
+		t.Errorf("sub-quorum assembly error mismatch: have %v, want %v", err, ErrInsufficientQuorum)
+	}
+	tests := []struct {
+		name   string
+		tamper func(qc *QuorumCert)
+		err    error
+	}{
+		{"view", func(qc *QuorumCert) { qc.View++ }, ErrInvalidQuorumCert},
+		{"hash", func(qc *QuorumCert) { qc.BlockHash = common.Hash{0x02} }, ErrInvalidQuorumCert},
+		{"swapped signer", func(qc *QuorumCert) { qc.Signers[0] ^= 0x03 }, ErrInvalidQuorumCert},
+		{"added signer", func(qc *QuorumCert) { qc.Signers[0] |= 0x02 }, ErrInvalidQuorumCert},
+		{"signer out of range", func(qc *QuorumCert) { qc.Signers[1] |= 0x04 }, ErrInvalidQuorumCert},
+		{"short bitmap", func(qc *QuorumCert) { qc.Signers = qc.Signers[:1] }, ErrInvalidQuorumCert},
+		{"below quorum", func(qc *QuorumCert) { qc.Signers[0] &^= 0x0c }, ErrInsufficientQuorum},
+	}
+	for _, tt := range tests {
+		cpy := &QuorumCert{View: qc.View, BlockHash: qc.BlockHash, Signers: common.CopyBytes(qc.Signers), Signature: common.CopyBytes(qc.Signature)}
+		tt.tamper(cpy)
+		if err := cpy.Verify(scheme, qt.validators, qt.publicKeys); !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+	if err := qc.Verify("unknown", qt.validators, qt.publicKeys); !errors.Is(err, ErrUnsupportedQuorumCrypto) {
+		t.Errorf("unknown scheme error mismatch: have %v, want %v", err, ErrUnsupportedQuorumCrypto)
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bls implements BLS signatures over the BLS12-381 curve, following the
+// proof-of-possession ciphersuite of the IETF BLS signature draft with public
+// keys in G1 and signatures in G2.
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+package bls
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/simplechain-org/client/crypto/bls12381"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// SecretKeyLength is the length of a serialized secret key.
+	SecretKeyLength = 32
+
+	// PublicKeyLength is the length of a compressed public key.
+	PublicKeyLength = 48
+
+	// SignatureLength is the length of a compressed signature.
+	SignatureLength = 96
+)
+
+var (
+	// SignatureDST is the domain separation tag of message signatures.
+	SignatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+	// ProofOfPossessionDST is the domain separation tag of proofs of possession.
+	ProofOfPossessionDST = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+var (
+	errShortSeed         = errors.New("bls: key material shorter than 32 bytes")
+	errInvalidSecretKey  = errors.New("bls: invalid secret key")
+	errInvalidPublicKey  = errors.New("bls: invalid public key")
+	errInvalidSignature  = errors.New("bls: invalid signature")
+	errEmptyAggregation  = errors.New("bls: nothing to aggregate")
+	errInfinityPublicKey = errors.New("bls: public key is the identity point")
+)
+
+// order is the order of the G1 and G2 subgroups.
+var order = bls12381.NewG1().Q()
+
+// SecretKey is a BLS secret key, a non-zero scalar modulo the group order.
+type SecretKey struct {
+	k *big.Int
+}
+
+// PublicKey is a BLS public key, a point in G1.
+type PublicKey struct {
+	p *bls12381.PointG1
+}
+
+// Signature is a BLS signature, a point in G2.
+type Signature struct {
+	p *bls12381.PointG2
+}
+
+// GenerateKey creates a new secret key from the given source of randomness,
+// falling back to crypto/rand if none is given.
+func GenerateKey(r io.Reader) (*SecretKey, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(r, ikm); err != nil {
+		return nil, err
+	}
+	return KeyGen(ikm, nil)
+}
+
+// KeyGen deterministically derives a secret key from at least 32 bytes of
+// input key material and optional key information.
+func KeyGen(ikm, keyInfo []byte) (*SecretKey, error) {
+	if len(ikm) < 32 {
+		return nil, errShortSeed
+	}
+	// L = ceil((3 * ceil(log2(r))) / 16) = 48
+	const L = 48
+
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	k := new(big.Int)
+	for k.Sign() == 0 {
+		digest := sha256.Sum256(salt)
+		salt = digest[:]
+
+		okm := make([]byte, L)
+		reader := hkdf.New(sha256.New, append(append([]byte{}, ikm...), 0), salt, append(append([]byte{}, keyInfo...), 0, L))
+		if _, err := io.ReadFull(reader, okm); err != nil {
+			return nil, err
+		}
+		k.SetBytes(okm).Mod(k, order)
+	}
+	return &SecretKey{k: k}, nil
+}
+
+// SecretKeyFromBytes parses a big endian serialized secret key.
+func SecretKeyFromBytes(b []byte) (*SecretKey, error) {
+	if len(b) != SecretKeyLength {
+		return nil, errInvalidSecretKey
+	}
+	k := new(big.Int).SetBytes(b)
+	if k.Sign() == 0 || k.Cmp(order) >= 0 {
+		return nil, errInvalidSecretKey
+	}
+	return &SecretKey{k: k}, nil
+}
+
+// Bytes serializes the secret key in big endian form.
+func (sk *SecretKey) Bytes() []byte {
+	out := make([]byte, SecretKeyLength)
+	return sk.k.FillBytes(out)
+}
+
+// PublicKey derives the public key belonging to the secret key.
+func (sk *SecretKey) PublicKey() *PublicKey {
+	g1 := bls12381.NewG1()
+	return &PublicKey{p: g1.MulScalar(g1.New(), g1.One(), sk.k)}
+}
+
+// PublicKeyFromBytes parses and validates a compressed public key, rejecting
+// the identity and points outside of the prime order subgroup.
+func PublicKeyFromBytes(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeyLength {
+		return nil, errInvalidPublicKey
+	}
+	g1 := bls12381.NewG1()
+	p, err := g1.FromCompressed(b)
+	if err != nil {
+		return nil, err
+	}
+	if g1.IsZero(p) {
+		return nil, errInfinityPublicKey
+	}
+	return &PublicKey{p: p}, nil
+}
+
+// Bytes serializes the public key in compressed form.
+func (pk *PublicKey) Bytes() []byte {
+	return bls12381.NewG1().ToCompressed(new(bls12381.PointG1).Set(pk.p))
+}
+
+// Equal reports whether two public keys are the same.
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return bls12381.NewG1().Equal(pk.p, other.p)
+}
+
+// SignatureFromBytes parses and validates a compressed signature.
+func SignatureFromBytes(b []byte) (*Signature, error) {
+	if len(b) != SignatureLength {
+		return nil, errInvalidSignature
+	}
+	p, err := bls12381.NewG2().FromCompressed(b)
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{p: p}, nil
+}
+
+// Bytes serializes the signature in compressed form.
+func (sig *Signature) Bytes() []byte {
+	return bls12381.NewG2().ToCompressed(new(bls12381.PointG2).Set(sig.p))
+}
+
+// Sign signs a message with the secret key.
+func Sign(sk *SecretKey, msg []byte) *Signature {
+	return coreSign(sk, msg, SignatureDST)
+}
+
+// Verify checks that the signature was created over the message by the owner
+// of the public key.
+func Verify(pk *PublicKey, msg []byte, sig *Signature) bool {
+	return coreVerify(pk, msg, sig, SignatureDST)
+}
+
+// AggregateSignatures combines multiple signatures into a single one.
+func AggregateSignatures(sigs []*Signature) (*Signature, error) {
+	if len(sigs) == 0 {
+		return nil, errEmptyAggregation
+	}
+	g2 := bls12381.NewG2()
+	agg := g2.New()
+	for _, sig := range sigs {
+		g2.Add(agg, agg, sig.p)
+	}
+	return &Signature{p: agg}, nil
+}
+
+// AggregatePublicKeys combines multiple public keys into a single one. The
+// result is only meaningful if every key has a verified proof of possession.
+func AggregatePublicKeys(pks []*PublicKey) (*PublicKey, error) {
+	if len(pks) == 0 {
+		return nil, errEmptyAggregation
+	}
+	g1 := bls12381.NewG1()
+	agg := g1.New()
+	for _, pk := range pks {
+		g1.Add(agg, agg, pk.p)
+	}
+	return &PublicKey{p: agg}, nil
+}
+
+// AggregateVerify checks an aggregated signature over a distinct message per
+// public key.
+func AggregateVerify(pks []*PublicKey, msgs [][]byte, sig *Signature) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	var (
+		g1     = bls12381.NewG1()
+		g2     = bls12381.NewG2()
+		engine = bls12381.NewPairingEngine()
+	)
+	if !g2.InCorrectSubgroup(sig.p) {
+		return false
+	}
+	for i, pk := range pks {
+		if g1.IsZero(pk.p) {
+			return false
+		}
+		h, err := g2.HashToCurve(msgs[i], SignatureDST)
+		if err != nil {
+			return false
+		}
+		engine.AddPair(pk.p, h)
+	}
+	engine.AddPairInv(g1.One(), sig.p)
+	return engine.Check()
+}
+
+// FastAggregateVerify checks an aggregated signature of the same message by all
+// the given public keys, each of which must have a verified proof of possession.
+func FastAggregateVerify(pks []*PublicKey, msg []byte, sig *Signature) bool {
+	agg, err := AggregatePublicKeys(pks)
+	if err != nil {
+		return false
+	}
+	return coreVerify(agg, msg, sig, SignatureDST)
+}
+
+// PopProve creates a proof of possession of the secret key, protecting the
+// aggregation of public keys against rogue key attacks.
+func PopProve(sk *SecretKey) *Signature {
+	return coreSign(sk, sk.PublicKey().Bytes(), ProofOfPossessionDST)
+}
+
+// PopVerify checks a proof of possession of the secret key behind the public key.
+func PopVerify(pk *PublicKey, proof *Signature) bool {
+	return coreVerify(pk, pk.Bytes(), proof, ProofOfPossessionDST)
+}
+
+// coreSign signs a message under the given domain separation tag.
+func coreSign(sk *SecretKey, msg, dst []byte) *Signature {
+	g2 := bls12381.NewG2()
+	h, err := g2.HashToCurve(msg, dst)
+	if err != nil {
+		// Only possible with oversized domain tags, which are all constants
+		panic("bls: " + err.Error())
+	}
+	return &Signature{p: g2.MulScalar(g2.New(), h, sk.k)}
+}
+
+// coreVerify checks a signature under the given domain separation tag.
+func coreVerify(pk *PublicKey, msg []byte, sig *Signature, dst []byte) bool {
+	var (
+		g1 = bls12381.NewG1()
+		g2 = bls12381.NewG2()
+	)
+	if g1.IsZero(pk.p) || !g2.InCorrectSubgroup(sig.p) {
+		return false
+	}
+	h, err := g2.HashToCurve(msg, dst)
+	if err != nil {
+		return false
+	}
+	engine := bls12381.NewPairingEngine()
+	engine.AddPair(pk.p, h)
+	engine.AddPairInv(g1.One(), sig.p)
+	return engine.Check()
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+)
+
+func newTestKeys(t *testing.T, n int) ([]*SecretKey, []*PublicKey) {
+	sks := make([]*SecretKey, n)
+	pks := make([]*PublicKey, n)
+	for i := 0; i < n; i++ {
+		sk, err := GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		sks[i], pks[i] = sk, sk.PublicKey()
+	}
+	return sks, pks
+}
+
+func TestSignVerify(t *testing.T) {
+	sks, pks := newTestKeys(t, 2)
+	msg := []byte("hotstuff")
+
+	sig := Sign(sks[0], msg)
+	if !Verify(pks[0], msg, sig) {
+		t.Fatal("valid signature rejected")
+	}
+	if Verify(pks[1], msg, sig) {
+		t.Error("signature accepted for wrong key")
+	}
+	if Verify(pks[0], []byte("other"), sig) {
+		t.Error("signature accepted for wrong message")
+	}
+}
+
+// Tests signing against a known vector of the Ethereum consensus spec tests,
+// which use the same proof-of-possession ciphersuite.
+func TestSignVector(t *testing.T) {
+	sk, err := SecretKeyFromBytes(common.FromHex("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3"))
+	if err != nil {
+		t.Fatalf("failed to parse secret key: %v", err)
+	}
+	want := common.FromHex("b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55")
+	if have := Sign(sk, make([]byte, 32)).Bytes(); !bytes.Equal(have, want) {
+		t.Fatalf("signature mismatch: have %x, want %x", have, want)
+	}
+}
+
+func TestSerialization(t *testing.T) {
+	sks, pks := newTestKeys(t, 1)
+	sig := Sign(sks[0], []byte("hotstuff"))
+
+	sk, err := SecretKeyFromBytes(sks[0].Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse secret key: %v", err)
+	}
+	if sk.k.Cmp(sks[0].k) != 0 {
+		t.Error("secret key mismatch")
+	}
+	pk, err := PublicKeyFromBytes(pks[0].Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse public key: %v", err)
+	}
+	if !pk.Equal(pks[0]) {
+		t.Error("public key mismatch")
+	}
+	dec, err := SignatureFromBytes(sig.Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse signature: %v", err)
+	}
+	if !bytes.Equal(dec.Bytes(), sig.Bytes()) {
+		t.Error("signature mismatch")
+	}
+	// The identity point must never be accepted as a public key
+	identity := make([]byte, PublicKeyLength)
+	identity[0] = 0xc0
+	if _, err := PublicKeyFromBytes(identity); err != errInfinityPublicKey {
+		t.Errorf("identity key error mismatch: have %v, want %v", err, errInfinityPublicKey)
+	}
+	if _, err := SecretKeyFromBytes(make([]byte, SecretKeyLength)); err != errInvalidSecretKey {
+		t.Errorf("zero key error mismatch: have %v, want %v", err, errInvalidSecretKey)
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+	ikm := bytes.Repeat([]byte{0x42}, 32)
+	a, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatalf("failed to derive key: %v", err)
+	}
+	b, _ := KeyGen(ikm, nil)
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Error("key derivation not deterministic")
+	}
+	c, _ := KeyGen(ikm, []byte("info"))
+	if bytes.Equal(a.Bytes(), c.Bytes()) {
+		t.Error("key info ignored")
+	}
+	if _, err := KeyGen(ikm[:31], nil); err != errShortSeed {
+		t.Errorf("short seed error mismatch: have %v, want %v", err, errShortSeed)
+	}
+}
+
+func TestAggregation(t *testing.T) {
+	sks, pks := newTestKeys(t, 4)
+	msg := []byte("hotstuff")
+
+	sigs := make([]*Signature, len(sks))
+	msgs := make([][]byte, len(sks))
+	distinct := make([]*Signature, len(sks))
+	for i, sk := range sks {
+		sigs[i] = Sign(sk, msg)
+		msgs[i] = []byte{byte(i)}
+		distinct[i] = Sign(sk, msgs[i])
+	}
+	agg, err := AggregateSignatures(sigs)
+	if err != nil {
+		t.Fatalf("failed to aggregate signatures: %v", err)
+	}
+	if !FastAggregateVerify(pks, msg, agg) {
+		t.Fatal("valid aggregate rejected")
+	}
+	if FastAggregateVerify(pks[:3], msg, agg) {
+		t.Error("aggregate accepted with missing signer")
+	}
+	aggKey, _ := AggregatePublicKeys(pks)
+	if !Verify(aggKey, msg, agg) {
+		t.Error("aggregate rejected under aggregated key")
+	}
+	aggDistinct, _ := AggregateSignatures(distinct)
+	if !AggregateVerify(pks, msgs, aggDistinct) {
+		t.Fatal("valid distinct message aggregate rejected")
+	}
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	if AggregateVerify(pks, msgs, aggDistinct) {
+		t.Error("aggregate accepted with swapped messages")
+	}
+	if _, err := AggregateSignatures(nil); err != errEmptyAggregation {
+		t.Errorf("empty aggregation error mismatch: have %v, want %v", err, errEmptyAggregation)
+	}
+}
+
+func TestProofOfPossession(t *testing.T) {
+	sks, pks := newTestKeys(t, 2)
+
+	proof := PopProve(sks[0])
+	if !PopVerify(pks[0], proof) {
+		t.Fatal("valid proof rejected")
+	}
+	if PopVerify(pks[1], proof) {
+		t.Error("proof accepted for wrong key")
+	}
+	// A proof of possession must not double as a message signature
+	if Verify(pks[0], pks[0].Bytes(), proof) {
+		t.Error("proof accepted as message signature")
+	}
+}
@@ -176,6 +176,15 @@ func (e *fe) sign() bool {
 	return r[0]&1 == 0
 }
 
+// signBE reports whether the element is lexicographically larger than its
+// negation, as used by the compressed point encoding.
+func (e *fe) signBE() bool {
+	negZ, z := new(fe), new(fe)
+	fromMont(z, e)
+	neg(negZ, z)
+	return z.cmp(negZ) > 0
+}
+
 func (fe *fe) div2(e uint64) {
 	fe[0] = fe[0]>>1 | fe[1]<<63
 	fe[1] = fe[1]>>1 | fe[2]<<63
@@ -196,6 +205,15 @@ func (fe *fe) mul2() uint64 {
 	return e
 }
 
+// signBE reports whether the element is lexicographically larger than its
+// negation, comparing the imaginary part first.
+func (e *fe2) signBE() bool {
+	if !e[1].isZero() {
+		return e[1].signBE()
+	}
+	return e[0].signBE()
+}
+
 func (e *fe2) zero() *fe2 {
 	e[0].zero()
 	e[1].zero()
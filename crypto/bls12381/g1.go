@@ -147,6 +147,72 @@ func (g *G1) ToBytes(p *PointG1) []byte {
 	return out
 }
 
+// FromCompressed expects byte slice at least 48 bytes and given bytes returns a new point in G1.
+// Serialization rules are in line with zcash library. See below for details.
+// https://github.com/zcash/librustzcash/blob/master/pairing/src/bls12_381/README.md#serialization
+// https://docs.rs/bls12_381/0.1.1/bls12_381/notes/serialization/index.html
+func (g *G1) FromCompressed(compressed []byte) (*PointG1, error) {
+	if len(compressed) != 48 {
+		return nil, errors.New("input string should be equal 48 bytes")
+	}
+	a := make([]byte, 48)
+	copy(a, compressed[:])
+	if !isBitSet(a[0], 7) {
+		return nil, errors.New("compression flag must be set")
+	}
+	if isBitSet(a[0], 6) {
+		// in zcash infinity point is (0,0) with the infinity flag set and no other bits
+		a[0] &= 0x3f
+		for i := 0; i < 48; i++ {
+			if a[i] != 0 {
+				return nil, errors.New("input string should be zero when infinity flag is set")
+			}
+		}
+		return g.Zero(), nil
+	}
+	a[0] &= 0x1f
+	x, err := fromBytes(a)
+	if err != nil {
+		return nil, err
+	}
+	// solve curve equation
+	y := &fe{}
+	square(y, x)
+	mul(y, y, x)
+	add(y, y, b)
+	if ok := sqrt(y, y); !ok {
+		return nil, errors.New("point is not on curve")
+	}
+	if y.signBE() != isBitSet(compressed[0], 5) {
+		neg(y, y)
+	}
+	z := new(fe).one()
+	p := &PointG1{*x, *y, *z}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not on correct subgroup")
+	}
+	return p, nil
+}
+
+// ToCompressed given a G1 point returns bytes in compressed form of the point.
+// Serialization rules are in line with zcash library. See below for details.
+// https://github.com/zcash/librustzcash/blob/master/pairing/src/bls12_381/README.md#serialization
+// https://docs.rs/bls12_381/0.1.1/bls12_381/notes/serialization/index.html
+func (g *G1) ToCompressed(p *PointG1) []byte {
+	out := make([]byte, 48)
+	g.Affine(p)
+	if g.IsZero(p) {
+		out[0] |= 1 << 6
+	} else {
+		copy(out[:], toBytes(&p[0]))
+		if p[1].signBE() {
+			out[0] |= 1 << 5
+		}
+	}
+	out[0] |= 1 << 7
+	return out
+}
+
 // EncodePoint encodes a point into 128 bytes.
 func (g *G1) EncodePoint(p *PointG1) []byte {
 	outRaw := g.ToBytes(p)
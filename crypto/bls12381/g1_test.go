@@ -51,6 +51,24 @@ func TestG1Serialization(t *testing.T) {
 			t.Fatal("bad serialization encode/decode")
 		}
 	}
+	for i := 0; i < fuz; i++ {
+		a := g1.rand()
+		compressed := g1.ToCompressed(a)
+		b, err := g1.FromCompressed(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g1.Equal(a, b) {
+			t.Fatal("bad serialization compress/decompress")
+		}
+	}
+	zero, err := g1.FromCompressed(g1.ToCompressed(g1.Zero()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g1.IsZero(zero) {
+		t.Fatal("bad infinity compress/decompress")
+	}
 }
 
 func TestG1IsOnCurve(t *testing.T) {
@@ -165,6 +165,72 @@ func (g *G2) ToBytes(p *PointG2) []byte {
 	return out
 }
 
+// FromCompressed expects byte slice at least 96 bytes and given bytes returns a new point in G2.
+// Serialization rules are in line with zcash library. See below for details.
+// https://github.com/zcash/librustzcash/blob/master/pairing/src/bls12_381/README.md#serialization
+// https://docs.rs/bls12_381/0.1.1/bls12_381/notes/serialization/index.html
+func (g *G2) FromCompressed(compressed []byte) (*PointG2, error) {
+	if len(compressed) != 96 {
+		return nil, errors.New("input string should be equal 96 bytes")
+	}
+	a := make([]byte, 96)
+	copy(a, compressed[:])
+	if !isBitSet(a[0], 7) {
+		return nil, errors.New("compression flag must be set")
+	}
+	if isBitSet(a[0], 6) {
+		// in zcash infinity point is (0,0) with the infinity flag set and no other bits
+		a[0] &= 0x3f
+		for i := 0; i < 96; i++ {
+			if a[i] != 0 {
+				return nil, errors.New("input string should be zero when infinity flag is set")
+			}
+		}
+		return g.Zero(), nil
+	}
+	a[0] &= 0x1f
+	x, err := g.f.fromBytes(a)
+	if err != nil {
+		return nil, err
+	}
+	// solve curve equation
+	y := &fe2{}
+	g.f.square(y, x)
+	g.f.mul(y, y, x)
+	g.f.add(y, y, b2)
+	if ok := g.f.sqrt(y, y); !ok {
+		return nil, errors.New("point is not on curve")
+	}
+	if y.signBE() != isBitSet(compressed[0], 5) {
+		g.f.neg(y, y)
+	}
+	z := new(fe2).one()
+	p := &PointG2{*x, *y, *z}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not on correct subgroup")
+	}
+	return p, nil
+}
+
+// ToCompressed given a G2 point returns bytes in compressed form of the point.
+// Serialization rules are in line with zcash library. See below for details.
+// https://github.com/zcash/librustzcash/blob/master/pairing/src/bls12_381/README.md#serialization
+// https://docs.rs/bls12_381/0.1.1/bls12_381/notes/serialization/index.html
+func (g *G2) ToCompressed(p *PointG2) []byte {
+	out := make([]byte, 96)
+	g.Affine(p)
+	if g.IsZero(p) {
+		out[0] |= 1 << 6
+	} else {
+		copy(out[:], g.f.toBytes(&p[0]))
+		if p[1].signBE() {
+			out[0] |= 1 << 5
+		}
+	}
+	out[0] |= 1 << 7
+	return out
+}
+
 // EncodePoint encodes a point into 256 bytes.
 func (g *G2) EncodePoint(p *PointG2) []byte {
 	// outRaw is 96 bytes
@@ -53,6 +53,24 @@ func TestG2Serialization(t *testing.T) {
 			t.Fatal("bad serialization encode/decode")
 		}
 	}
+	for i := 0; i < fuz; i++ {
+		a := g2.rand()
+		compressed := g2.ToCompressed(a)
+		b, err := g2.FromCompressed(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g2.Equal(a, b) {
+			t.Fatal("bad serialization compress/decompress")
+		}
+	}
+	zero, err := g2.FromCompressed(g2.ToCompressed(g2.Zero()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g2.IsZero(zero) {
+		t.Fatal("bad infinity compress/decompress")
+	}
 }
 
 func TestG2IsOnCurve(t *testing.T) {
@@ -0,0 +1,120 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls12381
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// HashToCurve hashes an arbitrary message into a G2 point following the
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ suite of the hash-to-curve specification.
+// https://datatracker.ietf.org/doc/html/rfc9380#section-8.8.2
+func (g *G2) HashToCurve(msg, domain []byte) (*PointG2, error) {
+	u, err := hashToFp2(msg, domain, 2)
+	if err != nil {
+		return nil, err
+	}
+	q0, q1 := g.mapToCurveNoClear(u[0]), g.mapToCurveNoClear(u[1])
+	r := g.New()
+	g.Add(r, q0, q1)
+	g.ClearCofactor(r)
+	return g.Affine(r), nil
+}
+
+// mapToCurveNoClear maps a field element onto the curve without clearing the
+// cofactor, which hash-to-curve does once for the sum of both mapped points.
+func (g *G2) mapToCurveNoClear(u *fe2) *PointG2 {
+	x, y := swuMapG2(g.f, u)
+	isogenyMapG2(g.f, x, y)
+	return &PointG2{*x, *y, *new(fe2).one()}
+}
+
+// hashToFp2 hashes a message into count Fp2 elements with uniformly random
+// distribution.
+func hashToFp2(msg, domain []byte, count int) ([]*fe2, error) {
+	// L = ceil((ceil(log2(p)) + k) / 8) = 64 for a 128 bit security level
+	const L = 64
+
+	uniform, err := expandMsgXMD(msg, domain, count*2*L)
+	if err != nil {
+		return nil, err
+	}
+	p := modulus.big()
+	elems := make([]*fe2, count)
+	for i := 0; i < count; i++ {
+		elems[i] = new(fe2)
+		for j := 0; j < 2; j++ {
+			offset := L * (j + i*2)
+			v := new(big.Int).SetBytes(uniform[offset : offset+L])
+			e, err := fromBig(v.Mod(v, p))
+			if err != nil {
+				return nil, err
+			}
+			elems[i][j].set(e)
+		}
+	}
+	return elems, nil
+}
+
+// expandMsgXMD expands a message into the requested number of uniformly random
+// bytes using SHA-256.
+// https://datatracker.ietf.org/doc/html/rfc9380#section-5.3.1
+func expandMsgXMD(msg, domain []byte, outLen int) ([]byte, error) {
+	const (
+		bInBytes = sha256.Size
+		rInBytes = sha256.BlockSize
+	)
+	ell := (outLen + bInBytes - 1) / bInBytes
+	if ell > 255 || outLen > 65535 {
+		return nil, errors.New("requested output too long")
+	}
+	if len(domain) > 255 {
+		return nil, errors.New("domain separation tag too long")
+	}
+	dstPrime := append(append([]byte{}, domain...), byte(len(domain)))
+
+	h := sha256.New()
+	h.Write(make([]byte, rInBytes))
+	h.Write(msg)
+	h.Write([]byte{byte(outLen >> 8), byte(outLen), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	out := make([]byte, 0, ell*bInBytes)
+	out = append(out, bi...)
+	for i := 2; i <= ell; i++ {
+		tmp := make([]byte, bInBytes)
+		for j := range tmp {
+			tmp[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(tmp)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:outLen], nil
+}
@@ -0,0 +1,58 @@
+package bls12381
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+)
+
+func TestExpandMsgXMD(t *testing.T) {
+	domain := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for i, v := range []struct {
+		msg      string
+		expected []byte
+	}{
+		{"", common.FromHex("68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235")},
+		{"abc", common.FromHex("d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615")},
+	} {
+		out, err := expandMsgXMD([]byte(v.msg), domain, len(v.expected))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, v.expected) {
+			t.Fatalf("expand mismatch at %d: have %x, want %x", i, out, v.expected)
+		}
+	}
+}
+
+func TestG2HashToCurve(t *testing.T) {
+	g := NewG2()
+	domain := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	expected := common.FromHex("" +
+		"05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d" +
+		"0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a" +
+		"12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6" +
+		"0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+	)
+	p, err := g.HashToCurve([]byte(""), domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(g.ToBytes(p), expected) {
+		t.Fatalf("hash to curve mismatch: have %x, want %x", g.ToBytes(p), expected)
+	}
+	if !g.InCorrectSubgroup(p) {
+		t.Fatal("hashed point not in correct subgroup")
+	}
+}
+
+func TestCompressedGenerators(t *testing.T) {
+	g1, g2 := NewG1(), NewG2()
+	if have, want := g1.ToCompressed(g1.One()), common.FromHex("97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"); !bytes.Equal(have, want) {
+		t.Fatalf("g1 generator mismatch: have %x, want %x", have, want)
+	}
+	if have, want := g2.ToCompressed(g2.One()), common.FromHex("93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"); !bytes.Equal(have, want) {
+		t.Fatalf("g2 generator mismatch: have %x, want %x", have, want)
+	}
+}
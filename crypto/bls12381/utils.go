@@ -43,3 +43,7 @@ func decodeFieldElement(in []byte) ([]byte, error) {
 	copy(out[:], in[16:])
 	return out, nil
 }
+
+func isBitSet(b byte, i int) bool {
+	return b&(1<<uint(i)) != 0
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/rpc"
+)
+
+// methodNotFoundCode is the JSON-RPC error code of calls to unknown methods.
+const methodNotFoundCode = -32601
+
+// BatchOptions configures how the batched retrieval methods split their work.
+type BatchOptions struct {
+	ChunkSize   int // Maximum number of calls sent in a single batch request
+	Concurrency int // Maximum number of batch requests in flight at once
+}
+
+// DefaultBatchOptions is used when no options, or zero fields, are given.
+var DefaultBatchOptions = BatchOptions{
+	ChunkSize:   100,
+	Concurrency: 4,
+}
+
+func (opts *BatchOptions) chunkSize() int {
+	if opts == nil || opts.ChunkSize <= 0 {
+		return DefaultBatchOptions.ChunkSize
+	}
+	return opts.ChunkSize
+}
+
+func (opts *BatchOptions) concurrency() int {
+	if opts == nil || opts.Concurrency <= 0 {
+		return DefaultBatchOptions.Concurrency
+	}
+	return opts.Concurrency
+}
+
+// BatchError is returned by the batched retrieval methods if some of the items
+// could not be retrieved. The results of the failed items are left nil, all
+// others are valid.
+type BatchError struct {
+	Total  int           // Number of items requested
+	Errors map[int]error // Failures keyed by the index of the item
+}
+
+func newBatchError(total int) *BatchError {
+	return &BatchError{Total: total, Errors: make(map[int]error)}
+}
+
+// Failed returns the indices of the items that failed, in ascending order.
+func (e *BatchError) Failed() []int {
+	failed := make([]int, 0, len(e.Errors))
+	for index := range e.Errors {
+		failed = append(failed, index)
+	}
+	sort.Ints(failed)
+	return failed
+}
+
+func (e *BatchError) Error() string {
+	first := e.Failed()[0]
+	return fmt.Sprintf("%d of %d batched items failed, first at index %d: %v", len(e.Errors), e.Total, first, e.Errors[first])
+}
+
+// result returns the batch error if any items failed, nil otherwise.
+func (e *BatchError) result() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// BlocksByNumberRange returns the canonical blocks in the inclusive range
+// [from, to]. Blocks that could not be retrieved are left nil and reported
+// through a *BatchError.
+//
+// The uncles of all blocks are retrieved in a second round of batches.
+func (ec *Client) BlocksByNumberRange(ctx context.Context, from, to uint64, opts *BatchOptions) ([]*types.Block, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+	if to-from == math.MaxUint64 {
+		return nil, fmt.Errorf("block range %d-%d too large", from, to)
+	}
+	var (
+		raws  = make([]json.RawMessage, to-from+1)
+		reqs  = make([]rpc.BatchElem, len(raws))
+		fails = newBatchError(len(raws))
+	)
+	for i := range reqs {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(from + uint64(i)), true},
+			Result: &raws[i],
+		}
+	}
+	ec.batchCall(ctx, reqs, opts)
+
+	// Decode the blocks, collecting the retrievals of their uncles
+	var (
+		heads     = make([]*types.Header, len(raws))
+		bodies    = make([]*rpcBlock, len(raws))
+		uncles    = make([][]*types.Header, len(raws))
+		uncleReqs []rpc.BatchElem
+		firsts    = make([]int, len(raws)) // Index of the first uncle request of each block
+	)
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			fails.Errors[i] = reqs[i].Error
+			continue
+		}
+		head, body, err := parseBlock(raws[i])
+		if err != nil {
+			fails.Errors[i] = err
+			continue
+		}
+		heads[i], bodies[i] = head, body
+		if len(body.UncleHashes) > 0 {
+			uncles[i] = make([]*types.Header, len(body.UncleHashes))
+			firsts[i] = len(uncleReqs)
+			uncleReqs = append(uncleReqs, uncleRequests(body.Hash, uncles[i])...)
+		}
+	}
+	ec.batchCall(ctx, uncleReqs, opts)
+
+	blocks := make([]*types.Block, len(raws))
+	for i, body := range bodies {
+		if body == nil {
+			continue
+		}
+		if n := len(uncles[i]); n > 0 {
+			if err := checkUncles(body.Hash, uncleReqs[firsts[i]:firsts[i]+n], uncles[i]); err != nil {
+				fails.Errors[i] = err
+				continue
+			}
+		}
+		blocks[i] = body.block(heads[i], uncles[i])
+	}
+	return blocks, fails.result()
+}
+
+// ReceiptsByBlock returns the receipts of all transactions in the given block.
+//
+// The receipts are retrieved in one call via eth_getBlockReceipts if the server
+// supports it, otherwise with batched eth_getTransactionReceipt calls. In the
+// latter case, receipts that could not be retrieved are left nil and reported
+// through a *BatchError.
+func (ec *Client) ReceiptsByBlock(ctx context.Context, hash common.Hash, opts *BatchOptions) ([]*types.Receipt, error) {
+	if atomic.LoadInt32(&ec.noBlockReceipts) == 0 {
+		var receipts []*types.Receipt
+		err := ec.c.CallContext(ctx, &receipts, "eth_getBlockReceipts", hash)
+		if err == nil {
+			if receipts == nil {
+				return nil, client.NotFound
+			}
+			return receipts, nil
+		}
+		if !isMethodNotFound(err) {
+			return nil, err
+		}
+		atomic.StoreInt32(&ec.noBlockReceipts, 1)
+	}
+	var block *struct {
+		Transactions []common.Hash `json:"transactions"`
+	}
+	if err := ec.c.CallContext(ctx, &block, "eth_getBlockByHash", hash, false); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, client.NotFound
+	}
+	var (
+		receipts = make([]*types.Receipt, len(block.Transactions))
+		reqs     = make([]rpc.BatchElem, len(receipts))
+		fails    = newBatchError(len(receipts))
+	)
+	for i, txhash := range block.Transactions {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{txhash},
+			Result: &receipts[i],
+		}
+	}
+	ec.batchCall(ctx, reqs, opts)
+
+	for i := range reqs {
+		switch {
+		case reqs[i].Error != nil:
+			fails.Errors[i] = reqs[i].Error
+			receipts[i] = nil
+		case receipts[i] == nil:
+			fails.Errors[i] = client.NotFound
+		}
+	}
+	return receipts, fails.result()
+}
+
+// BalancesAt returns the wei balances of the given accounts. The block number
+// can be nil, in which case the balances are taken from the latest known
+// block. Balances that could not be retrieved are left nil and reported
+// through a *BatchError.
+func (ec *Client) BalancesAt(ctx context.Context, accounts []common.Address, blockNumber *big.Int, opts *BatchOptions) ([]*big.Int, error) {
+	var (
+		results = make([]hexutil.Big, len(accounts))
+		reqs    = make([]rpc.BatchElem, len(accounts))
+		fails   = newBatchError(len(accounts))
+	)
+	for i, account := range accounts {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []interface{}{account, toBlockNumArg(blockNumber)},
+			Result: &results[i],
+		}
+	}
+	ec.batchCall(ctx, reqs, opts)
+
+	balances := make([]*big.Int, len(accounts))
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			fails.Errors[i] = reqs[i].Error
+			continue
+		}
+		balances[i] = (*big.Int)(&results[i])
+	}
+	return balances, fails.result()
+}
+
+// batchCall sends the requests in chunks, keeping a limited number of batches
+// in flight. Failures of a whole batch are recorded on each of its requests, so
+// callers only need to inspect the individual request errors.
+func (ec *Client) batchCall(ctx context.Context, reqs []rpc.BatchElem, opts *BatchOptions) {
+	var (
+		size  = opts.chunkSize()
+		slots = make(chan struct{}, opts.concurrency())
+		wg    sync.WaitGroup
+	)
+	for start := 0; start < len(reqs); start += size {
+		end := start + size
+		if end > len(reqs) {
+			end = len(reqs)
+		}
+		chunk := reqs[start:end]
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			if err := ec.c.BatchCallContext(ctx, chunk); err != nil {
+				for i := range chunk {
+					chunk[i].Error = err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// isMethodNotFound reports whether the error signals a call to a method the
+// server does not provide.
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethclient"
+	"github.com/simplechain-org/client/ethclient/mocknode"
+	"github.com/simplechain-org/client/params"
+	"github.com/simplechain-org/client/rpc"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testTo     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+)
+
+// methodCounter counts the served calls by method.
+type methodCounter struct {
+	lock  sync.Mutex
+	calls map[string]int
+}
+
+func (c *methodCounter) PreCall(ctx context.Context, info *rpc.CallInfo) context.Context {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.calls[info.Method]++
+	return ctx
+}
+
+func (c *methodCounter) PostCall(context.Context, *rpc.CallInfo, time.Duration, rpc.Error) {}
+func (c *methodCounter) SubscriptionEvent(*rpc.SubscriptionInfo)                           {}
+
+func (c *methodCounter) count(method string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.calls[method]
+}
+
+// blockReceiptsAPI adds eth_getBlockReceipts to a mock node.
+type blockReceiptsAPI struct {
+	receipts map[common.Hash][]*types.Receipt
+}
+
+func (api *blockReceiptsAPI) GetBlockReceipts(hash common.Hash) []*types.Receipt {
+	return api.receipts[hash]
+}
+
+// newBatchNode creates a mock node with a few blocks of transfers, returning
+// the receipts by block hash.
+func newBatchNode(t *testing.T) (*mocknode.Node, *methodCounter, map[common.Hash][]*types.Receipt) {
+	t.Helper()
+
+	var (
+		node     = mocknode.New(nil)
+		counter  = &methodCounter{calls: make(map[string]int)}
+		signer   = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+		receipts = make(map[common.Hash][]*types.Receipt)
+		nonce    uint64
+	)
+	node.Server().Use(counter)
+	for i := 0; i < 3; i++ {
+		var blockReceipts []*types.Receipt
+		block := node.AddBlock(func(b *mocknode.BlockGen) {
+			for j := 0; j < 5; j++ {
+				tx, err := types.SignNewTx(testKey, signer, &types.DynamicFeeTx{
+					ChainID:   params.TestChainConfig.ChainID,
+					Nonce:     nonce,
+					GasTipCap: big.NewInt(params.GWei),
+					GasFeeCap: big.NewInt(10 * params.GWei),
+					Gas:       params.TxGas,
+					To:        &testTo,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				blockReceipts = append(blockReceipts, b.AddTx(tx))
+				nonce++
+			}
+		})
+		receipts[block.Hash()] = blockReceipts
+	}
+	return node, counter, receipts
+}
+
+func TestBlocksByNumberRange(t *testing.T) {
+	node, counter, _ := newBatchNode(t)
+	defer node.Close()
+	ec := node.Client()
+	defer ec.Close()
+
+	blocks, err := ec.BlocksByNumberRange(context.Background(), 0, 3, &ethclient.BatchOptions{ChunkSize: 3, Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, block := range blocks {
+		want := node.BlockByNumber(uint64(i))
+		if block.Hash() != want.Hash() {
+			t.Errorf("block %d: hash mismatch: have %x, want %x", i, block.Hash(), want.Hash())
+		}
+		if block.Transactions().Len() != want.Transactions().Len() {
+			t.Errorf("block %d: transaction count mismatch: have %d, want %d", i, block.Transactions().Len(), want.Transactions().Len())
+		}
+	}
+	// Blocks beyond the head are reported without failing the others
+	blocks, err = ec.BlocksByNumberRange(context.Background(), 2, 5, nil)
+	var batchErr *ethclient.BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected batch error, got %v", err)
+	}
+	if failed := batchErr.Failed(); len(failed) != 2 || failed[0] != 2 || failed[1] != 3 {
+		t.Fatalf("failed items mismatch: have %v, want [2 3]", failed)
+	}
+	if !errors.Is(batchErr.Errors[2], client.NotFound) {
+		t.Errorf("unexpected item error: %v", batchErr.Errors[2])
+	}
+	if blocks[0] == nil || blocks[1] == nil || blocks[2] != nil || blocks[3] != nil {
+		t.Errorf("unexpected partial result: %v", blocks)
+	}
+	// Four blocks in chunks of three, then four blocks in one chunk
+	if have := counter.count("eth_getBlockByNumber"); have != 8 {
+		t.Errorf("call count mismatch: have %d, want 8", have)
+	}
+}
+
+func TestBlocksByNumberRangeUncles(t *testing.T) {
+	node := mocknode.New(nil)
+	defer node.Close()
+	counter := &methodCounter{calls: make(map[string]int)}
+	node.Server().Use(counter)
+
+	for i := 1; i <= 4; i++ {
+		node.AddBlock(func(b *mocknode.BlockGen) {
+			for j := 0; j < i%3; j++ {
+				b.AddUncle(&types.Header{Number: big.NewInt(int64(i - 1)), Difficulty: common.Big1, Extra: []byte{byte(j)}})
+			}
+		})
+	}
+	ec := node.Client()
+	defer ec.Close()
+
+	blocks, err := ec.BlocksByNumberRange(context.Background(), 0, 4, &ethclient.BatchOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, block := range blocks {
+		want := node.BlockByNumber(uint64(i))
+		if block.Hash() != want.Hash() {
+			t.Errorf("block %d: hash mismatch: have %x, want %x", i, block.Hash(), want.Hash())
+		}
+		if len(block.Uncles()) != len(want.Uncles()) {
+			t.Fatalf("block %d: uncle count mismatch: have %d, want %d", i, len(block.Uncles()), len(want.Uncles()))
+		}
+		for j, uncle := range block.Uncles() {
+			if uncle.Hash() != want.Uncles()[j].Hash() {
+				t.Errorf("block %d: uncle %d mismatch: have %x, want %x", i, j, uncle.Hash(), want.Uncles()[j].Hash())
+			}
+		}
+	}
+	if have := counter.count("eth_getUncleByBlockHashAndIndex"); have != 4 {
+		t.Errorf("uncle call count mismatch: have %d, want 4", have)
+	}
+	// Ranges whose length overflows are rejected instead of returning nothing
+	if _, err := ec.BlocksByNumberRange(context.Background(), 0, math.MaxUint64, nil); err == nil {
+		t.Errorf("overflowing range accepted")
+	}
+}
+
+func TestReceiptsByBlock(t *testing.T) {
+	node, counter, receipts := newBatchNode(t)
+	defer node.Close()
+
+	checkReceipts := func(ec *ethclient.Client) {
+		t.Helper()
+		for hash, want := range receipts {
+			have, err := ec.ReceiptsByBlock(context.Background(), hash, &ethclient.BatchOptions{ChunkSize: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != len(want) {
+				t.Fatalf("receipt count mismatch: have %d, want %d", len(have), len(want))
+			}
+			for i := range have {
+				if have[i].TxHash != want[i].TxHash || have[i].BlockHash != hash {
+					t.Errorf("receipt %d mismatch: have tx %x in %x", i, have[i].TxHash, have[i].BlockHash)
+				}
+			}
+		}
+		if _, err := ec.ReceiptsByBlock(context.Background(), common.Hash{1}, nil); !errors.Is(err, client.NotFound) {
+			t.Errorf("unknown block: have %v, want %v", err, client.NotFound)
+		}
+	}
+	// Without eth_getBlockReceipts, receipts are retrieved one by one
+	ec := node.Client()
+	checkReceipts(ec)
+	ec.Close()
+
+	if have := counter.count("eth_getTransactionReceipt"); have != 15 {
+		t.Errorf("receipt call count mismatch: have %d, want 15", have)
+	}
+	if have := counter.count("eth_getBlockReceipts"); have != 1 {
+		t.Errorf("block receipts should only be attempted once, have %d", have)
+	}
+	// With eth_getBlockReceipts, each block takes a single call
+	node.Server().RegisterName("eth", &blockReceiptsAPI{receipts})
+	ec = node.Client()
+	defer ec.Close()
+	checkReceipts(ec)
+
+	if have := counter.count("eth_getBlockReceipts"); have != 1+4 {
+		t.Errorf("block receipts call count mismatch: have %d, want 5", have)
+	}
+	if have := counter.count("eth_getTransactionReceipt"); have != 15 {
+		t.Errorf("unexpected receipt calls: have %d, want 15", have)
+	}
+}
+
+func TestBalancesAt(t *testing.T) {
+	node := mocknode.New(nil)
+	defer node.Close()
+	ec := node.Client()
+	defer ec.Close()
+
+	accounts := make([]common.Address, 250)
+	for i := range accounts {
+		accounts[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+		node.SetBalance(accounts[i], big.NewInt(int64(i*1000)))
+	}
+	balances, err := ec.BalancesAt(context.Background(), accounts, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, balance := range balances {
+		if balance.Int64() != int64(i*1000) {
+			t.Errorf("account %d: balance mismatch: have %v, want %d", i, balance, i*1000)
+		}
+	}
+	// Unknown blocks fail every item
+	_, err = ec.BalancesAt(context.Background(), accounts[:3], big.NewInt(100), nil)
+	var batchErr *ethclient.BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 3 {
+		t.Fatalf("expected three failed items, got %v", err)
+	}
+}
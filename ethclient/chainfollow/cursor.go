@@ -0,0 +1,68 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package chainfollow
+
+import (
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/rlp"
+)
+
+// cursorPrefix + name -> RLP encoded cursor
+var cursorPrefix = []byte("chainfollow-cursor-")
+
+// cursor is the persisted position of a follower: the hashes of the most recently
+// emitted blocks, the last one being the block emitted last.
+type cursor struct {
+	First  uint64        // Number of the oldest retained block
+	Hashes []common.Hash // Hashes of the retained blocks, oldest first
+}
+
+func cursorKey(name string) []byte {
+	return append(append([]byte{}, cursorPrefix...), name...)
+}
+
+// readCursor loads the cursor stored under the given name, returning nil if
+// there is none.
+func readCursor(db ethdb.KeyValueReader, name string) (*cursor, error) {
+	blob, err := db.Get(cursorKey(name))
+	if err != nil || len(blob) == 0 {
+		return nil, nil
+	}
+	cur := new(cursor)
+	if err := rlp.DecodeBytes(blob, cur); err != nil {
+		return nil, err
+	}
+	if len(cur.Hashes) == 0 {
+		return nil, nil
+	}
+	return cur, nil
+}
+
+// writeCursor stores the cursor under the given name.
+func writeCursor(db ethdb.KeyValueWriter, name string, cur *cursor) error {
+	blob, err := rlp.EncodeToBytes(cur)
+	if err != nil {
+		return err
+	}
+	return db.Put(cursorKey(name), blob)
+}
+
+// deleteCursor removes the cursor stored under the given name.
+func deleteCursor(db ethdb.KeyValueWriter, name string) error {
+	return db.Delete(cursorKey(name))
+}
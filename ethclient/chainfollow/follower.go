@@ -0,0 +1,619 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package chainfollow implements a follower of the canonical chain of a remote
+// node, delivering its blocks and their matching logs in order, and reporting the
+// ones reverted by chain reorganisations.
+//
+// Unlike raw head and log subscriptions, the follower never skips blocks: gaps
+// left by dropped connections or restarts are backfilled from the position
+// persisted in a database. Delivery is at-least-once, blocks delivered right
+// before a crash may be delivered again after the restart.
+package chainfollow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/event"
+	"github.com/simplechain-org/client/log"
+	"github.com/simplechain-org/client/rpc"
+)
+
+const (
+	// maxBackfill is the number of blocks processed in one round when catching up.
+	maxBackfill = 256
+
+	// maxSyncAttempts is the number of times a sync round is retried if the
+	// remote chain changes while it is processed.
+	maxSyncAttempts = 3
+
+	// pollInterval is the delay between head polls if the source doesn't
+	// support subscriptions.
+	pollInterval = 3 * time.Second
+)
+
+var (
+	// ErrReorgTooDeep is returned if the remote chain forks off below the oldest
+	// delivered block the follower retains.
+	ErrReorgTooDeep = errors.New("reorg beyond retained history")
+
+	// ErrMismatchingHeader is returned if the source served a header other than
+	// the one requested.
+	ErrMismatchingHeader = errors.New("mismatching header")
+
+	// errChainChanged is returned if the remote chain changed while a sync round
+	// was in progress.
+	errChainChanged = errors.New("chain changed during sync")
+
+	// errSubscriptionClosed is returned if the head subscription ended without
+	// an error.
+	errSubscriptionClosed = errors.New("head subscription closed")
+)
+
+// Source is the subset of the ethclient.Client methods the follower needs to
+// retrieve blocks and logs from a remote node.
+type Source interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q client.FilterQuery) ([]types.Log, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (client.Subscription, error)
+}
+
+// Config contains the settings of a follower.
+type Config struct {
+	// Logs selects the logs delivered with each block by their addresses and
+	// topics; the block fields are ignored. Nil only follows the blocks.
+	Logs *client.FilterQuery
+
+	// Confirmations is the number of blocks required on top of a block before
+	// it is delivered. Reorgs shallower than this are never reported.
+	Confirmations uint64
+
+	// Start is the first block delivered if no position is stored yet. Zero
+	// starts at the current confirmed head.
+	Start uint64
+
+	// History is the number of delivered blocks retained to detect reorgs.
+	History int
+
+	// RetryDelay is the delay before reconnecting after the source failed.
+	RetryDelay time.Duration
+}
+
+// DefaultConfig contains the default settings of a follower.
+var DefaultConfig = Config{
+	Confirmations: 0,
+	History:       128,
+	RetryDelay:    5 * time.Second,
+}
+
+// BlockEvent announces a block added to, or removed from, the canonical chain.
+type BlockEvent struct {
+	Number  uint64
+	Hash    common.Hash
+	Header  *types.Header // Nil if a removed block could no longer be retrieved
+	Logs    []*types.Log  // Matching logs of the block
+	Removed bool          // Whether the block was reverted by a reorg, also set on its logs
+}
+
+// block is a delivered block retained for reorg detection.
+type block struct {
+	number uint64
+	hash   common.Hash
+	header *types.Header // Nil if restored from the stored cursor
+	logs   []*types.Log
+}
+
+// Follower tracks the canonical chain of a remote node, delivering each block,
+// and the matching logs in it, once it has enough confirmations. If a delivered
+// block is reverted by a reorg, it is announced again as removed, along with its
+// logs, before the blocks of the new chain are delivered.
+type Follower struct {
+	source Source
+	db     ethdb.KeyValueStore
+	name   string
+	config Config
+
+	history []*block   // Delivered blocks, oldest first, the last one being the cursor
+	lock    sync.Mutex // Serializes sync rounds
+
+	blockFeed event.Feed
+	logsFeed  event.Feed
+	scope     event.SubscriptionScope
+}
+
+// NewFollower creates a follower retrieving the chain from the source, and
+// persisting its position into the database under the given name. If a position
+// is already stored, following resumes from there.
+func NewFollower(source Source, db ethdb.KeyValueStore, name string, config Config) (*Follower, error) {
+	if config.History <= 0 {
+		config.History = DefaultConfig.History
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultConfig.RetryDelay
+	}
+	f := &Follower{
+		source: source,
+		db:     db,
+		name:   name,
+		config: config,
+	}
+	cur, err := readCursor(db, name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored cursor: %w", err)
+	}
+	if cur != nil {
+		for i, hash := range cur.Hashes {
+			f.history = append(f.history, &block{number: cur.First + uint64(i), hash: hash})
+		}
+		log.Debug("Resuming chain follower", "name", name, "number", f.history[len(f.history)-1].number, "hash", f.history[len(f.history)-1].hash)
+	}
+	return f, nil
+}
+
+// Cursor returns the number and hash of the last delivered block, or false if
+// nothing was delivered yet.
+func (f *Follower) Cursor() (uint64, common.Hash, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.history) == 0 {
+		return 0, common.Hash{}, false
+	}
+	last := f.history[len(f.history)-1]
+	return last.number, last.hash, true
+}
+
+// Reset discards the stored position, following restarts from the configured
+// start block.
+func (f *Follower) Reset() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.history = nil
+	return deleteCursor(f.db, f.name)
+}
+
+// SubscribeBlocks subscribes to notifications about added and removed blocks.
+func (f *Follower) SubscribeBlocks(ch chan<- *BlockEvent) event.Subscription {
+	return f.scope.Track(f.blockFeed.Subscribe(ch))
+}
+
+// SubscribeLogs subscribes to notifications about the matching logs of added and
+// removed blocks. Logs of removed blocks have their Removed field set.
+func (f *Follower) SubscribeLogs(ch chan<- []*types.Log) event.Subscription {
+	return f.scope.Track(f.logsFeed.Subscribe(ch))
+}
+
+// Run follows the chain of the source until the context is cancelled or a reorg
+// beyond the retained history occurs. Failures of the source, such as dropped
+// connections, are retried after the configured delay, catching up on the blocks
+// missed meanwhile.
+func (f *Follower) Run(ctx context.Context) error {
+	defer f.scope.Close()
+
+	for {
+		err := f.follow(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, ErrReorgTooDeep) {
+			return err
+		}
+		log.Warn("Chain follower interrupted", "name", f.name, "err", err, "retry", f.config.RetryDelay)
+
+		select {
+		case <-time.After(f.config.RetryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// follow subscribes to the new heads of the source, syncing on each of them until
+// the subscription fails. If the source doesn't support subscriptions, its head
+// is polled instead.
+func (f *Follower) follow(ctx context.Context) error {
+	heads := make(chan *types.Header, 16)
+	sub, err := f.source.SubscribeNewHead(ctx, heads)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		log.Debug("Chain source doesn't support subscriptions, polling")
+		return f.poll(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	// Catch up after subscribing, so no head is missed in between
+	if err := f.Sync(ctx); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-heads:
+			if err := f.Sync(ctx); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			if err == nil {
+				err = errSubscriptionClosed
+			}
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// poll syncs with the source periodically.
+func (f *Follower) poll(ctx context.Context) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.Sync(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Sync delivers all blocks up to the confirmed head of the source, announcing the
+// delivered blocks no longer canonical as removed first.
+func (f *Follower) Sync(ctx context.Context) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for attempt := 0; ; {
+		done, err := f.step(ctx)
+		switch {
+		case errors.Is(err, errChainChanged) && attempt < maxSyncAttempts:
+			log.Debug("Remote chain changed during sync, retrying", "name", f.name, "err", err)
+			attempt++
+		case err != nil:
+			return err
+		case done:
+			return nil
+		}
+	}
+}
+
+// step processes the blocks up to the confirmed head of the source, at most
+// maxBackfill of them, and reports whether the head was reached.
+func (f *Follower) step(ctx context.Context) (bool, error) {
+	head, err := f.source.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	if head.Number.Uint64() < f.config.Confirmations {
+		return true, nil
+	}
+	target := head.Number.Uint64() - f.config.Confirmations
+
+	var next uint64
+	if len(f.history) == 0 {
+		if next = f.config.Start; next == 0 {
+			next = target
+		}
+		if next > target {
+			return true, nil
+		}
+	} else {
+		next = f.history[len(f.history)-1].number + 1
+	}
+	number, done := target, true
+	if target >= next && target-next >= maxBackfill {
+		number, done = next+maxBackfill-1, false
+	}
+	tip, err := f.fetchByNumber(ctx, number)
+	if err != nil {
+		return false, err
+	}
+	added, reverted, err := f.reconcile(ctx, tip, next)
+	if err != nil {
+		return false, err
+	}
+	if len(added) == 0 && reverted == 0 {
+		return done, nil
+	}
+	logs, err := f.fetchLogs(ctx, added)
+	if err != nil {
+		return false, err
+	}
+	// Everything retrieved, announce the reverted blocks newest first, then the
+	// added ones oldest first
+	removed := f.history[len(f.history)-reverted:]
+	for _, b := range removed {
+		f.restore(ctx, b)
+	}
+	for i := len(removed) - 1; i >= 0; i-- {
+		b := removed[i]
+		log.Debug("Chain follower reverted block", "name", f.name, "number", b.number, "hash", b.hash)
+		f.announce(&BlockEvent{Number: b.number, Hash: b.hash, Header: b.header, Logs: removedLogs(b.logs), Removed: true})
+	}
+	f.history = f.history[:len(f.history)-reverted]
+
+	for i, header := range added {
+		b := &block{number: header.Number.Uint64(), hash: header.Hash(), header: header, logs: logs[i]}
+		f.history = append(f.history, b)
+		f.announce(&BlockEvent{Number: b.number, Hash: b.hash, Header: header, Logs: b.logs})
+	}
+	if len(f.history) > f.config.History {
+		f.history = f.history[len(f.history)-f.config.History:]
+	}
+	if len(added) > 0 {
+		last := added[len(added)-1]
+		log.Debug("Chain follower delivered blocks", "name", f.name, "count", len(added), "reverted", reverted, "number", last.Number, "hash", last.Hash())
+	}
+	return done, f.persist()
+}
+
+// reconcile walks back from the tip to the delivered chain, returning the blocks
+// to deliver, oldest first, and the number of delivered blocks reverted. Without
+// a delivered chain, it walks back to the start block.
+func (f *Follower) reconcile(ctx context.Context, tip *types.Header, start uint64) ([]*types.Header, int, error) {
+	var (
+		headers []*types.Header
+		last    uint64
+	)
+	if len(f.history) > 0 {
+		last = f.history[len(f.history)-1].number
+
+		// A source lagging behind the delivered chain isn't a reorg
+		if b := f.delivered(tip.Number.Uint64()); b != nil && b.hash == tip.Hash() {
+			return nil, 0, nil
+		}
+	}
+	for header := tip; ; {
+		number := header.Number.Uint64()
+		if len(f.history) > 0 && number <= last {
+			b := f.delivered(number)
+			if b == nil {
+				return nil, 0, fmt.Errorf("%w: #%d [%x] differs, oldest retained #%d", ErrReorgTooDeep, number, header.Hash().Bytes()[:4], f.history[0].number)
+			}
+			if b.hash == header.Hash() {
+				break
+			}
+		}
+		headers = append(headers, header)
+		if number == 0 || (len(f.history) == 0 && number <= start) {
+			break
+		}
+		parent, err := f.fetchByHash(ctx, header.ParentHash, number-1)
+		if err != nil {
+			return nil, 0, err
+		}
+		header = parent
+	}
+	// Reverse the headers into chain order
+	for i, j := 0, len(headers)-1; i < j; i, j = i+1, j-1 {
+		headers[i], headers[j] = headers[j], headers[i]
+	}
+	reverted := 0
+	if len(headers) > 0 && len(f.history) > 0 && headers[0].Number.Uint64() <= last {
+		reverted = int(last - headers[0].Number.Uint64() + 1)
+	}
+	return headers, reverted, nil
+}
+
+// delivered returns the retained delivered block with the given number.
+func (f *Follower) delivered(number uint64) *block {
+	if len(f.history) == 0 || number < f.history[0].number {
+		return nil
+	}
+	if index := number - f.history[0].number; index < uint64(len(f.history)) {
+		return f.history[index]
+	}
+	return nil
+}
+
+// fetchByNumber retrieves a canonical header from the source, ensuring it is the
+// one requested.
+func (f *Follower) fetchByNumber(ctx context.Context, number uint64) (*types.Header, error) {
+	header, err := f.source.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, err
+	}
+	if header.Number == nil || header.Number.Uint64() != number {
+		return nil, fmt.Errorf("%w: requested #%d, got #%v", ErrMismatchingHeader, number, header.Number)
+	}
+	return header, nil
+}
+
+// fetchByHash retrieves a header from the source, ensuring it is the one requested.
+func (f *Follower) fetchByHash(ctx context.Context, hash common.Hash, number uint64) (*types.Header, error) {
+	header, err := f.source.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if header.Hash() != hash || header.Number == nil || header.Number.Uint64() != number {
+		return nil, fmt.Errorf("%w: requested #%d [%x], got #%v [%x]", ErrMismatchingHeader, number, hash.Bytes()[:4], header.Number, header.Hash().Bytes()[:4])
+	}
+	return header, nil
+}
+
+// fetchLogs retrieves the matching logs of a contiguous range of blocks. Blocks
+// whose bloom filter indicates matches missed by the range query, which happens
+// if the chain changed meanwhile, are queried again by hash.
+func (f *Follower) fetchLogs(ctx context.Context, headers []*types.Header) ([][]*types.Log, error) {
+	logs := make([][]*types.Log, len(headers))
+	if f.config.Logs == nil || len(headers) == 0 {
+		return logs, nil
+	}
+	if len(headers) == 1 {
+		blockLogs, err := f.fetchBlockLogs(ctx, headers[0])
+		if err != nil {
+			return nil, err
+		}
+		logs[0] = blockLogs
+		return logs, nil
+	}
+	query := f.query()
+	query.FromBlock, query.ToBlock = headers[0].Number, headers[len(headers)-1].Number
+
+	results, err := f.source.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[common.Hash]int, len(headers))
+	for i, header := range headers {
+		index[header.Hash()] = i
+	}
+	for i := range results {
+		log := &results[i]
+		pos, ok := index[log.BlockHash]
+		if !ok {
+			return nil, fmt.Errorf("%w: log in unknown block #%d [%x]", errChainChanged, log.BlockNumber, log.BlockHash.Bytes()[:4])
+		}
+		logs[pos] = append(logs[pos], log)
+	}
+	for i, header := range headers {
+		if len(logs[i]) == 0 && bloomMatches(header.Bloom, query) {
+			if logs[i], err = f.fetchBlockLogs(ctx, header); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return logs, nil
+}
+
+// fetchBlockLogs retrieves the matching logs of a single block.
+func (f *Follower) fetchBlockLogs(ctx context.Context, header *types.Header) ([]*types.Log, error) {
+	var (
+		query = f.query()
+		hash  = header.Hash()
+	)
+	query.BlockHash = &hash
+
+	results, err := f.source.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var logs []*types.Log
+	for i := range results {
+		if results[i].BlockHash != hash {
+			return nil, fmt.Errorf("%w: requested logs of [%x], got [%x]", ErrMismatchingHeader, hash.Bytes()[:4], results[i].BlockHash.Bytes()[:4])
+		}
+		logs = append(logs, &results[i])
+	}
+	return logs, nil
+}
+
+// query returns the configured log filter, without block restrictions.
+func (f *Follower) query() client.FilterQuery {
+	return client.FilterQuery{
+		Addresses: f.config.Logs.Addresses,
+		Topics:    f.config.Logs.Topics,
+	}
+}
+
+// restore retrieves the header and logs of a delivered block restored from the
+// stored cursor, so they can be announced on its removal. Failures only lose
+// details of the removal, so they are logged rather than returned.
+func (f *Follower) restore(ctx context.Context, b *block) {
+	if b.header != nil {
+		return
+	}
+	header, err := f.fetchByHash(ctx, b.hash, b.number)
+	if err != nil {
+		log.Warn("Failed to retrieve reverted block", "name", f.name, "number", b.number, "hash", b.hash, "err", err)
+		return
+	}
+	b.header = header
+	if f.config.Logs != nil {
+		if b.logs, err = f.fetchBlockLogs(ctx, header); err != nil {
+			log.Warn("Failed to retrieve logs of reverted block", "name", f.name, "number", b.number, "hash", b.hash, "err", err)
+		}
+	}
+}
+
+// announce delivers a block event and its logs to the subscribers.
+func (f *Follower) announce(ev *BlockEvent) {
+	f.blockFeed.Send(ev)
+	if len(ev.Logs) > 0 {
+		f.logsFeed.Send(ev.Logs)
+	}
+}
+
+// persist stores the hashes of the retained delivered blocks.
+func (f *Follower) persist() error {
+	if len(f.history) == 0 {
+		return deleteCursor(f.db, f.name)
+	}
+	cur := &cursor{First: f.history[0].number, Hashes: make([]common.Hash, len(f.history))}
+	for i, b := range f.history {
+		cur.Hashes[i] = b.hash
+	}
+	return writeCursor(f.db, f.name, cur)
+}
+
+// removedLogs returns copies of the logs, flagged as removed.
+func removedLogs(logs []*types.Log) []*types.Log {
+	if len(logs) == 0 {
+		return nil
+	}
+	removed := make([]*types.Log, len(logs))
+	for i, log := range logs {
+		cpy := *log
+		cpy.Removed = true
+		removed[i] = &cpy
+	}
+	return removed
+}
+
+// bloomMatches reports whether the bloom filter may contain logs matching the
+// addresses and topics of the query.
+func bloomMatches(bloom types.Bloom, query client.FilterQuery) bool {
+	if len(query.Addresses) > 0 {
+		var included bool
+		for _, addr := range query.Addresses {
+			if bloom.Test(addr.Bytes()) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	for _, sub := range query.Topics {
+		included := len(sub) == 0 // empty rule set == wildcard
+		for _, topic := range sub {
+			if bloom.Test(topic.Bytes()) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,390 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package chainfollow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethclient"
+	"github.com/simplechain-org/client/ethclient/mocknode"
+	"github.com/simplechain-org/client/params"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testToken  = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testOther  = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	testTopic  = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	testQuery  = &client.FilterQuery{Addresses: []common.Address{testToken}}
+)
+
+// testChain drives a mock node, adding blocks with a matching and a non-matching
+// log each.
+type testChain struct {
+	t     *testing.T
+	node  *mocknode.Node
+	nonce uint64
+}
+
+func newTestChain(t *testing.T) *testChain {
+	return &testChain{t: t, node: mocknode.New(nil)}
+}
+
+func (c *testChain) addBlocks(n int) {
+	c.t.Helper()
+
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	for i := 0; i < n; i++ {
+		tx, err := types.SignNewTx(testKey, signer, &types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     c.nonce,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(10 * params.GWei),
+			Gas:       params.TxGas,
+			To:        &testToken,
+		})
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		c.nonce++
+		c.node.AddBlock(func(b *mocknode.BlockGen) {
+			b.AddTx(tx,
+				&types.Log{Address: testToken, Topics: []common.Hash{testTopic}, Data: b.Number().Bytes()},
+				&types.Log{Address: testOther, Topics: []common.Hash{testTopic}},
+			)
+		})
+	}
+}
+
+// collect subscribes to the block events of a follower.
+func collect(f *Follower) (chan *BlockEvent, func()) {
+	events := make(chan *BlockEvent, 1024)
+	sub := f.SubscribeBlocks(events)
+	return events, sub.Unsubscribe
+}
+
+// expect checks the next block events against the expected numbers, negative ones
+// denoting removals, and returns them.
+func expect(t *testing.T, events chan *BlockEvent, numbers ...int) []*BlockEvent {
+	t.Helper()
+
+	var result []*BlockEvent
+	for _, number := range numbers {
+		select {
+		case ev := <-events:
+			want := fmt.Sprintf("added #%d", number)
+			if number < 0 {
+				want = fmt.Sprintf("removed #%d", -number)
+			}
+			have := fmt.Sprintf("added #%d", ev.Number)
+			if ev.Removed {
+				have = fmt.Sprintf("removed #%d", ev.Number)
+			}
+			if have != want {
+				t.Fatalf("event mismatch: have %s, want %s", have, want)
+			}
+			result = append(result, ev)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for block %d", number)
+		}
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected event for block #%d (removed %v)", ev.Number, ev.Removed)
+	default:
+	}
+	return result
+}
+
+// checkLogs ensures the events carry the matching log of their block.
+func checkLogs(t *testing.T, events []*BlockEvent) {
+	t.Helper()
+
+	for _, ev := range events {
+		if ev.Header == nil || ev.Header.Hash() != ev.Hash {
+			t.Fatalf("block #%d: header missing or mismatching", ev.Number)
+		}
+		if len(ev.Logs) != 1 {
+			t.Fatalf("block #%d: log count mismatch: have %d, want 1", ev.Number, len(ev.Logs))
+		}
+		log := ev.Logs[0]
+		if log.BlockHash != ev.Hash || log.Address != testToken || log.Removed != ev.Removed {
+			t.Errorf("block #%d: log mismatch: block %x, address %x, removed %v", ev.Number, log.BlockHash, log.Address, log.Removed)
+		}
+	}
+}
+
+func TestFollowerReorg(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.node.Close()
+	chain.addBlocks(3)
+
+	ec := chain.node.Client()
+	defer ec.Close()
+	f, err := NewFollower(ec, rawdb.NewMemoryDatabase(), "test", Config{Logs: testQuery, Start: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, unsub := collect(f)
+	defer unsub()
+
+	if err := f.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkLogs(t, expect(t, events, 1, 2, 3))
+
+	// Rewind and build a longer side chain, the reverted blocks are announced
+	// newest first, along with their logs
+	chain.node.SetHead(1)
+	chain.addBlocks(3)
+	if err := f.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkLogs(t, expect(t, events, -3, -2, 2, 3, 4))
+
+	number, hash, ok := f.Cursor()
+	if !ok || number != 4 || hash != chain.node.Head().Hash() {
+		t.Fatalf("cursor mismatch: have #%d [%x], want #4 [%x]", number, hash, chain.node.Head().Hash())
+	}
+	// Shorter canonical chains are reorgs too
+	chain.node.SetHead(2)
+	chain.addBlocks(1)
+	if err := f.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkLogs(t, expect(t, events, -4, -3, 3))
+}
+
+func TestFollowerLogFeed(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.node.Close()
+	chain.addBlocks(2)
+
+	ec := chain.node.Client()
+	defer ec.Close()
+	f, _ := NewFollower(ec, rawdb.NewMemoryDatabase(), "test", Config{Logs: testQuery, Start: 1})
+	logs := make(chan []*types.Log, 16)
+	sub := f.SubscribeLogs(logs)
+	defer sub.Unsubscribe()
+
+	f.Sync(context.Background())
+	chain.node.SetHead(1)
+	chain.addBlocks(2)
+	f.Sync(context.Background())
+
+	want := []struct {
+		number  uint64
+		removed bool
+	}{{1, false}, {2, false}, {2, true}, {2, false}, {3, false}}
+	for i, w := range want {
+		select {
+		case have := <-logs:
+			if len(have) != 1 || have[0].BlockNumber != w.number || have[0].Removed != w.removed {
+				t.Fatalf("logs %d mismatch: have #%d removed %v, want #%d removed %v", i, have[0].BlockNumber, have[0].Removed, w.number, w.removed)
+			}
+		default:
+			t.Fatalf("logs %d missing", i)
+		}
+	}
+}
+
+func TestFollowerConfirmations(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.node.Close()
+	chain.addBlocks(3)
+
+	ec := chain.node.Client()
+	defer ec.Close()
+	f, _ := NewFollower(ec, rawdb.NewMemoryDatabase(), "test", Config{Confirmations: 2, Start: 1})
+	events, unsub := collect(f)
+	defer unsub()
+
+	f.Sync(context.Background())
+	expect(t, events, 1)
+
+	// Reorgs of unconfirmed blocks are never seen
+	chain.node.SetHead(2)
+	chain.addBlocks(2)
+	f.Sync(context.Background())
+	ev := expect(t, events, 2)
+	if ev[0].Hash != chain.node.BlockByNumber(2).Hash() || ev[0].Logs != nil {
+		t.Fatalf("confirmed block mismatch")
+	}
+	// Deeper ones are reported
+	chain.node.SetHead(1)
+	chain.addBlocks(4)
+	f.Sync(context.Background())
+	expect(t, events, -2, 2, 3)
+}
+
+func TestFollowerResume(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.node.Close()
+	chain.addBlocks(3)
+
+	ec := chain.node.Client()
+	defer ec.Close()
+	db := rawdb.NewMemoryDatabase()
+	f, _ := NewFollower(ec, db, "test", Config{Logs: testQuery, Start: 1})
+	f.Sync(context.Background())
+
+	// Reorg and extend the chain while no follower is running, the reverted blocks
+	// are retrieved for their removal
+	chain.node.SetHead(2)
+	chain.addBlocks(300)
+
+	f, err := NewFollower(ec, db, "test", Config{Logs: testQuery, Start: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number, _, ok := f.Cursor(); !ok || number != 3 {
+		t.Fatalf("restored cursor mismatch: have #%d", number)
+	}
+	events, unsub := collect(f)
+	defer unsub()
+	if err := f.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	numbers := []int{-3}
+	for i := 3; i <= 302; i++ {
+		numbers = append(numbers, i)
+	}
+	checkLogs(t, expect(t, events, numbers...))
+
+	// Followers with other names are independent
+	other, _ := NewFollower(ec, db, "other", Config{})
+	if _, _, ok := other.Cursor(); ok {
+		t.Fatal("cursor shared between followers")
+	}
+	if err := f.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ = NewFollower(ec, db, "test", Config{}); func() bool { _, _, ok := f.Cursor(); return ok }() {
+		t.Fatal("cursor not reset")
+	}
+}
+
+func TestFollowerReorgTooDeep(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.node.Close()
+	chain.addBlocks(5)
+
+	ec := chain.node.Client()
+	defer ec.Close()
+	f, _ := NewFollower(ec, rawdb.NewMemoryDatabase(), "test", Config{Start: 1, History: 2})
+	f.Sync(context.Background())
+
+	chain.node.SetHead(2)
+	chain.addBlocks(4)
+	if err := f.Sync(context.Background()); !errors.Is(err, ErrReorgTooDeep) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrReorgTooDeep)
+	}
+}
+
+// dropSource is a chain source whose head subscriptions can be failed on demand,
+// emulating lost connections.
+type dropSource struct {
+	*ethclient.Client
+
+	lock   sync.Mutex
+	subs   []*dropSub
+	closed chan struct{}
+}
+
+type dropSub struct {
+	client.Subscription
+	source *dropSource
+	err    chan error
+}
+
+func (s *dropSub) Err() <-chan error {
+	return s.err
+}
+
+func (s *dropSub) Unsubscribe() {
+	s.Subscription.Unsubscribe()
+	s.source.closed <- struct{}{}
+}
+
+func (s *dropSource) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (client.Subscription, error) {
+	sub, err := s.Client.SubscribeNewHead(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	wrapped := &dropSub{Subscription: sub, source: s, err: make(chan error, 1)}
+	s.subs = append(s.subs, wrapped)
+	return wrapped, nil
+}
+
+func (s *dropSource) drop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.subs[len(s.subs)-1].err <- errors.New("connection lost")
+}
+
+func TestFollowerRun(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.node.Close()
+	chain.addBlocks(1)
+
+	ec := chain.node.Client()
+	defer ec.Close()
+	source := &dropSource{Client: ec, closed: make(chan struct{}, 1)}
+	f, _ := NewFollower(source, rawdb.NewMemoryDatabase(), "test", Config{Logs: testQuery, Start: 1, RetryDelay: 50 * time.Millisecond})
+	events, unsub := collect(f)
+	defer unsub()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- f.Run(ctx) }()
+
+	expect(t, events, 1)
+	chain.addBlocks(1)
+	expect(t, events, 2)
+
+	// Blocks mined while disconnected are backfilled after reconnecting
+	source.drop()
+	<-source.closed
+	chain.addBlocks(2)
+	checkLogs(t, expect(t, events, 3, 4))
+
+	source.lock.Lock()
+	if len(source.subs) != 2 {
+		t.Errorf("subscription count mismatch: have %d, want 2", len(source.subs))
+	}
+	source.lock.Unlock()
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("run error mismatch: have %v", err)
+	}
+}
@@ -34,6 +34,8 @@ import (
 // Client defines typed wrappers for the Ethereum RPC API.
 type Client struct {
 	c *rpc.Client
+
+	noBlockReceipts int32 // Set when the server lacks eth_getBlockReceipts (atomic)
 }
 
 // Dial connects a client to the given URL.
@@ -51,7 +53,7 @@ func DialContext(ctx context.Context, rawurl string) (*Client, error) {
 
 // NewClient creates a client that uses the given RPC client.
 func NewClient(c *rpc.Client) *Client {
-	return &Client{c}
+	return &Client{c: c}
 }
 
 func (ec *Client) Close() {
@@ -105,56 +107,96 @@ func (ec *Client) getBlock(ctx context.Context, method string, args ...interface
 	err := ec.c.CallContext(ctx, &raw, method, args...)
 	if err != nil {
 		return nil, err
-	} else if len(raw) == 0 {
-		return nil, client.NotFound
+	}
+	return ec.decodeBlock(ctx, raw)
+}
+
+// decodeBlock assembles a block from its RPC representation, fetching the
+// uncles separately if it has any.
+func (ec *Client) decodeBlock(ctx context.Context, raw json.RawMessage) (*types.Block, error) {
+	head, body, err := parseBlock(raw)
+	if err != nil {
+		return nil, err
+	}
+	// Load uncles because they are not included in the block response.
+	var uncles []*types.Header
+	if len(body.UncleHashes) > 0 {
+		uncles = make([]*types.Header, len(body.UncleHashes))
+		reqs := uncleRequests(body.Hash, uncles)
+		if err := ec.c.BatchCallContext(ctx, reqs); err != nil {
+			return nil, err
+		}
+		if err := checkUncles(body.Hash, reqs, uncles); err != nil {
+			return nil, err
+		}
+	}
+	return body.block(head, uncles), nil
+}
+
+// parseBlock decodes the header and body of a block's RPC representation.
+func parseBlock(raw json.RawMessage) (*types.Header, *rpcBlock, error) {
+	if len(raw) == 0 {
+		return nil, nil, client.NotFound
 	}
 	// Decode header and transactions.
 	var head *types.Header
 	var body rpcBlock
 	if err := json.Unmarshal(raw, &head); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if head == nil {
+		return nil, nil, client.NotFound
 	}
 	if err := json.Unmarshal(raw, &body); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// Quick-verify transaction and uncle lists. This mostly helps with debugging the server.
 	if head.UncleHash == types.EmptyUncleHash && len(body.UncleHashes) > 0 {
-		return nil, fmt.Errorf("server returned non-empty uncle list but block header indicates no uncles")
+		return nil, nil, fmt.Errorf("server returned non-empty uncle list but block header indicates no uncles")
 	}
 	if head.UncleHash != types.EmptyUncleHash && len(body.UncleHashes) == 0 {
-		return nil, fmt.Errorf("server returned empty uncle list but block header indicates uncles")
+		return nil, nil, fmt.Errorf("server returned empty uncle list but block header indicates uncles")
 	}
 	if head.TxHash == types.EmptyRootHash && len(body.Transactions) > 0 {
-		return nil, fmt.Errorf("server returned non-empty transaction list but block header indicates no transactions")
+		return nil, nil, fmt.Errorf("server returned non-empty transaction list but block header indicates no transactions")
 	}
 	if head.TxHash != types.EmptyRootHash && len(body.Transactions) == 0 {
-		return nil, fmt.Errorf("server returned empty transaction list but block header indicates transactions")
+		return nil, nil, fmt.Errorf("server returned empty transaction list but block header indicates transactions")
 	}
-	// Load uncles because they are not included in the block response.
-	var uncles []*types.Header
-	if len(body.UncleHashes) > 0 {
-		uncles = make([]*types.Header, len(body.UncleHashes))
-		reqs := make([]rpc.BatchElem, len(body.UncleHashes))
-		for i := range reqs {
-			reqs[i] = rpc.BatchElem{
-				Method: "eth_getUncleByBlockHashAndIndex",
-				Args:   []interface{}{body.Hash, hexutil.EncodeUint64(uint64(i))},
-				Result: &uncles[i],
-			}
+	return head, &body, nil
+}
+
+// uncleRequests creates the calls retrieving the uncles of a block into the
+// given slice.
+func uncleRequests(hash common.Hash, uncles []*types.Header) []rpc.BatchElem {
+	reqs := make([]rpc.BatchElem, len(uncles))
+	for i := range reqs {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getUncleByBlockHashAndIndex",
+			Args:   []interface{}{hash, hexutil.EncodeUint64(uint64(i))},
+			Result: &uncles[i],
 		}
-		if err := ec.c.BatchCallContext(ctx, reqs); err != nil {
-			return nil, err
+	}
+	return reqs
+}
+
+// checkUncles returns the first failure of the calls retrieving the uncles of
+// a block.
+func checkUncles(hash common.Hash, reqs []rpc.BatchElem, uncles []*types.Header) error {
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			return reqs[i].Error
 		}
-		for i := range reqs {
-			if reqs[i].Error != nil {
-				return nil, reqs[i].Error
-			}
-			if uncles[i] == nil {
-				return nil, fmt.Errorf("got null header for uncle %d of block %x", i, body.Hash[:])
-			}
+		if uncles[i] == nil {
+			return fmt.Errorf("got null header for uncle %d of block %x", i, hash[:])
 		}
 	}
-	// Fill the sender cache of transactions in the block.
+	return nil
+}
+
+// block assembles the block of a decoded body, filling the sender cache of its
+// transactions.
+func (body *rpcBlock) block(head *types.Header, uncles []*types.Header) *types.Block {
 	txs := make([]*types.Transaction, len(body.Transactions))
 	for i, tx := range body.Transactions {
 		if tx.From != nil {
@@ -162,7 +204,7 @@ func (ec *Client) getBlock(ctx context.Context, method string, args ...interface
 		}
 		txs[i] = tx.tx
 	}
-	return types.NewBlockWithHeader(head).WithBody(txs, uncles), nil
+	return types.NewBlockWithHeader(head).WithBody(txs, uncles)
 }
 
 // HeaderByHash returns the block header with the given hash.
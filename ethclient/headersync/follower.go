@@ -0,0 +1,274 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package headersync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/consensus"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/event"
+	"github.com/simplechain-org/client/log"
+	"github.com/simplechain-org/client/rpc"
+)
+
+const (
+	// maxHeaderFetch is the number of headers fetched and verified in one batch.
+	maxHeaderFetch = 192
+
+	// pollInterval is the delay between head polls if the endpoint doesn't
+	// support subscriptions.
+	pollInterval = 3 * time.Second
+)
+
+var (
+	// ErrInvalidHeader is returned if the endpoint served a header that failed
+	// consensus verification.
+	ErrInvalidHeader = errors.New("invalid header")
+
+	// ErrReorgTooDeep is returned if the endpoint's chain forks off before the
+	// anchor of the local store.
+	ErrReorgTooDeep = errors.New("reorg beyond anchor")
+
+	// ErrMismatchingHeader is returned if the endpoint served a header other than
+	// the one requested.
+	ErrMismatchingHeader = errors.New("mismatching header")
+)
+
+// HeaderSource is the subset of the ethclient.Client methods the follower needs
+// to retrieve headers from a remote node.
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (client.Subscription, error)
+}
+
+// Follower tracks the header chain of an untrusted remote node, verifying every
+// header against a consensus engine before inserting it into the local store.
+// Forged or otherwise invalid headers are rejected, so the store only ever holds
+// chains the consensus rules allow.
+type Follower struct {
+	source HeaderSource
+	engine consensus.Engine
+	store  *Store
+
+	headFeed event.Feed
+	scope    event.SubscriptionScope
+}
+
+// NewFollower creates a follower fetching headers from the source, verifying them
+// with the engine and inserting them into the store. The engine must be set up
+// for the chain configured in the store.
+func NewFollower(source HeaderSource, engine consensus.Engine, store *Store) *Follower {
+	return &Follower{
+		source: source,
+		engine: engine,
+		store:  store,
+	}
+}
+
+// Store returns the local header store, which also serves as the chain reader
+// of the verified headers.
+func (f *Follower) Store() *Store {
+	return f.store
+}
+
+// SubscribeNewHead subscribes to notifications about the verified head of the
+// local canonical chain.
+func (f *Follower) SubscribeNewHead(ch chan<- *types.Header) event.Subscription {
+	return f.scope.Track(f.headFeed.Subscribe(ch))
+}
+
+// Run syncs the store with the source and keeps following its new heads until the
+// context is cancelled or an error occurs. If the source doesn't support
+// subscriptions, its head is polled instead.
+func (f *Follower) Run(ctx context.Context) error {
+	defer f.scope.Close()
+
+	if err := f.Sync(ctx); err != nil {
+		return err
+	}
+	heads := make(chan *types.Header, 16)
+	sub, err := f.source.SubscribeNewHead(ctx, heads)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		log.Debug("Header source doesn't support subscriptions, polling")
+		return f.poll(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case head := <-heads:
+			if err := f.follow(ctx, head); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// poll syncs the store with the source periodically.
+func (f *Follower) poll(ctx context.Context) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.Sync(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// follow processes a head announced by the source. Heads extending the local
+// chain are verified right away, any other one triggers a full sync.
+func (f *Follower) follow(ctx context.Context, head *types.Header) error {
+	if head == nil || head.Number == nil {
+		return f.Sync(ctx)
+	}
+	current := f.store.CurrentHeader()
+	if head.ParentHash != current.Hash() || head.Number.Uint64() != current.Number.Uint64()+1 {
+		return f.Sync(ctx)
+	}
+	return f.insert([]*types.Header{head})
+}
+
+// Sync brings the store up to the current head of the source, fetching and
+// verifying any missing headers, and switching over to the source's chain if it
+// is heavier than the local one.
+func (f *Follower) Sync(ctx context.Context) error {
+	head, err := f.source.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	ancestor, err := f.findAncestor(ctx, head)
+	if err != nil {
+		return err
+	}
+	for from := ancestor + 1; from <= head.Number.Uint64(); from += maxHeaderFetch {
+		to := from + maxHeaderFetch - 1
+		if to > head.Number.Uint64() {
+			to = head.Number.Uint64()
+		}
+		headers := make([]*types.Header, 0, to-from+1)
+		for number := from; number <= to; number++ {
+			header, err := f.fetch(ctx, number)
+			if err != nil {
+				return err
+			}
+			headers = append(headers, header)
+		}
+		if err := f.insert(headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findAncestor returns the number of the highest header the source's chain up to
+// the given head shares with the local store.
+func (f *Follower) findAncestor(ctx context.Context, head *types.Header) (uint64, error) {
+	var (
+		anchor = f.store.Anchor().Number.Uint64()
+		number = f.store.CurrentHeader().Number.Uint64()
+	)
+	if head.Number.Uint64() < anchor {
+		return 0, fmt.Errorf("%w: remote head #%d below anchor #%d", ErrReorgTooDeep, head.Number, anchor)
+	}
+	if head.Number.Uint64() < number {
+		number = head.Number.Uint64()
+	}
+	for ; ; number-- {
+		remote := head
+		if number != head.Number.Uint64() {
+			var err error
+			if remote, err = f.fetch(ctx, number); err != nil {
+				return 0, err
+			}
+		}
+		if local := f.store.GetHeaderByNumber(number); local != nil && local.Hash() == remote.Hash() {
+			return number, nil
+		}
+		if f.store.GetHeader(remote.Hash(), number) != nil {
+			return number, nil // Known side chain, resume from there
+		}
+		if number == anchor {
+			return 0, fmt.Errorf("%w: #%d", ErrReorgTooDeep, anchor)
+		}
+	}
+}
+
+// fetch retrieves a header by number from the source, ensuring it is the one
+// requested.
+func (f *Follower) fetch(ctx context.Context, number uint64) (*types.Header, error) {
+	header, err := f.source.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, err
+	}
+	if header.Number == nil || header.Number.Uint64() != number {
+		return nil, fmt.Errorf("%w: requested #%d, got #%v", ErrMismatchingHeader, number, header.Number)
+	}
+	return header, nil
+}
+
+// insert verifies a contiguous batch of headers against the consensus engine and
+// writes them into the store, announcing the new head if the canonical chain
+// changed.
+func (f *Follower) insert(headers []*types.Header) error {
+	for i := 1; i < len(headers); i++ {
+		if headers[i].ParentHash != headers[i-1].Hash() {
+			return fmt.Errorf("%w: #%d [%x] not child of [%x]", ErrMismatchingHeader,
+				headers[i].Number, headers[i].Hash().Bytes()[:4], headers[i-1].Hash().Bytes()[:4])
+		}
+	}
+	seals := make([]bool, len(headers))
+	for i := range seals {
+		seals[i] = true
+	}
+	abort, results := f.engine.VerifyHeaders(f.store, headers, seals)
+	defer close(abort)
+
+	for _, header := range headers {
+		if err := <-results; err != nil {
+			return fmt.Errorf("%w #%d [%x]: %w", ErrInvalidHeader, header.Number, header.Hash().Bytes()[:4], err)
+		}
+	}
+	changed, err := f.store.Insert(headers)
+	if err != nil {
+		return err
+	}
+	head := headers[len(headers)-1]
+	log.Debug("Imported verified headers", "count", len(headers), "number", head.Number, "hash", head.Hash(), "canonical", changed)
+	if changed {
+		f.headFeed.Send(f.store.CurrentHeader())
+	}
+	return nil
+}
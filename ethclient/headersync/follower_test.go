@@ -0,0 +1,211 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package headersync
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/consensus/clique"
+	"github.com/simplechain-org/client/consensus/misc"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/params"
+	"github.com/simplechain-org/client/rpc"
+)
+
+// testerSource is a header source serving a fixed chain, without subscriptions.
+type testerSource struct {
+	headers []*types.Header
+}
+
+func (s *testerSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return s.headers[len(s.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(s.headers)) {
+		return nil, client.NotFound
+	}
+	return s.headers[number.Uint64()], nil
+}
+
+func (s *testerSource) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (client.Subscription, error) {
+	return nil, rpc.ErrNotificationsUnsupported
+}
+
+// testerSigners is a set of clique signers, sorted by address.
+type testerSigners []*ecdsa.PrivateKey
+
+func newTesterSigners(n int) testerSigners {
+	signers := make(testerSigners, n)
+	for i := range signers {
+		signers[i], _ = crypto.GenerateKey()
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		a, b := crypto.PubkeyToAddress(signers[i].PublicKey), crypto.PubkeyToAddress(signers[j].PublicKey)
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+	return signers
+}
+
+// genesis creates a clique genesis header authorizing the signers.
+func (signers testerSigners) genesis() *types.Header {
+	extra := make([]byte, 32, 32+len(signers)*common.AddressLength+crypto.SignatureLength)
+	for _, key := range signers {
+		extra = append(extra, crypto.PubkeyToAddress(key.PublicKey).Bytes()...)
+	}
+	extra = append(extra, make([]byte, crypto.SignatureLength)...)
+
+	return &types.Header{
+		Number:     big.NewInt(0),
+		Time:       uint64(time.Now().Unix()) - 1000,
+		GasLimit:   params.GenesisGasLimit,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: big.NewInt(1),
+		UncleHash:  types.EmptyUncleHash,
+		Extra:      extra,
+	}
+}
+
+// extend appends n headers sealed in turn to the chain, offsetting their time to
+// fork off an otherwise identical chain.
+func (signers testerSigners) extend(chain []*types.Header, n int, offset uint64) []*types.Header {
+	chain = append([]*types.Header{}, chain...)
+	for i := 0; i < n; i++ {
+		parent := chain[len(chain)-1]
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Time:       parent.Time + 1 + offset,
+			GasLimit:   parent.GasLimit,
+			BaseFee:    misc.CalcBaseFee(params.AllCliqueProtocolChanges, parent),
+			Difficulty: big.NewInt(2),
+			UncleHash:  types.EmptyUncleHash,
+			Extra:      make([]byte, 32+crypto.SignatureLength),
+		}
+		signers.seal(header, signers[header.Number.Uint64()%uint64(len(signers))])
+		chain = append(chain, header)
+	}
+	return chain
+}
+
+// seal signs the header with the given key.
+func (signers testerSigners) seal(header *types.Header, key *ecdsa.PrivateKey) {
+	sig, err := crypto.Sign(clique.SealHash(header).Bytes(), key)
+	if err != nil {
+		panic(err)
+	}
+	copy(header.Extra[len(header.Extra)-crypto.SignatureLength:], sig)
+}
+
+func newTesterFollower(t *testing.T, db ethdb.Database, genesis *types.Header, source HeaderSource) *Follower {
+	store, err := NewStore(params.AllCliqueProtocolChanges, db, genesis)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	engine := clique.New(params.AllCliqueProtocolChanges.Clique, rawdb.NewMemoryDatabase())
+	return NewFollower(source, engine, store)
+}
+
+// Tests that a valid chain is synced, switched over on reorgs and resumed from
+// the database.
+func TestFollowerSync(t *testing.T) {
+	signers := newTesterSigners(3)
+	chain := signers.extend([]*types.Header{signers.genesis()}, 10, 0)
+
+	db := rawdb.NewMemoryDatabase()
+	source := &testerSource{headers: chain}
+	follower := newTesterFollower(t, db, chain[0], source)
+	if err := follower.Sync(context.Background()); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+	if head := follower.Store().CurrentHeader(); head.Hash() != chain[10].Hash() {
+		t.Fatalf("head mismatch: have #%d [%x], want #10 [%x]", head.Number, head.Hash(), chain[10].Hash())
+	}
+	// Switch the source over to a heavier fork and ensure it's followed
+	fork := signers.extend(chain[:5], 8, 1)
+	source.headers = fork
+	if err := follower.Sync(context.Background()); err != nil {
+		t.Fatalf("failed to sync fork: %v", err)
+	}
+	store := follower.Store()
+	for number, header := range fork {
+		if have := store.GetHeaderByNumber(uint64(number)); have == nil || have.Hash() != header.Hash() {
+			t.Errorf("canonical header #%d mismatch: have %v, want %x", number, have, header.Hash())
+		}
+	}
+	if have := store.GetHeaderByNumber(uint64(len(fork))); have != nil {
+		t.Errorf("stale canonical header #%d: %x", len(fork), have.Hash())
+	}
+	if have := store.GetHeaderByHash(chain[10].Hash()); have == nil {
+		t.Errorf("side chain header missing")
+	}
+	// Reopen the database and ensure the head is resumed
+	resumed, err := NewStore(params.AllCliqueProtocolChanges, db, chain[0])
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	if head := resumed.CurrentHeader(); head.Hash() != fork[len(fork)-1].Hash() {
+		t.Errorf("resumed head mismatch: have #%d, want #%d", head.Number, len(fork)-1)
+	}
+	if _, err := NewStore(params.AllCliqueProtocolChanges, db, newTesterSigners(1).genesis()); !errors.Is(err, ErrAnchorMismatch) {
+		t.Errorf("foreign anchor error mismatch: have %v, want %v", err, ErrAnchorMismatch)
+	}
+}
+
+// Tests that batches containing headers failing consensus verification are
+// rejected as a whole.
+func TestFollowerRejectsInvalid(t *testing.T) {
+	signers := newTesterSigners(3)
+	genesis := signers.genesis()
+	outsider, _ := crypto.GenerateKey()
+
+	tests := []struct {
+		name   string
+		tamper func(header *types.Header)
+	}{
+		{"unauthorized", func(header *types.Header) { signers.seal(header, outsider) }},
+		{"modified", func(header *types.Header) { header.GasUsed = 1 }},
+		{"difficulty", func(header *types.Header) {
+			header.Difficulty = big.NewInt(1)
+			signers.seal(header, signers[header.Number.Uint64()%3])
+		}},
+	}
+	for _, tt := range tests {
+		chain := signers.extend([]*types.Header{genesis}, 6, 0)
+		tt.tamper(chain[4])
+		chain = signers.extend(chain[:5], 2, 0)
+
+		follower := newTesterFollower(t, rawdb.NewMemoryDatabase(), genesis, &testerSource{headers: chain})
+		if err := follower.Sync(context.Background()); !errors.Is(err, ErrInvalidHeader) {
+			t.Errorf("%s: sync error mismatch: have %v, want %v", tt.name, err, ErrInvalidHeader)
+		}
+		if head := follower.Store().CurrentHeader(); head.Number.Uint64() != 0 {
+			t.Errorf("%s: head mismatch: have #%d, want #0", tt.name, head.Number)
+		}
+	}
+}
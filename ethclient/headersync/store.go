@@ -0,0 +1,233 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package headersync implements a header chain follower that verifies the
+// headers served by an untrusted RPC endpoint against a consensus engine.
+package headersync
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/log"
+	"github.com/simplechain-org/client/params"
+)
+
+// headerCacheLimit is the number of recent headers kept in memory.
+const headerCacheLimit = 512
+
+var (
+	// ErrAnchorMismatch is returned if the database already holds a chain that
+	// doesn't contain the trusted anchor header.
+	ErrAnchorMismatch = errors.New("anchor header mismatch")
+
+	// ErrUnknownParent is returned if a header is inserted without its parent
+	// being present in the store.
+	ErrUnknownParent = errors.New("unknown parent")
+
+	// ErrNonContiguousHeaders is returned if a batch of headers isn't a chain.
+	ErrNonContiguousHeaders = errors.New("non contiguous headers")
+)
+
+// Store is a local header chain persisted into a database, rooted at a trusted
+// anchor header. It implements consensus.ChainHeaderReader, so that consensus
+// engines can verify new headers against it. The canonical chain is the one
+// with the highest total difficulty counted from the anchor.
+type Store struct {
+	config *params.ChainConfig
+	db     ethdb.Database
+	anchor *types.Header
+
+	current atomic.Value // Current head of the canonical chain (*types.Header)
+	headers *lru.Cache   // Recently accessed headers, by hash
+
+	lock sync.Mutex // Serializes chain insertions
+}
+
+// NewStore creates a header store on top of the given database, rooted at the
+// trusted anchor, which is either the genesis header or a checkpoint the consensus
+// engine can start verifying from (e.g. a clique epoch header). If the database
+// already holds a chain, it is resumed from its head.
+func NewStore(config *params.ChainConfig, db ethdb.Database, anchor *types.Header) (*Store, error) {
+	headers, _ := lru.New(headerCacheLimit)
+	s := &Store{
+		config:  config,
+		db:      db,
+		anchor:  types.CopyHeader(anchor),
+		headers: headers,
+	}
+	var (
+		hash   = anchor.Hash()
+		number = anchor.Number.Uint64()
+	)
+	if stored := rawdb.ReadCanonicalHash(db, number); stored != (common.Hash{}) && stored != hash {
+		return nil, fmt.Errorf("%w: have %x, want %x", ErrAnchorMismatch, stored, hash)
+	}
+	if head := rawdb.ReadHeadHeaderHash(db); head != (common.Hash{}) {
+		if header := s.GetHeaderByHash(head); header != nil && header.Number.Uint64() >= number {
+			s.current.Store(header)
+			return s, nil
+		}
+		log.Warn("Discarding unreachable header chain head", "hash", head)
+	}
+	// Fresh database, or head lost, start afresh from the anchor. The total
+	// difficulty of the anchor is only used for relative comparisons.
+	td := new(big.Int)
+	if anchor.Difficulty != nil {
+		td.Set(anchor.Difficulty)
+	}
+	batch := db.NewBatch()
+	rawdb.WriteHeader(batch, anchor)
+	rawdb.WriteTd(batch, hash, number, td)
+	rawdb.WriteCanonicalHash(batch, hash, number)
+	rawdb.WriteHeadHeaderHash(batch, hash)
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	s.current.Store(s.anchor)
+	return s, nil
+}
+
+// Config implements consensus.ChainHeaderReader, retrieving the chain configuration.
+func (s *Store) Config() *params.ChainConfig {
+	return s.config
+}
+
+// Anchor returns the trusted header the store is rooted at.
+func (s *Store) Anchor() *types.Header {
+	return s.anchor
+}
+
+// CurrentHeader implements consensus.ChainHeaderReader, retrieving the head of
+// the canonical chain.
+func (s *Store) CurrentHeader() *types.Header {
+	return s.current.Load().(*types.Header)
+}
+
+// GetHeader implements consensus.ChainHeaderReader, retrieving a header by hash
+// and number, caching it if found.
+func (s *Store) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header, ok := s.headers.Get(hash); ok {
+		return header.(*types.Header)
+	}
+	header := rawdb.ReadHeader(s.db, hash, number)
+	if header == nil {
+		return nil
+	}
+	s.headers.Add(hash, header)
+	return header
+}
+
+// GetHeaderByHash implements consensus.ChainHeaderReader, retrieving a header by
+// hash, caching it if found.
+func (s *Store) GetHeaderByHash(hash common.Hash) *types.Header {
+	if header, ok := s.headers.Get(hash); ok {
+		return header.(*types.Header)
+	}
+	number := rawdb.ReadHeaderNumber(s.db, hash)
+	if number == nil {
+		return nil
+	}
+	return s.GetHeader(hash, *number)
+}
+
+// GetHeaderByNumber implements consensus.ChainHeaderReader, retrieving a header
+// of the canonical chain by number, caching it if found.
+func (s *Store) GetHeaderByNumber(number uint64) *types.Header {
+	hash := rawdb.ReadCanonicalHash(s.db, number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	return s.GetHeader(hash, number)
+}
+
+// GetTd retrieves the total difficulty of a header, counted from the anchor.
+func (s *Store) GetTd(hash common.Hash, number uint64) *big.Int {
+	return rawdb.ReadTd(s.db, hash, number)
+}
+
+// Insert writes a contiguous batch of headers, whose parent must already be
+// stored, and updates the canonical chain if the batch extends a heavier one.
+// The headers must have been verified by the caller; the return value reports
+// whether the canonical head changed.
+func (s *Store) Insert(headers []*types.Header) (bool, error) {
+	if len(headers) == 0 {
+		return false, nil
+	}
+	for i := 1; i < len(headers); i++ {
+		if headers[i].Number.Uint64() != headers[i-1].Number.Uint64()+1 || headers[i].ParentHash != headers[i-1].Hash() {
+			return false, fmt.Errorf("%w: #%d [%x] after #%d [%x]", ErrNonContiguousHeaders,
+				headers[i].Number, headers[i].Hash().Bytes()[:4], headers[i-1].Number, headers[i-1].Hash().Bytes()[:4])
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	first := headers[0]
+	if first.Number.Sign() == 0 {
+		return false, fmt.Errorf("%w: genesis header", ErrUnknownParent)
+	}
+	td := s.GetTd(first.ParentHash, first.Number.Uint64()-1)
+	if td == nil {
+		return false, fmt.Errorf("%w: #%d [%x]", ErrUnknownParent, first.Number.Uint64()-1, first.ParentHash.Bytes()[:4])
+	}
+	td = new(big.Int).Set(td)
+
+	batch := s.db.NewBatch()
+	for _, header := range headers {
+		td.Add(td, header.Difficulty)
+		rawdb.WriteHeader(batch, header)
+		rawdb.WriteTd(batch, header.Hash(), header.Number.Uint64(), td)
+	}
+	// Only switch the canonical chain over if the new one is heavier
+	var (
+		head    = headers[len(headers)-1]
+		current = s.CurrentHeader()
+	)
+	if td.Cmp(s.GetTd(current.Hash(), current.Number.Uint64())) <= 0 {
+		return false, batch.Write()
+	}
+	// Delete the canonical hashes of the old chain above the new head, then
+	// overwrite the rest until reaching the common ancestor
+	for number := head.Number.Uint64() + 1; number <= current.Number.Uint64(); number++ {
+		rawdb.DeleteCanonicalHash(batch, number)
+	}
+	for i := len(headers) - 1; i >= 0; i-- {
+		rawdb.WriteCanonicalHash(batch, headers[i].Hash(), headers[i].Number.Uint64())
+	}
+	for hash, number := first.ParentHash, first.Number.Uint64()-1; rawdb.ReadCanonicalHash(s.db, number) != hash; number-- {
+		header := s.GetHeader(hash, number)
+		if header == nil {
+			return false, fmt.Errorf("%w: #%d [%x]", ErrUnknownParent, number, hash.Bytes()[:4])
+		}
+		rawdb.WriteCanonicalHash(batch, hash, number)
+		hash = header.ParentHash
+	}
+	rawdb.WriteHeadHeaderHash(batch, head.Hash())
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+	s.current.Store(types.CopyHeader(head))
+	return true, nil
+}
@@ -0,0 +1,570 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package mocknode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/rpc"
+)
+
+// netAPI serves the net namespace.
+type netAPI struct {
+	node *Node
+}
+
+// Version returns the network ID, which is the chain ID.
+func (api *netAPI) Version() string {
+	return api.node.config.ChainID.String()
+}
+
+// ethAPI serves the eth namespace.
+type ethAPI struct {
+	node *Node
+}
+
+// ChainId returns the chain ID.
+func (api *ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.node.config.ChainID)
+}
+
+// BlockNumber returns the number of the head block.
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.node.Head().NumberU64())
+}
+
+// Syncing always reports the node as synced.
+func (api *ethAPI) Syncing() bool {
+	return false
+}
+
+// GasPrice returns the suggested tip on top of the base fee of the head.
+func (api *ethAPI) GasPrice() *hexutil.Big {
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	price := new(big.Int).Set(api.node.gasTip)
+	if fee := api.node.head.BaseFee(); fee != nil {
+		price.Add(price, fee)
+	}
+	return (*hexutil.Big)(price)
+}
+
+// MaxPriorityFeePerGas returns the suggested tip.
+func (api *ethAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	return (*hexutil.Big)(new(big.Int).Set(api.node.gasTip))
+}
+
+// blockByNumber resolves a block number of the canonical chain, the pending block
+// being the head.
+func (api *ethAPI) blockByNumber(number rpc.BlockNumber) *types.Block {
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return api.node.Head()
+	default:
+		return api.node.BlockByNumber(uint64(number))
+	}
+}
+
+// blockByHash returns a known block, canonical or not.
+func (api *ethAPI) blockByHash(hash common.Hash) *types.Block {
+	number := rawdb.ReadHeaderNumber(api.node.db, hash)
+	if number == nil {
+		return nil
+	}
+	return rawdb.ReadBlock(api.node.db, hash, *number)
+}
+
+// GetBlockByNumber returns a block of the canonical chain.
+func (api *ethAPI) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	if block := api.blockByNumber(number); block != nil {
+		return marshalBlock(block, fullTx, api.node.signer)
+	}
+	return nil, nil
+}
+
+// GetBlockByHash returns a block by hash.
+func (api *ethAPI) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	if block := api.blockByHash(hash); block != nil {
+		return marshalBlock(block, fullTx, api.node.signer)
+	}
+	return nil, nil
+}
+
+// GetUncleByBlockHashAndIndex returns an uncle header of a block.
+func (api *ethAPI) GetUncleByBlockHashAndIndex(hash common.Hash, index hexutil.Uint) (map[string]interface{}, error) {
+	if block := api.blockByHash(hash); block != nil && int(index) < len(block.Uncles()) {
+		return marshalFields(block.Uncles()[index])
+	}
+	return nil, nil
+}
+
+// GetBlockTransactionCountByHash returns the number of transactions in a block.
+func (api *ethAPI) GetBlockTransactionCountByHash(hash common.Hash) *hexutil.Uint {
+	if block := api.blockByHash(hash); block != nil {
+		n := hexutil.Uint(len(block.Transactions()))
+		return &n
+	}
+	return nil
+}
+
+// GetBlockTransactionCountByNumber returns the number of transactions in a block,
+// or in the pending pool for the pending block.
+func (api *ethAPI) GetBlockTransactionCountByNumber(number rpc.BlockNumber) *hexutil.Uint {
+	if number == rpc.PendingBlockNumber {
+		n := hexutil.Uint(len(api.node.Pending()))
+		return &n
+	}
+	if block := api.blockByNumber(number); block != nil {
+		n := hexutil.Uint(len(block.Transactions()))
+		return &n
+	}
+	return nil
+}
+
+// GetTransactionByHash returns an included or pending transaction.
+func (api *ethAPI) GetTransactionByHash(hash common.Hash) (map[string]interface{}, error) {
+	api.node.lock.RLock()
+	tx := api.node.pendingTx(hash)
+	api.node.lock.RUnlock()
+	if tx != nil {
+		return marshalTx(tx, common.Hash{}, 0, 0, api.node.signer)
+	}
+	tx, blockHash, number, index := rawdb.ReadTransaction(api.node.db, hash)
+	if tx == nil {
+		return nil, nil
+	}
+	return marshalTx(tx, blockHash, number, index, api.node.signer)
+}
+
+// GetTransactionByBlockHashAndIndex returns a transaction of a block.
+func (api *ethAPI) GetTransactionByBlockHashAndIndex(hash common.Hash, index hexutil.Uint) (map[string]interface{}, error) {
+	block := api.blockByHash(hash)
+	if block == nil || int(index) >= len(block.Transactions()) {
+		return nil, nil
+	}
+	return marshalTx(block.Transactions()[index], hash, block.NumberU64(), uint64(index), api.node.signer)
+}
+
+// GetTransactionReceipt returns the receipt of an included transaction.
+func (api *ethAPI) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	receipt, _, _, _ := rawdb.ReadReceipt(api.node.db, hash, api.node.config)
+	if receipt != nil {
+		ensureTopics(receipt.Logs)
+	}
+	return receipt, nil
+}
+
+// checkBlock returns an error if the block of a state query is unknown.
+func (api *ethAPI) checkBlock(block rpc.BlockNumberOrHash) error {
+	if hash, ok := block.Hash(); ok {
+		if api.blockByHash(hash) == nil {
+			return errUnknownBlock
+		}
+	} else if number, ok := block.Number(); ok && api.blockByNumber(number) == nil {
+		return errUnknownBlock
+	}
+	return nil
+}
+
+// state returns the scripted state of an account, if the block of the query exists.
+func (api *ethAPI) state(addr common.Address, block rpc.BlockNumberOrHash) (account, error) {
+	if err := api.checkBlock(block); err != nil {
+		return account{}, err
+	}
+	api.node.lock.Lock()
+	defer api.node.lock.Unlock()
+
+	acc := *api.node.account(addr)
+	acc.balance = new(big.Int).Set(acc.balance)
+	if number, ok := block.Number(); ok && number == rpc.PendingBlockNumber {
+		acc.nonce = api.node.pendingNonce(addr)
+	}
+	return acc, nil
+}
+
+// GetBalance returns the scripted balance of an account.
+func (api *ethAPI) GetBalance(addr common.Address, block rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	acc, err := api.state(addr, block)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(acc.balance), nil
+}
+
+// GetTransactionCount returns the nonce of an account, including its pending
+// transactions for the pending block.
+func (api *ethAPI) GetTransactionCount(addr common.Address, block rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	acc, err := api.state(addr, block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := hexutil.Uint64(acc.nonce)
+	return &nonce, nil
+}
+
+// GetCode returns the scripted code of an account.
+func (api *ethAPI) GetCode(addr common.Address, block rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	acc, err := api.state(addr, block)
+	if err != nil {
+		return nil, err
+	}
+	return common.CopyBytes(acc.code), nil
+}
+
+// GetStorageAt returns a scripted storage slot of an account.
+func (api *ethAPI) GetStorageAt(addr common.Address, key common.Hash, block rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	acc, err := api.state(addr, block)
+	if err != nil {
+		return nil, err
+	}
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	value := acc.storage[key]
+	return value[:], nil
+}
+
+// callArgs are the arguments of eth_call and eth_estimateGas.
+type callArgs struct {
+	From  *common.Address `json:"from"`
+	To    *common.Address `json:"to"`
+	Data  *hexutil.Bytes  `json:"data"`
+	Input *hexutil.Bytes  `json:"input"`
+}
+
+func (args *callArgs) data() []byte {
+	if args.Input != nil {
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
+	}
+	return nil
+}
+
+// cannedCall returns the scripted result of a call, nil if there is none.
+func (api *ethAPI) cannedCall(args callArgs) *cannedCall {
+	if args.To == nil {
+		return nil
+	}
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	return api.node.call(*args.To, args.data())
+}
+
+// Call returns the scripted output of a call.
+func (api *ethAPI) Call(args callArgs, block rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if err := api.checkBlock(block); err != nil {
+		return nil, err
+	}
+	call := api.cannedCall(args)
+	if call == nil {
+		return hexutil.Bytes{}, nil
+	}
+	if call.err != nil {
+		return nil, call.err
+	}
+	return common.CopyBytes(call.output), nil
+}
+
+// EstimateGas returns the scripted gas estimate, or the error scripted for a call.
+func (api *ethAPI) EstimateGas(args callArgs) (hexutil.Uint64, error) {
+	if call := api.cannedCall(args); call != nil && call.err != nil {
+		return 0, call.err
+	}
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	return hexutil.Uint64(api.node.estimate), nil
+}
+
+// SendRawTransaction adds a signed transaction to the pending pool.
+func (api *ethAPI) SendRawTransaction(input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.node.SendTransaction(tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// GetLogs returns the logs of the canonical chain matching a filter.
+func (api *ethAPI) GetLogs(crit filterCriteria) ([]*types.Log, error) {
+	var blocks []*types.Block
+	if crit.BlockHash != nil {
+		block := api.blockByHash(*crit.BlockHash)
+		if block == nil {
+			return nil, errors.New("unknown block")
+		}
+		blocks = append(blocks, block)
+	} else {
+		head := api.node.Head().NumberU64()
+		from, to := resolveNumber(crit.FromBlock, head), resolveNumber(crit.ToBlock, head)
+		for number := from; number <= to && number <= head; number++ {
+			if block := api.node.BlockByNumber(number); block != nil {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+	logs := []*types.Log{}
+	for _, block := range blocks {
+		for _, txLogs := range rawdb.ReadLogs(api.node.db, block.Hash(), block.NumberU64()) {
+			ensureTopics(txLogs)
+			logs = append(logs, crit.filter(txLogs)...)
+		}
+	}
+	return logs, nil
+}
+
+// NewHeads notifies the headers of new blocks.
+func (api *ethAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	var (
+		rpcSub = notifier.CreateSubscription()
+		heads  = make(chan *types.Header, 16)
+		sub    = api.node.scope.Track(api.node.headFeed.Subscribe(heads))
+	)
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case head := <-heads:
+				notifier.Notify(rpcSub.ID, head)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// Logs notifies the logs of new blocks matching a filter, and the removed ones
+// when the chain is rewound. The block range of the filter is ignored.
+func (api *ethAPI) Logs(ctx context.Context, crit filterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	var (
+		rpcSub = notifier.CreateSubscription()
+		batch  = make(chan []*types.Log, 16)
+		sub    = api.node.scope.Track(api.node.logsFeed.Subscribe(batch))
+	)
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case logs := <-batch:
+				for _, log := range crit.filter(logs) {
+					notifier.Notify(rpcSub.ID, log)
+				}
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// resolveNumber converts a filter block number into a block height.
+func resolveNumber(number *rpc.BlockNumber, head uint64) uint64 {
+	if number == nil || *number < 0 {
+		return head
+	}
+	return uint64(*number)
+}
+
+// filterCriteria is a log filter, as sent by ethclient.
+type filterCriteria struct {
+	BlockHash *common.Hash
+	FromBlock *rpc.BlockNumber
+	ToBlock   *rpc.BlockNumber
+	Addresses []common.Address
+	Topics    [][]common.Hash
+}
+
+// UnmarshalJSON parses a filter with a single address or a list of them, and topic
+// positions being null, a single topic or a list of alternatives.
+func (crit *filterCriteria) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		BlockHash *common.Hash      `json:"blockHash"`
+		FromBlock *rpc.BlockNumber  `json:"fromBlock"`
+		ToBlock   *rpc.BlockNumber  `json:"toBlock"`
+		Addresses json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	crit.BlockHash, crit.FromBlock, crit.ToBlock = raw.BlockHash, raw.FromBlock, raw.ToBlock
+
+	if len(raw.Addresses) > 0 && string(raw.Addresses) != "null" {
+		if err := json.Unmarshal(raw.Addresses, &crit.Addresses); err != nil {
+			var addr common.Address
+			if err := json.Unmarshal(raw.Addresses, &addr); err != nil {
+				return fmt.Errorf("invalid address filter: %v", err)
+			}
+			crit.Addresses = []common.Address{addr}
+		}
+	}
+	for i, position := range raw.Topics {
+		var topics []common.Hash
+		if string(position) != "null" {
+			if err := json.Unmarshal(position, &topics); err != nil {
+				var topic common.Hash
+				if err := json.Unmarshal(position, &topic); err != nil {
+					return fmt.Errorf("invalid topic filter %d: %v", i, err)
+				}
+				topics = []common.Hash{topic}
+			}
+		}
+		crit.Topics = append(crit.Topics, topics)
+	}
+	return nil
+}
+
+// filter returns the logs matching the addresses and topics of the criteria.
+func (crit *filterCriteria) filter(logs []*types.Log) []*types.Log {
+	var matches []*types.Log
+	for _, log := range logs {
+		if crit.matches(log) {
+			matches = append(matches, log)
+		}
+	}
+	return matches
+}
+
+func (crit *filterCriteria) matches(log *types.Log) bool {
+	if len(crit.Addresses) > 0 {
+		found := false
+		for _, addr := range crit.Addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(crit.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, alternatives := range crit.Topics {
+		if len(alternatives) == 0 {
+			continue // wildcard
+		}
+		found := false
+		for _, topic := range alternatives {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// marshalBlock encodes a block like the eth namespace of a full node.
+func marshalBlock(block *types.Block, fullTx bool, signer types.Signer) (map[string]interface{}, error) {
+	fields, err := marshalFields(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	fields["size"] = hexutil.Uint64(block.Size())
+	uncles := make([]common.Hash, len(block.Uncles()))
+	for i, uncle := range block.Uncles() {
+		uncles[i] = uncle.Hash()
+	}
+	fields["uncles"] = uncles
+
+	txs := make([]interface{}, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		if !fullTx {
+			txs[i] = tx.Hash()
+			continue
+		}
+		if txs[i], err = marshalTx(tx, block.Hash(), block.NumberU64(), uint64(i), signer); err != nil {
+			return nil, err
+		}
+	}
+	fields["transactions"] = txs
+	return fields, nil
+}
+
+// marshalTx encodes a transaction with its sender and inclusion, the latter only
+// if the block hash is set.
+func marshalTx(tx *types.Transaction, blockHash common.Hash, number, index uint64, signer types.Signer) (map[string]interface{}, error) {
+	fields, err := marshalFields(tx)
+	if err != nil {
+		return nil, err
+	}
+	from, _ := types.Sender(signer, tx)
+	fields["from"] = from
+	if blockHash != (common.Hash{}) {
+		fields["blockHash"] = blockHash
+		fields["blockNumber"] = hexutil.Uint64(number)
+		fields["transactionIndex"] = hexutil.Uint64(index)
+	} else {
+		fields["blockHash"] = nil
+		fields["blockNumber"] = nil
+		fields["transactionIndex"] = nil
+	}
+	return fields, nil
+}
+
+// marshalFields encodes a value into a JSON object, so fields can be added to it.
+func marshalFields(v interface{}) (map[string]interface{}, error) {
+	blob, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(blob, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
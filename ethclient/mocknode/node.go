@@ -0,0 +1,559 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package mocknode implements a programmable in-process Ethereum node, serving the
+// eth namespace from a scripted in-memory chain. It allows testing code built on
+// ethclient.Client or bind.ContractBackend without a network.
+//
+// The chain is made of the blocks appended by the test. Account state is not
+// versioned nor derived from transactions: balances, code and storage are whatever
+// the test sets, at every block. Only nonces follow the included transactions.
+// Contract calls return canned results.
+package mocknode
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/consensus/misc"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/ethclient"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/event"
+	"github.com/simplechain-org/client/params"
+	"github.com/simplechain-org/client/rpc"
+	"github.com/simplechain-org/client/trie"
+)
+
+const (
+	blockGasLimit = 30000000 // Gas limit of the generated blocks
+	blockPeriod   = 10       // Seconds between the timestamps of generated blocks
+)
+
+var (
+	// ErrNonceTooLow is returned for sent transactions whose nonce was included
+	// in the chain already.
+	ErrNonceTooLow = errors.New("nonce too low")
+
+	// ErrAlreadyKnown is returned for sent transactions which are pending already.
+	ErrAlreadyKnown = errors.New("already known")
+
+	// ErrReplaceUnderpriced is returned for sent transactions replacing a pending
+	// one without raising its fee caps by at least 10%.
+	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
+
+	// errUnknownBlock is returned for state queries of unknown blocks.
+	errUnknownBlock = errors.New("header not found")
+)
+
+// account is the scripted state of an address.
+type account struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[common.Hash]common.Hash
+}
+
+// cannedCall is a scripted eth_call result.
+type cannedCall struct {
+	input  []byte
+	output []byte
+	err    error
+}
+
+// Node is an in-process fake Ethereum node.
+type Node struct {
+	config *params.ChainConfig
+	signer types.Signer
+	db     ethdb.Database
+	server *rpc.Server
+
+	lock     sync.RWMutex
+	head     *types.Block
+	forks    uint64 // number of rewinds, to tell apart the blocks of side chains
+	accounts map[common.Address]*account
+	calls    map[common.Address][]*cannedCall
+	pool     map[common.Address]map[uint64]*types.Transaction // pending transactions by sender and nonce
+	gasTip   *big.Int
+	estimate uint64
+
+	headFeed event.Feed // *types.Header of every new block
+	logsFeed event.Feed // []*types.Log of every new block, or removed ones on rewinds
+	scope    event.SubscriptionScope
+}
+
+// New creates a node with a genesis block. If config is nil, the chain follows
+// params.TestChainConfig.
+func New(config *params.ChainConfig) *Node {
+	if config == nil {
+		config = params.TestChainConfig
+	}
+	n := &Node{
+		config:   config,
+		signer:   types.LatestSigner(config),
+		db:       rawdb.NewMemoryDatabase(),
+		server:   rpc.NewServer(),
+		accounts: make(map[common.Address]*account),
+		calls:    make(map[common.Address][]*cannedCall),
+		pool:     make(map[common.Address]map[uint64]*types.Transaction),
+		gasTip:   big.NewInt(params.GWei),
+		estimate: params.TxGas,
+	}
+	genesis := &types.Header{
+		Difficulty: common.Big1,
+		Number:     common.Big0,
+		GasLimit:   blockGasLimit,
+		UncleHash:  types.EmptyUncleHash,
+	}
+	if config.IsLondon(common.Big0) {
+		genesis.BaseFee = big.NewInt(params.InitialBaseFee)
+	}
+	n.writeBlock(types.NewBlock(genesis, nil, nil, nil, trie.NewStackTrie(nil)), nil)
+
+	if err := n.server.RegisterName("eth", &ethAPI{n}); err != nil {
+		panic(err)
+	}
+	if err := n.server.RegisterName("net", &netAPI{n}); err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// Server returns the RPC server of the node, e.g. to expose it over HTTP.
+func (n *Node) Server() *rpc.Server {
+	return n.server
+}
+
+// Attach creates an RPC client connected to the node in-process.
+func (n *Node) Attach() *rpc.Client {
+	return rpc.DialInProc(n.server)
+}
+
+// Client creates an ethclient connected to the node in-process. It implements
+// bind.ContractBackend.
+func (n *Node) Client() *ethclient.Client {
+	return ethclient.NewClient(n.Attach())
+}
+
+// Close stops serving and ends all subscriptions.
+func (n *Node) Close() {
+	n.scope.Close()
+	n.server.Stop()
+}
+
+// Config returns the chain configuration of the node.
+func (n *Node) Config() *params.ChainConfig {
+	return n.config
+}
+
+// Head returns the head block of the canonical chain.
+func (n *Node) Head() *types.Block {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.head
+}
+
+// BlockByNumber returns a block of the canonical chain, nil if it doesn't exist.
+func (n *Node) BlockByNumber(number uint64) *types.Block {
+	hash := rawdb.ReadCanonicalHash(n.db, number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	return rawdb.ReadBlock(n.db, hash, number)
+}
+
+// account returns the state of an address, creating it if needed. The lock must
+// be held.
+func (n *Node) account(addr common.Address) *account {
+	acc := n.accounts[addr]
+	if acc == nil {
+		acc = &account{balance: new(big.Int), storage: make(map[common.Hash]common.Hash)}
+		n.accounts[addr] = acc
+	}
+	return acc
+}
+
+// SetBalance sets the balance of an account.
+func (n *Node) SetBalance(addr common.Address, balance *big.Int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.account(addr).balance = new(big.Int).Set(balance)
+}
+
+// SetNonce sets the nonce of an account, the number of its included transactions.
+func (n *Node) SetNonce(addr common.Address, nonce uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.account(addr).nonce = nonce
+}
+
+// SetCode sets the code of a contract account.
+func (n *Node) SetCode(addr common.Address, code []byte) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.account(addr).code = common.CopyBytes(code)
+}
+
+// SetStorage sets a storage slot of a contract account.
+func (n *Node) SetStorage(addr common.Address, key, value common.Hash) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.account(addr).storage[key] = value
+}
+
+// SetCallResult scripts the output of eth_call for calls to a contract. Calls
+// whose data equals the input are matched first, otherwise the longest input
+// prefixing the data is used, so the 4 byte selector alone matches a method
+// regardless of its arguments. Unmatched calls return no output, like calls to
+// accounts without code.
+func (n *Node) SetCallResult(to common.Address, input, output []byte) {
+	n.setCall(to, &cannedCall{input: common.CopyBytes(input), output: common.CopyBytes(output)})
+}
+
+// SetCallError scripts an error returned by eth_call and eth_estimateGas for calls
+// to a contract, matched like in SetCallResult.
+func (n *Node) SetCallError(to common.Address, input []byte, err error) {
+	n.setCall(to, &cannedCall{input: common.CopyBytes(input), err: err})
+}
+
+func (n *Node) setCall(to common.Address, call *cannedCall) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	calls := n.calls[to]
+	for i, old := range calls {
+		if bytes.Equal(old.input, call.input) {
+			calls[i] = call
+			return
+		}
+	}
+	n.calls[to] = append(calls, call)
+}
+
+// call looks up the scripted result of a call. The lock must be held.
+func (n *Node) call(to common.Address, data []byte) *cannedCall {
+	var match *cannedCall
+	for _, call := range n.calls[to] {
+		if bytes.Equal(call.input, data) {
+			return call
+		}
+		if bytes.HasPrefix(data, call.input) && (match == nil || len(call.input) > len(match.input)) {
+			match = call
+		}
+	}
+	return match
+}
+
+// SetGasTip sets the priority fee suggested by eth_maxPriorityFeePerGas. The gas
+// price suggested by eth_gasPrice is the tip on top of the base fee of the head.
+func (n *Node) SetGasTip(tip *big.Int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.gasTip = new(big.Int).Set(tip)
+}
+
+// SetGasEstimate sets the result of eth_estimateGas for calls which don't have
+// a scripted error.
+func (n *Node) SetGasEstimate(gas uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.estimate = gas
+}
+
+// SendTransaction adds a transaction to the pending pool, like sending it through
+// eth_sendRawTransaction.
+func (n *Node) SendTransaction(tx *types.Transaction) error {
+	from, err := types.Sender(n.signer, tx)
+	if err != nil {
+		return err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if tx.Nonce() < n.account(from).nonce {
+		return ErrNonceTooLow
+	}
+	pending := n.pool[from]
+	if pending == nil {
+		pending = make(map[uint64]*types.Transaction)
+		n.pool[from] = pending
+	}
+	if old := pending[tx.Nonce()]; old != nil {
+		if old.Hash() == tx.Hash() {
+			return ErrAlreadyKnown
+		}
+		if !bumped(old.GasFeeCap(), tx.GasFeeCap()) || !bumped(old.GasTipCap(), tx.GasTipCap()) {
+			return ErrReplaceUnderpriced
+		}
+	}
+	pending[tx.Nonce()] = tx
+	return nil
+}
+
+// bumped reports whether a price was raised by at least 10%.
+func bumped(prev, next *big.Int) bool {
+	threshold := new(big.Int).Mul(prev, big.NewInt(110))
+	return threshold.Cmp(new(big.Int).Mul(next, big.NewInt(100))) <= 0
+}
+
+// Pending returns the transactions of the pending pool, ordered by sender and nonce.
+func (n *Node) Pending() []*types.Transaction {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	var txs []*types.Transaction
+	for _, pending := range n.pool {
+		for _, tx := range pending {
+			txs = append(txs, tx)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		fi, _ := types.Sender(n.signer, txs[i])
+		fj, _ := types.Sender(n.signer, txs[j])
+		if fi != fj {
+			return bytes.Compare(fi[:], fj[:]) < 0
+		}
+		return txs[i].Nonce() < txs[j].Nonce()
+	})
+	return txs
+}
+
+// pendingNonce returns the next nonce of an account, including its pending
+// transactions. The lock must be held.
+func (n *Node) pendingNonce(addr common.Address) uint64 {
+	nonce := n.account(addr).nonce
+	for n.pool[addr][nonce] != nil {
+		nonce++
+	}
+	return nonce
+}
+
+// pendingTx returns a pending transaction by hash. The lock must be held.
+func (n *Node) pendingTx(hash common.Hash) *types.Transaction {
+	for _, pending := range n.pool {
+		for _, tx := range pending {
+			if tx.Hash() == hash {
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
+// BlockGen creates the contents of a block appended by AddBlock.
+type BlockGen struct {
+	node     *Node
+	header   *types.Header
+	txs      []*types.Transaction
+	receipts []*types.Receipt
+	uncles   []*types.Header
+}
+
+// Number returns the number of the block.
+func (b *BlockGen) Number() *big.Int {
+	return new(big.Int).Set(b.header.Number)
+}
+
+// SetCoinbase sets the miner of the block.
+func (b *BlockGen) SetCoinbase(addr common.Address) {
+	b.header.Coinbase = addr
+}
+
+// SetExtra sets the extra data of the block.
+func (b *BlockGen) SetExtra(data []byte) {
+	b.header.Extra = common.CopyBytes(data)
+}
+
+// AddUncle includes an uncle header in the block.
+func (b *BlockGen) AddUncle(header *types.Header) {
+	b.uncles = append(b.uncles, types.CopyHeader(header))
+}
+
+// AddTx includes a successful transaction emitting the given logs, and returns
+// its receipt. The transaction uses all of its gas. The derived fields of the logs
+// and receipt are filled in once the block is complete.
+func (b *BlockGen) AddTx(tx *types.Transaction, logs ...*types.Log) *types.Receipt {
+	return b.addTx(tx, types.ReceiptStatusSuccessful, logs)
+}
+
+// AddFailedTx includes a reverted transaction and returns its receipt.
+func (b *BlockGen) AddFailedTx(tx *types.Transaction) *types.Receipt {
+	return b.addTx(tx, types.ReceiptStatusFailed, nil)
+}
+
+func (b *BlockGen) addTx(tx *types.Transaction, status uint64, logs []*types.Log) *types.Receipt {
+	from, err := types.Sender(b.node.signer, tx)
+	if err != nil {
+		panic(err)
+	}
+	b.header.GasUsed += tx.Gas()
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		Status:            status,
+		CumulativeGasUsed: b.header.GasUsed,
+		Logs:              logs,
+	}
+	if receipt.Logs == nil {
+		receipt.Logs = []*types.Log{}
+	}
+	ensureTopics(receipt.Logs)
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	b.txs = append(b.txs, tx)
+	b.receipts = append(b.receipts, receipt)
+
+	// Advance the nonce of the sender and drop the transaction from the pool
+	if acc := b.node.account(from); acc.nonce <= tx.Nonce() {
+		acc.nonce = tx.Nonce() + 1
+	}
+	if pending := b.node.pool[from]; pending != nil {
+		for nonce := range pending {
+			if nonce <= tx.Nonce() {
+				delete(pending, nonce)
+			}
+		}
+		if len(pending) == 0 {
+			delete(b.node.pool, from)
+		}
+	}
+	return receipt
+}
+
+// AddBlock appends a block to the canonical chain, with the contents created by
+// gen (which may be nil for an empty block), and notifies the subscribers. The
+// node is locked while gen runs, so it must not call other methods of the node.
+func (n *Node) AddBlock(gen func(*BlockGen)) *types.Block {
+	n.lock.Lock()
+	parent := n.head.Header()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Difficulty: common.Big1,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   blockGasLimit,
+		Time:       parent.Time + blockPeriod,
+		Nonce:      types.EncodeNonce(n.forks),
+	}
+	if n.config.IsLondon(header.Number) {
+		header.BaseFee = misc.CalcBaseFee(n.config, parent)
+	}
+	b := &BlockGen{node: n, header: header}
+	if gen != nil {
+		gen(b)
+	}
+	block := types.NewBlock(header, b.txs, b.uncles, b.receipts, trie.NewStackTrie(nil))
+	logs := n.writeBlock(block, b.receipts)
+	n.lock.Unlock()
+
+	n.headFeed.Send(block.Header())
+	if len(logs) > 0 {
+		n.logsFeed.Send(logs)
+	}
+	return block
+}
+
+// Commit appends a block including the executable transactions of the pending
+// pool, and returns it.
+func (n *Node) Commit() *types.Block {
+	return n.AddBlock(func(b *BlockGen) {
+		senders := make([]common.Address, 0, len(n.pool))
+		for from := range n.pool {
+			senders = append(senders, from)
+		}
+		sort.Slice(senders, func(i, j int) bool { return bytes.Compare(senders[i][:], senders[j][:]) < 0 })
+
+		for _, from := range senders {
+			for nonce := n.account(from).nonce; n.pool[from][nonce] != nil; nonce++ {
+				b.AddTx(n.pool[from][nonce])
+			}
+		}
+	})
+}
+
+// writeBlock stores a block as the new head of the canonical chain, returning the
+// logs of its receipts. The lock must be held.
+func (n *Node) writeBlock(block *types.Block, receipts types.Receipts) []*types.Log {
+	hash, number := block.Hash(), block.NumberU64()
+
+	rawdb.WriteBlock(n.db, block)
+	rawdb.WriteReceipts(n.db, hash, number, receipts)
+	rawdb.WriteCanonicalHash(n.db, hash, number)
+	rawdb.WriteTxLookupEntriesByBlock(n.db, block)
+	rawdb.WriteHeadBlockHash(n.db, hash)
+	n.head = block
+
+	var logs []*types.Log
+	if len(receipts) > 0 {
+		receipts.DeriveFields(n.config, hash, number, block.Transactions())
+		for _, receipt := range receipts {
+			logs = append(logs, receipt.Logs...)
+		}
+	}
+	return logs
+}
+
+// SetHead rewinds the canonical chain to the given block, notifying log subscribers
+// about the removed logs. Blocks appended afterwards form a side chain, differing
+// from the rewound ones. Account state is left untouched.
+func (n *Node) SetHead(number uint64) {
+	n.lock.Lock()
+	var removed []*types.Log
+	for current := n.head.NumberU64(); current > number; current-- {
+		block := n.BlockByNumber(current)
+		for _, logs := range rawdb.ReadLogs(n.db, block.Hash(), current) {
+			ensureTopics(logs)
+			for _, log := range logs {
+				log.Removed = true
+				removed = append(removed, log)
+			}
+		}
+		for _, tx := range block.Transactions() {
+			rawdb.DeleteTxLookupEntry(n.db, tx.Hash())
+		}
+		rawdb.DeleteCanonicalHash(n.db, current)
+	}
+	if head := n.BlockByNumber(number); head != nil {
+		n.head = head
+		rawdb.WriteHeadBlockHash(n.db, head.Hash())
+	}
+	n.forks++
+	n.lock.Unlock()
+
+	if len(removed) > 0 {
+		n.logsFeed.Send(removed)
+	}
+}
+
+// ensureTopics replaces missing log topics with empty lists, which JSON decoding
+// of logs requires.
+func ensureTopics(logs []*types.Log) {
+	for _, log := range logs {
+		if log.Topics == nil {
+			log.Topics = []common.Hash{}
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package mocknode
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/accounts/abi"
+	"github.com/simplechain-org/client/accounts/abi/bind"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/params"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testToken   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testTopic   = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	testABI     = `[{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},{"type":"function","name":"burn","inputs":[],"outputs":[]}]`
+	testChainID = params.TestChainConfig.ChainID
+)
+
+// signTx creates a signed transfer to the test token.
+func signTx(t *testing.T, nonce uint64, tip int64) *types.Transaction {
+	t.Helper()
+
+	tx, err := types.SignNewTx(testKey, types.LatestSignerForChainID(testChainID), &types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: big.NewInt(10 * tip),
+		Gas:       params.TxGas,
+		To:        &testToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestChainAccess(t *testing.T) {
+	node := New(nil)
+	defer node.Close()
+	ec := node.Client()
+	defer ec.Close()
+	ctx := context.Background()
+
+	tx := signTx(t, 0, params.GWei)
+	log := &types.Log{Address: testToken, Topics: []common.Hash{testTopic, testAddr.Hash()}, Data: []byte{1}}
+	block := node.AddBlock(func(b *BlockGen) {
+		b.SetCoinbase(testAddr)
+		b.AddTx(tx, log)
+	})
+	node.AddBlock(nil)
+
+	if number, err := ec.BlockNumber(ctx); err != nil || number != 2 {
+		t.Fatalf("block number mismatch: have %d, %v, want 2", number, err)
+	}
+	fetched, err := ec.BlockByNumber(ctx, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched.Hash() != block.Hash() || len(fetched.Transactions()) != 1 || fetched.Transactions()[0].Hash() != tx.Hash() {
+		t.Fatalf("block mismatch: have %x, want %x", fetched.Hash(), block.Hash())
+	}
+	if header, err := ec.HeaderByHash(ctx, block.Hash()); err != nil || header.Hash() != block.Hash() {
+		t.Fatalf("header mismatch: %v", err)
+	}
+	if _, err := ec.BlockByNumber(ctx, big.NewInt(3)); err != client.NotFound {
+		t.Fatalf("missing block error mismatch: have %v, want %v", err, client.NotFound)
+	}
+	// Transactions and receipts are indexed
+	included, pending, err := ec.TransactionByHash(ctx, tx.Hash())
+	if err != nil || pending || included.Hash() != tx.Hash() {
+		t.Fatalf("transaction mismatch: pending %v, err %v", pending, err)
+	}
+	if sender, err := ec.TransactionSender(ctx, included, block.Hash(), 0); err != nil || sender != testAddr {
+		t.Fatalf("sender mismatch: have %x, %v, want %x", sender, err, testAddr)
+	}
+	receipt, err := ec.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.BlockHash != block.Hash() || len(receipt.Logs) != 1 || receipt.Logs[0].TxHash != tx.Hash() {
+		t.Fatalf("receipt mismatch: %+v", receipt)
+	}
+	// Logs are filtered by address and topics
+	logs, err := ec.FilterLogs(ctx, client.FilterQuery{Addresses: []common.Address{testToken}, Topics: [][]common.Hash{{testTopic}}})
+	if err != nil || len(logs) != 1 || logs[0].BlockNumber != 1 {
+		t.Fatalf("log filter mismatch: have %d logs, %v", len(logs), err)
+	}
+	logs, err = ec.FilterLogs(ctx, client.FilterQuery{Topics: [][]common.Hash{nil, {testToken.Hash()}}})
+	if err != nil || len(logs) != 0 {
+		t.Fatalf("mismatching log filter returned %d logs, %v", len(logs), err)
+	}
+	// Scripted state is served for every block
+	node.SetBalance(testAddr, big.NewInt(params.Ether))
+	node.SetStorage(testToken, common.Hash{1}, common.Hash{2})
+	if balance, err := ec.BalanceAt(ctx, testAddr, big.NewInt(1)); err != nil || balance.Cmp(big.NewInt(params.Ether)) != 0 {
+		t.Fatalf("balance mismatch: have %v, %v", balance, err)
+	}
+	if value, err := ec.StorageAt(ctx, testToken, common.Hash{1}, nil); err != nil || common.BytesToHash(value) != (common.Hash{2}) {
+		t.Fatalf("storage mismatch: have %x, %v", value, err)
+	}
+	if nonce, err := ec.NonceAt(ctx, testAddr, nil); err != nil || nonce != 1 {
+		t.Fatalf("nonce mismatch: have %d, %v, want 1", nonce, err)
+	}
+	if _, err := ec.BalanceAt(ctx, testAddr, big.NewInt(10)); err == nil {
+		t.Fatal("balance of unknown block returned")
+	}
+	if id, err := ec.NetworkID(ctx); err != nil || id.Cmp(testChainID) != 0 {
+		t.Fatalf("network ID mismatch: have %v, %v", id, err)
+	}
+}
+
+func TestTransactionPool(t *testing.T) {
+	node := New(nil)
+	defer node.Close()
+	ec := node.Client()
+	defer ec.Close()
+	ctx := context.Background()
+
+	tx := signTx(t, 0, params.GWei)
+	if err := ec.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if err := ec.SendTransaction(ctx, tx); err == nil || err.Error() != ErrAlreadyKnown.Error() {
+		t.Fatalf("duplicate error mismatch: have %v, want %v", err, ErrAlreadyKnown)
+	}
+	if err := ec.SendTransaction(ctx, signTx(t, 0, params.GWei+1)); err == nil || err.Error() != ErrReplaceUnderpriced.Error() {
+		t.Fatalf("underpriced error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	replacement := signTx(t, 0, 2*params.GWei)
+	if err := ec.SendTransaction(ctx, replacement); err != nil {
+		t.Fatalf("replacement rejected: %v", err)
+	}
+	if err := ec.SendTransaction(ctx, signTx(t, 1, params.GWei)); err != nil {
+		t.Fatal(err)
+	}
+	if nonce, err := ec.PendingNonceAt(ctx, testAddr); err != nil || nonce != 2 {
+		t.Fatalf("pending nonce mismatch: have %d, %v, want 2", nonce, err)
+	}
+	if _, pending, err := ec.TransactionByHash(ctx, replacement.Hash()); err != nil || !pending {
+		t.Fatalf("pending transaction mismatch: pending %v, err %v", pending, err)
+	}
+	if _, err := ec.TransactionReceipt(ctx, replacement.Hash()); err != client.NotFound {
+		t.Fatalf("pending receipt error mismatch: have %v, want %v", err, client.NotFound)
+	}
+	// Committing includes the pending transactions
+	block := node.Commit()
+	if len(block.Transactions()) != 2 || block.Transactions()[0].Hash() != replacement.Hash() {
+		t.Fatalf("committed transactions mismatch: have %d", len(block.Transactions()))
+	}
+	if len(node.Pending()) != 0 {
+		t.Fatalf("pool not emptied: %d pending", len(node.Pending()))
+	}
+	if err := ec.SendTransaction(ctx, signTx(t, 1, 3*params.GWei)); err == nil || err.Error() != ErrNonceTooLow.Error() {
+		t.Fatalf("stale nonce error mismatch: have %v, want %v", err, ErrNonceTooLow)
+	}
+	if receipt, err := ec.TransactionReceipt(ctx, replacement.Hash()); err != nil || receipt.BlockNumber.Uint64() != 1 {
+		t.Fatalf("receipt mismatch: %v", err)
+	}
+}
+
+func TestSubscriptions(t *testing.T) {
+	node := New(nil)
+	defer node.Close()
+	ec := node.Client()
+	defer ec.Close()
+	ctx := context.Background()
+
+	heads := make(chan *types.Header)
+	headSub, err := ec.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer headSub.Unsubscribe()
+	logs := make(chan types.Log)
+	logSub, err := ec.SubscribeFilterLogs(ctx, client.FilterQuery{Addresses: []common.Address{testToken}}, logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logSub.Unsubscribe()
+
+	tx := signTx(t, 0, params.GWei)
+	block := node.AddBlock(func(b *BlockGen) {
+		b.AddTx(tx, &types.Log{Address: testToken}, &types.Log{Address: testAddr})
+	})
+	select {
+	case head := <-heads:
+		if head.Hash() != block.Hash() {
+			t.Fatalf("head mismatch: have %x, want %x", head.Hash(), block.Hash())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("head not notified")
+	}
+	select {
+	case log := <-logs:
+		if log.Address != testToken || log.BlockHash != block.Hash() || log.Removed {
+			t.Fatalf("log mismatch: %+v", log)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("log not notified")
+	}
+	// Rewinding notifies the removed logs, and new blocks fork off
+	node.SetHead(0)
+	select {
+	case log := <-logs:
+		if log.BlockHash != block.Hash() || !log.Removed {
+			t.Fatalf("removed log mismatch: %+v", log)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("removed log not notified")
+	}
+	fork := node.AddBlock(nil)
+	if fork.Hash() == block.Hash() || fork.NumberU64() != 1 {
+		t.Fatalf("side chain block mismatch: number %d", fork.NumberU64())
+	}
+	if _, err := ec.TransactionReceipt(ctx, tx.Hash()); err != client.NotFound {
+		t.Fatalf("rewound receipt error mismatch: have %v, want %v", err, client.NotFound)
+	}
+}
+
+func TestBoundContract(t *testing.T) {
+	node := New(nil)
+	defer node.Close()
+	ec := node.Client()
+	defer ec.Close()
+
+	parsed, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := bind.NewBoundContract(testToken, parsed, ec, ec, ec)
+
+	// Calls to accounts without code are detected
+	var out []interface{}
+	if err := contract.Call(nil, &out, "balanceOf", testAddr); err != bind.ErrNoCode {
+		t.Fatalf("codeless call error mismatch: have %v, want %v", err, bind.ErrNoCode)
+	}
+	// Canned results are returned for the selector
+	node.SetCode(testToken, []byte{0x60, 0x00})
+	output, _ := parsed.Methods["balanceOf"].Outputs.Pack(big.NewInt(42))
+	node.SetCallResult(testToken, parsed.Methods["balanceOf"].ID, output)
+	if err := contract.Call(nil, &out, "balanceOf", testAddr); err != nil {
+		t.Fatal(err)
+	}
+	if balance := out[0].(*big.Int); balance.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("balance mismatch: have %v, want 42", balance)
+	}
+	// Transactions are sent to the pool, failing estimates are reported
+	opts, _ := bind.NewKeyedTransactorWithChainID(testKey, testChainID)
+	tx, err := contract.Transact(opts, "burn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending := node.Pending(); len(pending) != 1 || pending[0].Hash() != tx.Hash() {
+		t.Fatalf("pending transaction mismatch")
+	}
+	reverted := errors.New("execution reverted")
+	node.SetCallError(testToken, parsed.Methods["burn"].ID, reverted)
+	if _, err := contract.Transact(opts, "burn"); err == nil || !strings.Contains(err.Error(), reverted.Error()) {
+		t.Fatalf("estimate error mismatch: have %v, want %v", err, reverted)
+	}
+}
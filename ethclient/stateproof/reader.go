@@ -0,0 +1,309 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package stateproof implements a chain state reader that verifies the values
+// served by an untrusted RPC endpoint against Merkle proofs.
+package stateproof
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethclient/gethclient"
+	"github.com/simplechain-org/client/ethdb/memorydb"
+	"github.com/simplechain-org/client/rlp"
+	"github.com/simplechain-org/client/trie"
+)
+
+// emptyCodeHash is the code hash of accounts without code.
+var emptyCodeHash = crypto.Keccak256Hash(nil)
+
+var (
+	// ErrUnknownHeader is returned if the state is requested at a block whose
+	// header is not known to the trusted header reader.
+	ErrUnknownHeader = errors.New("unknown header")
+
+	// ErrInvalidAccountProof is returned if the account proof doesn't verify
+	// against the state root of the header.
+	ErrInvalidAccountProof = errors.New("invalid account proof")
+
+	// ErrInvalidStorageProof is returned if a storage proof doesn't verify
+	// against the storage root of the account.
+	ErrInvalidStorageProof = errors.New("invalid storage proof")
+
+	// ErrAccountMismatch is returned if the account fields reported by the
+	// endpoint differ from the proven ones.
+	ErrAccountMismatch = errors.New("account mismatch")
+
+	// ErrStorageMismatch is returned if a storage value reported by the endpoint
+	// differs from the proven one.
+	ErrStorageMismatch = errors.New("storage mismatch")
+
+	// ErrCodeMismatch is returned if the code served by the endpoint doesn't hash
+	// to the proven code hash of the account.
+	ErrCodeMismatch = errors.New("code mismatch")
+)
+
+// HeaderReader provides the trusted headers whose state roots the proofs are
+// verified against, such as a headersync.Store following the chain.
+type HeaderReader interface {
+	CurrentHeader() *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// ProofSource is the subset of the gethclient.Client methods needed to retrieve
+// account and storage proofs from a remote node.
+type ProofSource interface {
+	GetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*gethclient.AccountResult, error)
+}
+
+// CodeSource is the subset of the ethclient.Client methods needed to retrieve the
+// code of contracts from a remote node.
+type CodeSource interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// Reader is a client.ChainStateReader that doesn't trust the remote node: every
+// value is checked against a Merkle proof rooted in a trusted header.
+type Reader struct {
+	proofs  ProofSource
+	code    CodeSource
+	headers HeaderReader
+}
+
+var _ client.ChainStateReader = (*Reader)(nil)
+
+// NewReader creates a state reader fetching proofs and code from the given
+// sources, and verifying them against the headers of the trusted reader.
+func NewReader(proofs ProofSource, code CodeSource, headers HeaderReader) *Reader {
+	return &Reader{
+		proofs:  proofs,
+		code:    code,
+		headers: headers,
+	}
+}
+
+// BalanceAt returns the proven wei balance of the given account.
+// The block number can be nil, in which case the balance is taken from the
+// current trusted header.
+func (r *Reader) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	acc, _, err := r.account(ctx, account, nil, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return acc.Balance, nil
+}
+
+// NonceAt returns the proven nonce of the given account.
+// The block number can be nil, in which case the nonce is taken from the current
+// trusted header.
+func (r *Reader) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	acc, _, err := r.account(ctx, account, nil, blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	return acc.Nonce, nil
+}
+
+// StorageAt returns the proven value of key in the contract storage of the given
+// account, left padded to 32 bytes. The block number can be nil, in which case
+// the value is taken from the current trusted header.
+func (r *Reader) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	_, values, err := r.account(ctx, account, []common.Hash{key}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return common.LeftPadBytes(values[0], common.HashLength), nil
+}
+
+// CodeAt returns the contract code of the given account, checked against the
+// proven code hash. The block number can be nil, in which case the code is taken
+// from the current trusted header.
+func (r *Reader) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	header, err := r.header(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	acc, _, err := r.accountAt(ctx, header, account, nil)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(acc.CodeHash, emptyCodeHash[:]) {
+		return nil, nil
+	}
+	code, err := r.code.CodeAt(ctx, account, header.Number)
+	if err != nil {
+		return nil, err
+	}
+	if hash := crypto.Keccak256(code); !bytes.Equal(hash, acc.CodeHash) {
+		return nil, fmt.Errorf("%w: account %x at #%d: hash %x, proven %x", ErrCodeMismatch, account, header.Number, hash, acc.CodeHash)
+	}
+	return code, nil
+}
+
+// header resolves the trusted header of the given block number, nil meaning the
+// current one.
+func (r *Reader) header(number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	if number == nil {
+		header = r.headers.CurrentHeader()
+	} else if number.Sign() >= 0 && number.IsUint64() {
+		header = r.headers.GetHeaderByNumber(number.Uint64())
+	}
+	if header == nil {
+		return nil, fmt.Errorf("%w: #%v", ErrUnknownHeader, number)
+	}
+	return header, nil
+}
+
+// account retrieves the proven account and storage values at the given block.
+func (r *Reader) account(ctx context.Context, account common.Address, keys []common.Hash, number *big.Int) (*types.StateAccount, [][]byte, error) {
+	header, err := r.header(number)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.accountAt(ctx, header, account, keys)
+}
+
+// accountAt retrieves the proof of an account and some of its storage slots
+// at the given header, verifying it against the header's state root. The
+// returned storage values are stripped of their leading zeroes.
+func (r *Reader) accountAt(ctx context.Context, header *types.Header, account common.Address, keys []common.Hash) (*types.StateAccount, [][]byte, error) {
+	hexkeys := make([]string, len(keys))
+	for i, key := range keys {
+		hexkeys[i] = key.Hex()
+	}
+	// Request the proof at the number of the trusted header, as the endpoint's
+	// notion of the latest block may differ
+	res, err := r.proofs.GetProof(ctx, account, hexkeys, header.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+	acc, err := VerifyAccountProof(header.Root, account, res)
+	if err != nil {
+		return nil, nil, fmt.Errorf("account %x at #%d: %w", account, header.Number, err)
+	}
+	if len(res.StorageProof) != len(keys) {
+		return nil, nil, fmt.Errorf("%w: account %x at #%d: %d proofs for %d keys", ErrInvalidStorageProof, account, header.Number, len(res.StorageProof), len(keys))
+	}
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		if values[i], err = VerifyStorageProof(acc.Root, key, &res.StorageProof[i]); err != nil {
+			return nil, nil, fmt.Errorf("account %x slot %x at #%d: %w", account, key, header.Number, err)
+		}
+	}
+	return acc, values, nil
+}
+
+// VerifyAccountProof verifies the account proof of a GetProof result against a
+// state root, returning the proven account. Non-existent accounts are proven
+// as empty ones. An error is returned if the proof is invalid, or if the
+// account fields of the result differ from the proven ones.
+func VerifyAccountProof(root common.Hash, account common.Address, res *gethclient.AccountResult) (*types.StateAccount, error) {
+	if res.Address != account {
+		return nil, fmt.Errorf("%w: address %x, requested %x", ErrAccountMismatch, res.Address, account)
+	}
+	proof, err := proofDB(res.AccountProof)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAccountProof, err)
+	}
+	blob, err := trie.VerifyProof(root, crypto.Keccak256(account[:]), proof)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAccountProof, err)
+	}
+	acc := &types.StateAccount{
+		Balance:  new(big.Int),
+		Root:     types.EmptyRootHash,
+		CodeHash: emptyCodeHash[:],
+	}
+	if blob != nil {
+		if err := rlp.DecodeBytes(blob, acc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAccountProof, err)
+		}
+	}
+	// Nodes report absent accounts with zero hashes, accept both flavours
+	var (
+		storageHash = res.StorageHash
+		codeHash    = res.CodeHash
+	)
+	if blob == nil && storageHash == (common.Hash{}) {
+		storageHash = types.EmptyRootHash
+	}
+	if blob == nil && codeHash == (common.Hash{}) {
+		codeHash = emptyCodeHash
+	}
+	switch {
+	case res.Balance == nil || res.Balance.Cmp(acc.Balance) != 0:
+		return nil, fmt.Errorf("%w: balance %v, proven %v", ErrAccountMismatch, res.Balance, acc.Balance)
+	case res.Nonce != acc.Nonce:
+		return nil, fmt.Errorf("%w: nonce %d, proven %d", ErrAccountMismatch, res.Nonce, acc.Nonce)
+	case storageHash != acc.Root:
+		return nil, fmt.Errorf("%w: storage hash %x, proven %x", ErrAccountMismatch, res.StorageHash, acc.Root)
+	case !bytes.Equal(codeHash[:], acc.CodeHash):
+		return nil, fmt.Errorf("%w: code hash %x, proven %x", ErrAccountMismatch, res.CodeHash, acc.CodeHash)
+	}
+	return acc, nil
+}
+
+// VerifyStorageProof verifies a storage proof of a GetProof result against the
+// storage root of an account, returning the proven value without its leading
+// zeroes. An error is returned if the proof is for a different key, if it is
+// invalid, or if the value of the result differs from the proven one.
+func VerifyStorageProof(root common.Hash, key common.Hash, res *gethclient.StorageResult) ([]byte, error) {
+	if have, err := hexutil.Decode(res.Key); err != nil || common.BytesToHash(have) != key {
+		return nil, fmt.Errorf("%w: key %s, requested %x", ErrStorageMismatch, res.Key, key)
+	}
+	proof, err := proofDB(res.Proof)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStorageProof, err)
+	}
+	blob, err := trie.VerifyProof(root, crypto.Keccak256(key[:]), proof)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStorageProof, err)
+	}
+	var value []byte
+	if blob != nil {
+		if _, value, _, err = rlp.Split(blob); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStorageProof, err)
+		}
+	}
+	if res.Value == nil || res.Value.Cmp(new(big.Int).SetBytes(value)) != 0 {
+		return nil, fmt.Errorf("%w: value %v, proven %x", ErrStorageMismatch, res.Value, value)
+	}
+	return value, nil
+}
+
+// proofDB decodes the hex encoded nodes of a proof into a database keyed by the
+// node hashes, as expected by trie.VerifyProof.
+func proofDB(nodes []string) (*memorydb.Database, error) {
+	db := memorydb.New()
+	for i, node := range nodes {
+		blob, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, fmt.Errorf("proof node %d: %v", i, err)
+		}
+		db.Put(crypto.Keccak256(blob), blob)
+	}
+	return db, nil
+}
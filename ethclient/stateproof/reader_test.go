@@ -0,0 +1,248 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package stateproof
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethclient/gethclient"
+	"github.com/simplechain-org/client/ethdb/memorydb"
+	"github.com/simplechain-org/client/rlp"
+	"github.com/simplechain-org/client/trie"
+)
+
+var (
+	testContract = common.Address{0x01}
+	testAccount  = common.Address{0x02}
+	testAbsent   = common.Address{0x03}
+	testCode     = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	testSlot     = common.Hash{0x0a}
+	testValue    = big.NewInt(0x1234)
+)
+
+// proofList collects the nodes of a proof in hex encoding.
+type proofList []string
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, hexutil.Encode(value))
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// testerSource serves honest proofs of a small state, which may be tampered with
+// before being returned.
+type testerSource struct {
+	state   *trie.SecureTrie
+	storage *trie.SecureTrie
+	code    []byte
+	tamper  func(res *gethclient.AccountResult)
+}
+
+func newTesterSource(t *testing.T) (*testerSource, common.Hash) {
+	db := trie.NewDatabase(memorydb.New())
+	storage, _ := trie.NewSecure(common.Hash{}, db)
+	blob, _ := rlp.EncodeToBytes(testValue.Bytes())
+	storage.Update(testSlot[:], blob)
+
+	state, _ := trie.NewSecure(common.Hash{}, db)
+	accounts := map[common.Address]*types.StateAccount{
+		testContract: {Nonce: 1, Balance: big.NewInt(0), Root: storage.Hash(), CodeHash: crypto.Keccak256(testCode)},
+		testAccount:  {Nonce: 7, Balance: big.NewInt(1000), Root: types.EmptyRootHash, CodeHash: emptyCodeHash[:]},
+	}
+	for addr, acc := range accounts {
+		if err := state.TryUpdateAccount(addr[:], acc); err != nil {
+			t.Fatalf("failed to insert account: %v", err)
+		}
+	}
+	return &testerSource{state: state, storage: storage, code: testCode}, state.Hash()
+}
+
+func (s *testerSource) GetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*gethclient.AccountResult, error) {
+	res := &gethclient.AccountResult{Address: account, Balance: new(big.Int)}
+	var proof proofList
+	if err := s.state.Prove(crypto.Keccak256(account[:]), 0, &proof); err != nil {
+		return nil, err
+	}
+	res.AccountProof = proof
+
+	acc := &types.StateAccount{Balance: new(big.Int), Root: types.EmptyRootHash, CodeHash: emptyCodeHash[:]}
+	if blob := s.state.Get(account[:]); blob != nil {
+		if err := rlp.DecodeBytes(blob, acc); err != nil {
+			return nil, err
+		}
+	}
+	res.Balance, res.Nonce = acc.Balance, acc.Nonce
+	res.StorageHash, res.CodeHash = acc.Root, common.BytesToHash(acc.CodeHash)
+
+	for _, key := range keys {
+		slot := common.HexToHash(key)
+		value := new(big.Int)
+		proof = nil
+		if account == testContract {
+			if err := s.storage.Prove(crypto.Keccak256(slot[:]), 0, &proof); err != nil {
+				return nil, err
+			}
+			if blob := s.storage.Get(slot[:]); blob != nil {
+				_, content, _, _ := rlp.Split(blob)
+				value.SetBytes(content)
+			}
+		}
+		res.StorageProof = append(res.StorageProof, gethclient.StorageResult{Key: key, Value: value, Proof: proof})
+	}
+	if s.tamper != nil {
+		s.tamper(res)
+	}
+	return res, nil
+}
+
+func (s *testerSource) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	if account == testContract {
+		return s.code, nil
+	}
+	return nil, nil
+}
+
+// testerHeaders is a trusted header reader of a single header.
+type testerHeaders struct {
+	header *types.Header
+}
+
+func (h *testerHeaders) CurrentHeader() *types.Header { return h.header }
+
+func (h *testerHeaders) GetHeaderByNumber(number uint64) *types.Header {
+	if number == h.header.Number.Uint64() {
+		return h.header
+	}
+	return nil
+}
+
+func newTesterReader(t *testing.T) (*Reader, *testerSource) {
+	source, root := newTesterSource(t)
+	headers := &testerHeaders{header: &types.Header{Number: big.NewInt(10), Root: root}}
+	return NewReader(source, source, headers), source
+}
+
+// Tests that honestly served state is proven and returned.
+func TestReaderHonest(t *testing.T) {
+	reader, _ := newTesterReader(t)
+	ctx := context.Background()
+
+	if balance, err := reader.BalanceAt(ctx, testAccount, nil); err != nil || balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("balance mismatch: have %v (%v), want 1000", balance, err)
+	}
+	if nonce, err := reader.NonceAt(ctx, testAccount, big.NewInt(10)); err != nil || nonce != 7 {
+		t.Errorf("nonce mismatch: have %d (%v), want 7", nonce, err)
+	}
+	if value, err := reader.StorageAt(ctx, testContract, testSlot, nil); err != nil || !bytes.Equal(value, common.LeftPadBytes(testValue.Bytes(), 32)) {
+		t.Errorf("storage mismatch: have %x (%v), want %x", value, err, testValue)
+	}
+	if value, err := reader.StorageAt(ctx, testContract, common.Hash{0x0b}, nil); err != nil || !bytes.Equal(value, make([]byte, 32)) {
+		t.Errorf("empty slot mismatch: have %x (%v), want zero", value, err)
+	}
+	if code, err := reader.CodeAt(ctx, testContract, nil); err != nil || !bytes.Equal(code, testCode) {
+		t.Errorf("code mismatch: have %x (%v), want %x", code, err, testCode)
+	}
+	if balance, err := reader.BalanceAt(ctx, testAbsent, nil); err != nil || balance.Sign() != 0 {
+		t.Errorf("absent balance mismatch: have %v (%v), want 0", balance, err)
+	}
+	if _, err := reader.BalanceAt(ctx, testAccount, big.NewInt(11)); !errors.Is(err, ErrUnknownHeader) {
+		t.Errorf("unknown header error mismatch: have %v, want %v", err, ErrUnknownHeader)
+	}
+}
+
+// Tests that tampered responses are rejected with the appropriate errors.
+func TestReaderTampered(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func(source *testerSource, res *gethclient.AccountResult)
+		account common.Address
+		err     error
+	}{
+		{
+			name:    "balance",
+			tamper:  func(_ *testerSource, res *gethclient.AccountResult) { res.Balance = big.NewInt(1001) },
+			account: testAccount,
+			err:     ErrAccountMismatch,
+		},
+		{
+			name:    "absent",
+			tamper:  func(_ *testerSource, res *gethclient.AccountResult) { res.Nonce = 1 },
+			account: testAbsent,
+			err:     ErrAccountMismatch,
+		},
+		{
+			name:    "truncated",
+			tamper:  func(_ *testerSource, res *gethclient.AccountResult) { res.AccountProof = res.AccountProof[:1] },
+			account: testAccount,
+			err:     ErrInvalidAccountProof,
+		},
+		{
+			name: "forged",
+			tamper: func(_ *testerSource, res *gethclient.AccountResult) {
+				res.AccountProof[len(res.AccountProof)-1] = hexutil.Encode([]byte{0xc0})
+			},
+			account: testAccount,
+			err:     ErrInvalidAccountProof,
+		},
+		{
+			name:    "storage",
+			tamper:  func(_ *testerSource, res *gethclient.AccountResult) { res.StorageProof[0].Value = big.NewInt(1) },
+			account: testContract,
+			err:     ErrStorageMismatch,
+		},
+		{
+			name:    "storageProof",
+			tamper:  func(_ *testerSource, res *gethclient.AccountResult) { res.StorageProof[0].Proof = nil },
+			account: testContract,
+			err:     ErrInvalidStorageProof,
+		},
+		{
+			name:    "code",
+			tamper:  func(source *testerSource, _ *gethclient.AccountResult) { source.code = []byte{0x00} },
+			account: testContract,
+			err:     ErrCodeMismatch,
+		},
+	}
+	for _, tt := range tests {
+		reader, source := newTesterReader(t)
+		source.tamper = func(res *gethclient.AccountResult) { tt.tamper(source, res) }
+
+		var err error
+		switch tt.account {
+		case testContract:
+			if _, err = reader.StorageAt(context.Background(), tt.account, testSlot, nil); err == nil {
+				_, err = reader.CodeAt(context.Background(), tt.account, nil)
+			}
+		default:
+			_, err = reader.NonceAt(context.Background(), tt.account, nil)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
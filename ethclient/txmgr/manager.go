@@ -0,0 +1,788 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package txmgr implements a managed transaction sender, which allocates nonces
+// locally, follows the sent transactions until they are confirmed and replaces
+// the stuck ones with fee bumped versions.
+//
+// The state of the transactions in flight is persisted, so a restarted manager
+// resumes following them. Transactions are identified by the hash of their first
+// signed version, which stays their ID across replacements.
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/accounts/abi/bind"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/event"
+	"github.com/simplechain-org/client/log"
+	"github.com/simplechain-org/client/rpc"
+)
+
+const (
+	// minPriceBump is the fee increase, in percent, nodes require by default to
+	// replace a pending transaction.
+	minPriceBump = 10
+
+	// finalCacheLimit is the number of finalized transactions remembered for
+	// Wait after they are removed from the database.
+	finalCacheLimit = 1024
+)
+
+// Error messages of transaction pool rejections nodes return.
+const (
+	nonceTooLowMsg  = "nonce too low"
+	underpricedMsg  = "replacement transaction underpriced"
+	alreadyKnownMsg = "already known"
+)
+
+var (
+	// ErrUnknownTx is returned by Wait for transactions the manager doesn't know.
+	ErrUnknownTx = errors.New("unknown transaction")
+
+	// ErrReverted is returned by Wait for transactions whose execution failed.
+	ErrReverted = errors.New("transaction reverted")
+
+	// ErrDropped is returned by Wait for transactions whose nonce was used by
+	// another transaction.
+	ErrDropped = errors.New("transaction nonce used by another transaction")
+)
+
+// Backend is the subset of the ethclient.Client methods the manager needs to
+// send and follow transactions.
+type Backend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call client.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Config contains the settings of a manager.
+type Config struct {
+	Confirmations    uint64        // Number of blocks, including its own, before a transaction is final
+	ResubmitInterval time.Duration // Time a transaction may stay pending before its fees are bumped
+	PriceBump        uint64        // Percentage the fees are bumped by, at least 10
+	MaxGasPrice      *big.Int      // Cap on the gas price or fee cap of bumped transactions, nil for none
+	PollInterval     time.Duration // Delay between checks of the transactions in flight
+}
+
+// DefaultConfig contains the default settings of a manager.
+var DefaultConfig = Config{
+	Confirmations:    1,
+	ResubmitInterval: time.Minute,
+	PriceBump:        minPriceBump,
+	PollInterval:     5 * time.Second,
+}
+
+// Request describes a transaction to send. Fields left zero are filled in like
+// bind.TransactOpts does: the gas limit is estimated, and fees are suggested by
+// the node, creating a dynamic fee transaction once London is active and a legacy
+// one otherwise or if GasPrice is set.
+type Request struct {
+	From  common.Address
+	To    *common.Address // Nil for contract creations
+	Value *big.Int
+	Data  []byte
+	Gas   uint64
+
+	GasPrice  *big.Int // Gas price of a legacy transaction
+	GasFeeCap *big.Int // Fee cap of a dynamic fee transaction
+	GasTipCap *big.Int // Tip cap of a dynamic fee transaction
+}
+
+// Event announces a status change of a managed transaction.
+type Event struct {
+	ID      common.Hash
+	From    common.Address
+	Nonce   uint64
+	Status  Status
+	Tx      *types.Transaction // Latest signed version, or the included one
+	Receipt *types.Receipt     // Receipt of the included version, if any
+}
+
+// managedTx is a transaction in flight.
+type managedTx struct {
+	record
+	receipt *types.Receipt // Receipt of the included version, if any
+}
+
+// Manager sends transactions on behalf of a set of accounts and follows them until
+// they are final, announcing their status changes.
+type Manager struct {
+	backend Backend
+	signer  bind.SignerFn
+	db      ethdb.KeyValueStore
+	config  Config
+	now     func() time.Time
+
+	lock    sync.Mutex
+	txs     map[common.Hash]*managedTx     // Transactions in flight by ID
+	nonces  map[common.Address]uint64      // Next nonce of the accounts sent from
+	sending map[common.Address]*sync.Mutex // Serializes the sends of each account
+	finals  *lru.Cache                     // Recently finalized transactions, ID -> *Event
+
+	feed  event.Feed
+	scope event.SubscriptionScope
+}
+
+// NewManager creates a manager sending transactions through the backend, signed
+// by the signer, and resumes following the transactions in flight stored in the
+// database.
+func NewManager(backend Backend, signer bind.SignerFn, db ethdb.KeyValueStore, config Config) (*Manager, error) {
+	if config.Confirmations == 0 {
+		config.Confirmations = DefaultConfig.Confirmations
+	}
+	if config.ResubmitInterval <= 0 {
+		config.ResubmitInterval = DefaultConfig.ResubmitInterval
+	}
+	if config.PriceBump < minPriceBump {
+		config.PriceBump = minPriceBump
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultConfig.PollInterval
+	}
+	finals, _ := lru.New(finalCacheLimit)
+	m := &Manager{
+		backend: backend,
+		signer:  signer,
+		db:      db,
+		config:  config,
+		now:     time.Now,
+		txs:     make(map[common.Hash]*managedTx),
+		nonces:  make(map[common.Address]uint64),
+		sending: make(map[common.Address]*sync.Mutex),
+		finals:  finals,
+	}
+	records, err := readRecords(db)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		m.txs[rec.ID] = &managedTx{record: *rec}
+	}
+	if len(records) > 0 {
+		log.Info("Resumed managed transactions", "count", len(records))
+	}
+	return m, nil
+}
+
+// SubscribeEvents subscribes to notifications about status changes of the managed
+// transactions.
+func (m *Manager) SubscribeEvents(ch chan<- *Event) event.Subscription {
+	return m.scope.Track(m.feed.Subscribe(ch))
+}
+
+// Status returns the current status of a transaction, and false if it is neither
+// in flight nor recently finalized.
+func (m *Manager) Status(id common.Hash) (Status, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if tx := m.txs[id]; tx != nil {
+		return tx.Status, true
+	}
+	if ev, ok := m.finals.Get(id); ok {
+		return ev.(*Event).Status, true
+	}
+	return 0, false
+}
+
+// Pending returns the IDs of the transactions in flight.
+func (m *Manager) Pending() []common.Hash {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	ids := make([]common.Hash, 0, len(m.txs))
+	for _, tx := range m.sorted() {
+		ids = append(ids, tx.ID)
+	}
+	return ids
+}
+
+// Send allocates the next nonce of the sender to the requested transaction, signs
+// and submits it, and starts following it. The returned transaction's hash is the
+// ID of the managed transaction. If the node can't be reached, the transaction is
+// queued and submitted again later; if it rejects it, the nonce is released and
+// the error returned.
+//
+// Sends from the same account are serialized, so a released nonce is reused by
+// the next one instead of leaving a gap.
+func (m *Manager) Send(ctx context.Context, req *Request) (*types.Transaction, error) {
+	m.lock.Lock()
+	sending := m.sending[req.From]
+	if sending == nil {
+		sending = new(sync.Mutex)
+		m.sending[req.From] = sending
+	}
+	m.lock.Unlock()
+
+	// Only the nonce allocation holds the manager lock, the round trips to the
+	// node must not stall the checks of the transactions in flight
+	sending.Lock()
+	defer sending.Unlock()
+
+	for resynced := false; ; resynced = true {
+		nonce, err := m.nextNonce(ctx, req.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve account nonce: %w", err)
+		}
+		unsigned, err := m.build(ctx, req, nonce)
+		if err != nil {
+			return nil, err
+		}
+		signed, err := m.signer(req.From, unsigned)
+		if err != nil {
+			return nil, err
+		}
+		tx := &managedTx{record: record{
+			ID:       signed.Hash(),
+			From:     req.From,
+			Nonce:    nonce,
+			Status:   StatusQueued,
+			Attempts: []*types.Transaction{signed},
+		}}
+		// Store the transaction before submitting it, so it isn't lost if the
+		// process dies with the transaction out
+		if err := writeRecord(m.db, &tx.record); err != nil {
+			return nil, err
+		}
+		err = m.submit(ctx, tx, signed)
+		switch {
+		case err == nil:
+			m.track(tx)
+			log.Debug("Sent managed transaction", "id", tx.ID, "from", req.From, "nonce", nonce)
+			return signed, nil
+
+		case isRejection(err):
+			deleteRecord(m.db, tx.ID)
+			if strings.Contains(err.Error(), nonceTooLowMsg) && !resynced {
+				// Someone else sent from the account, resync its nonce
+				log.Debug("Managed nonce too low, resyncing", "from", req.From, "nonce", nonce)
+				m.lock.Lock()
+				delete(m.nonces, req.From)
+				m.lock.Unlock()
+				continue
+			}
+			return nil, err
+
+		default:
+			log.Warn("Failed to submit managed transaction, queued", "id", tx.ID, "err", err)
+			m.track(tx)
+			return signed, nil
+		}
+	}
+}
+
+// track starts following a sent transaction, allocating its nonce.
+func (m *Manager) track(tx *managedTx) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.nonces[tx.From] = tx.Nonce + 1
+	m.txs[tx.ID] = tx
+}
+
+// Wait blocks until a transaction is final, returning the receipt of its included
+// version. Reverted transactions return their receipt along with ErrReverted.
+func (m *Manager) Wait(ctx context.Context, id common.Hash) (*types.Receipt, error) {
+	events := make(chan *Event, 16)
+	sub := m.SubscribeEvents(events)
+	defer sub.Unsubscribe()
+
+	m.lock.Lock()
+	_, inflight := m.txs[id]
+	final, finalized := m.finals.Get(id)
+	m.lock.Unlock()
+
+	switch {
+	case finalized:
+		return final.(*Event).result()
+	case !inflight:
+		return nil, ErrUnknownTx
+	}
+	for {
+		select {
+		case ev := <-events:
+			if ev.ID == id && ev.Status.Final() {
+				return ev.result()
+			}
+		case <-sub.Err():
+			return nil, errors.New("manager stopped")
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// result converts a final event into the return values of Wait.
+func (ev *Event) result() (*types.Receipt, error) {
+	switch ev.Status {
+	case StatusFailed:
+		return ev.Receipt, ErrReverted
+	case StatusDropped:
+		return nil, ErrDropped
+	default:
+		return ev.Receipt, nil
+	}
+}
+
+// Run checks the transactions in flight periodically, until the context is
+// cancelled. Check failures are logged and retried on the next round.
+func (m *Manager) Run(ctx context.Context) error {
+	defer m.scope.Close()
+
+	ticker := time.NewTicker(m.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Check(ctx); err != nil && ctx.Err() == nil {
+			log.Warn("Failed to check managed transactions", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Check refreshes the status of the transactions in flight, announcing changes,
+// resubmitting the queued transactions and replacing the stuck ones.
+func (m *Manager) Check(ctx context.Context) error {
+	m.lock.Lock()
+	events, err := m.check(ctx)
+	m.lock.Unlock()
+
+	// Announce outside the lock, so subscribers may call into the manager
+	for _, ev := range events {
+		m.feed.Send(ev)
+	}
+	return err
+}
+
+func (m *Manager) check(ctx context.Context) ([]*Event, error) {
+	if len(m.txs) == 0 {
+		return nil, nil
+	}
+	head, err := m.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		events []*Event
+		nonces = make(map[common.Address]uint64)
+	)
+	for _, tx := range m.sorted() {
+		ev, err := m.checkTx(ctx, head, tx, nonces)
+		if err != nil {
+			return events, err
+		}
+		if ev != nil {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+// checkTx refreshes the status of a single transaction, returning the event to
+// announce if it changed. The account nonces of the head are cached in nonces.
+func (m *Manager) checkTx(ctx context.Context, head *types.Header, tx *managedTx, nonces map[common.Address]uint64) (*Event, error) {
+	receipt, included, err := m.included(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	// If not included (anymore), find out whether the nonce was used by another
+	// transaction
+	var used bool
+	if receipt == nil {
+		nonce, ok := nonces[tx.From]
+		if !ok {
+			if nonce, err = m.backend.NonceAt(ctx, tx.From, head.Number); err != nil {
+				return nil, err
+			}
+			nonces[tx.From] = nonce
+		}
+		if used = nonce > tx.Nonce; used {
+			// The transaction itself may have been included since the receipt
+			// lookup, look again before declaring it dropped
+			if receipt, included, err = m.included(ctx, tx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if receipt != nil {
+		if head.Number.Uint64()+1 >= receipt.BlockNumber.Uint64()+m.config.Confirmations {
+			status := StatusConfirmed
+			if receipt.Status == types.ReceiptStatusFailed {
+				status = StatusFailed
+			}
+			tx.receipt = receipt
+			return m.finalize(tx, status, included)
+		}
+		if tx.Status == StatusMined && tx.receipt != nil && tx.receipt.BlockHash == receipt.BlockHash {
+			return nil, nil
+		}
+		tx.receipt = receipt
+		return m.update(tx, StatusMined, included)
+	}
+	if used {
+		tx.receipt = nil
+		return m.finalize(tx, StatusDropped, tx.current())
+	}
+	if tx.Status == StatusMined {
+		log.Debug("Managed transaction reorged out", "id", tx.ID, "from", tx.From, "nonce", tx.Nonce)
+		tx.receipt = nil
+		return m.update(tx, StatusPending, tx.current())
+	}
+	if tx.Status == StatusQueued {
+		if err := m.submit(ctx, tx, tx.current()); err != nil {
+			log.Debug("Failed to resubmit queued transaction", "id", tx.ID, "err", err)
+			return nil, nil
+		}
+		return m.event(tx, tx.current()), nil
+	}
+	if m.now().Sub(time.Unix(0, int64(tx.Submitted))) < m.config.ResubmitInterval {
+		return nil, nil
+	}
+	return m.replace(ctx, head, tx)
+}
+
+// included looks up the receipt of the included version of a transaction, newest
+// first. It returns nil if no version is included.
+func (m *Manager) included(ctx context.Context, tx *managedTx) (*types.Receipt, *types.Transaction, error) {
+	for i := len(tx.Attempts) - 1; i >= 0; i-- {
+		receipt, err := m.backend.TransactionReceipt(ctx, tx.Attempts[i].Hash())
+		if errors.Is(err, client.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return receipt, tx.Attempts[i], nil
+	}
+	return nil, nil, nil
+}
+
+// replace signs and submits a fee bumped version of a stuck transaction. If the
+// fees can't be raised below the configured cap, the current version is
+// broadcast again instead.
+func (m *Manager) replace(ctx context.Context, head *types.Header, tx *managedTx) (*Event, error) {
+	prev := tx.current()
+	unsigned, err := m.bumpFees(ctx, head, prev)
+	if err != nil {
+		return nil, err
+	}
+	if unsigned == nil {
+		log.Debug("Managed transaction at fee cap, rebroadcasting", "id", tx.ID, "hash", prev.Hash())
+		return nil, m.submit(ctx, tx, prev)
+	}
+	next, err := m.signer(tx.From, unsigned)
+	if err != nil {
+		return nil, err
+	}
+	err = m.backend.SendTransaction(ctx, next)
+	switch {
+	case err == nil || strings.Contains(err.Error(), alreadyKnownMsg):
+	case strings.Contains(err.Error(), underpricedMsg):
+		// The node holds a pricier version than known, keep the attempt so the
+		// next bump starts from it
+		log.Debug("Managed transaction replacement underpriced", "id", tx.ID, "hash", next.Hash())
+		tx.Attempts = append(tx.Attempts, next)
+		tx.Submitted = uint64(m.now().UnixNano())
+		return nil, writeRecord(m.db, &tx.record)
+	case isRejection(err):
+		// The nonce was likely used meanwhile, the next check finds out
+		log.Debug("Managed transaction replacement rejected", "id", tx.ID, "hash", next.Hash(), "err", err)
+		return nil, nil
+	default:
+		return nil, err
+	}
+	log.Debug("Replaced stuck managed transaction", "id", tx.ID, "prev", prev.Hash(), "hash", next.Hash())
+	tx.Attempts = append(tx.Attempts, next)
+	tx.Submitted = uint64(m.now().UnixNano())
+	return m.update(tx, StatusPending, next)
+}
+
+// submit sends a signed version of a transaction to the node, marking the
+// transaction pending if it is accepted. Versions the node already knows count as
+// accepted.
+func (m *Manager) submit(ctx context.Context, tx *managedTx, signed *types.Transaction) error {
+	err := m.backend.SendTransaction(ctx, signed)
+	if err != nil && !strings.Contains(err.Error(), alreadyKnownMsg) {
+		return err
+	}
+	tx.Status = StatusPending
+	tx.Submitted = uint64(m.now().UnixNano())
+	return writeRecord(m.db, &tx.record)
+}
+
+// update changes the status of a transaction in flight, returning the event to
+// announce.
+func (m *Manager) update(tx *managedTx, status Status, current *types.Transaction) (*Event, error) {
+	tx.Status = status
+	if err := writeRecord(m.db, &tx.record); err != nil {
+		return nil, err
+	}
+	return m.event(tx, current), nil
+}
+
+// finalize concludes a transaction, removing it from the transactions in flight,
+// and returns the event to announce.
+func (m *Manager) finalize(tx *managedTx, status Status, current *types.Transaction) (*Event, error) {
+	if err := deleteRecord(m.db, tx.ID); err != nil {
+		return nil, err
+	}
+	tx.Status = status
+	delete(m.txs, tx.ID)
+
+	ev := m.event(tx, current)
+	m.finals.Add(tx.ID, ev)
+	log.Debug("Managed transaction finalized", "id", tx.ID, "hash", current.Hash(), "status", status)
+	return ev, nil
+}
+
+// event creates the announcement of the current status of a transaction.
+func (m *Manager) event(tx *managedTx, current *types.Transaction) *Event {
+	return &Event{
+		ID:      tx.ID,
+		From:    tx.From,
+		Nonce:   tx.Nonce,
+		Status:  tx.Status,
+		Tx:      current,
+		Receipt: tx.receipt,
+	}
+}
+
+// sorted returns the transactions in flight, ordered by sender and nonce.
+func (m *Manager) sorted() []*managedTx {
+	txs := make([]*managedTx, 0, len(m.txs))
+	for _, tx := range m.txs {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].From != txs[j].From {
+			return bytes.Compare(txs[i].From[:], txs[j].From[:]) < 0
+		}
+		return txs[i].Nonce < txs[j].Nonce
+	})
+	return txs
+}
+
+// nextNonce returns the next nonce to allocate for an account, recovering it from
+// the node and the transactions in flight if the account wasn't sent from yet.
+func (m *Manager) nextNonce(ctx context.Context, from common.Address) (uint64, error) {
+	m.lock.Lock()
+	nonce, ok := m.nonces[from]
+	m.lock.Unlock()
+	if ok {
+		return nonce, nil
+	}
+	nonce, err := m.backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, tx := range m.txs {
+		if tx.From == from && tx.Nonce >= nonce {
+			nonce = tx.Nonce + 1
+		}
+	}
+	return nonce, nil
+}
+
+// build creates the unsigned transaction of a request, filling in the fees and gas
+// limit like bind.BoundContract does.
+func (m *Manager) build(ctx context.Context, req *Request, nonce uint64) (*types.Transaction, error) {
+	if req.GasPrice != nil && (req.GasFeeCap != nil || req.GasTipCap != nil) {
+		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	}
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	head, err := m.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		gasPrice  = req.GasPrice
+		gasFeeCap = req.GasFeeCap
+		gasTipCap = req.GasTipCap
+	)
+	if head.BaseFee != nil && gasPrice == nil {
+		if gasTipCap == nil {
+			if gasTipCap, err = m.backend.SuggestGasTipCap(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if gasFeeCap == nil {
+			gasFeeCap = new(big.Int).Add(gasTipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+		}
+		if gasFeeCap.Cmp(gasTipCap) < 0 {
+			return nil, fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", gasFeeCap, gasTipCap)
+		}
+	} else {
+		if gasFeeCap != nil || gasTipCap != nil {
+			return nil, errors.New("maxFeePerGas or maxPriorityFeePerGas specified but london is not active yet")
+		}
+		if gasPrice == nil {
+			if gasPrice, err = m.backend.SuggestGasPrice(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	gas := req.Gas
+	if gas == 0 {
+		msg := client.CallMsg{From: req.From, To: req.To, GasPrice: gasPrice, GasTipCap: gasTipCap, GasFeeCap: gasFeeCap, Value: value, Data: req.Data}
+		if gas, err = m.backend.EstimateGas(ctx, msg); err != nil {
+			return nil, fmt.Errorf("failed to estimate gas needed: %w", err)
+		}
+	}
+	if gasFeeCap == nil {
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      gas,
+			To:       req.To,
+			Value:    value,
+			Data:     req.Data,
+		}), nil
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       gas,
+		To:        req.To,
+		Value:     value,
+		Data:      req.Data,
+	}), nil
+}
+
+// bumpFees creates a replacement of a transaction with fees raised by at least the
+// configured percentage, and at least to the currently suggested ones. It returns
+// nil if the fees can't be raised enough without exceeding the configured cap.
+func (m *Manager) bumpFees(ctx context.Context, head *types.Header, prev *types.Transaction) (*types.Transaction, error) {
+	if prev.Type() == types.DynamicFeeTxType {
+		tip, err := m.backend.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var (
+			minTip    = m.bump(prev.GasTipCap())
+			minFeeCap = m.bump(prev.GasFeeCap())
+		)
+		tip = bigMax(tip, minTip)
+		feeCap := minFeeCap
+		if head.BaseFee != nil {
+			feeCap = bigMax(feeCap, new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2))))
+		}
+		feeCap = bigMax(feeCap, tip)
+		if limit := m.config.MaxGasPrice; limit != nil && feeCap.Cmp(limit) > 0 {
+			if feeCap = limit; feeCap.Cmp(minFeeCap) < 0 {
+				return nil, nil
+			}
+			if tip.Cmp(feeCap) > 0 {
+				tip = feeCap
+			}
+			if tip.Cmp(minTip) < 0 {
+				return nil, nil
+			}
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    prev.ChainId(),
+			Nonce:      prev.Nonce(),
+			GasTipCap:  tip,
+			GasFeeCap:  feeCap,
+			Gas:        prev.Gas(),
+			To:         prev.To(),
+			Value:      prev.Value(),
+			Data:       prev.Data(),
+			AccessList: prev.AccessList(),
+		}), nil
+	}
+	price, err := m.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	minPrice := m.bump(prev.GasPrice())
+	price = bigMax(price, minPrice)
+	if limit := m.config.MaxGasPrice; limit != nil && price.Cmp(limit) > 0 {
+		if price = limit; price.Cmp(minPrice) < 0 {
+			return nil, nil
+		}
+	}
+	if prev.Type() == types.AccessListTxType {
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    prev.ChainId(),
+			Nonce:      prev.Nonce(),
+			GasPrice:   price,
+			Gas:        prev.Gas(),
+			To:         prev.To(),
+			Value:      prev.Value(),
+			Data:       prev.Data(),
+			AccessList: prev.AccessList(),
+		}), nil
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    prev.Nonce(),
+		GasPrice: price,
+		Gas:      prev.Gas(),
+		To:       prev.To(),
+		Value:    prev.Value(),
+		Data:     prev.Data(),
+	}), nil
+}
+
+// bump raises a price by the configured percentage, rounding up.
+func (m *Manager) bump(price *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(price, new(big.Int).SetUint64(100+m.config.PriceBump))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// isRejection reports whether an error was returned by the node, as opposed to a
+// failure to reach it.
+func isRejection(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+func bigMax(x, y *big.Int) *big.Int {
+	if x.Cmp(y) < 0 {
+		return y
+	}
+	return x
+}
@@ -0,0 +1,411 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client"
+	"github.com/simplechain-org/client/accounts/abi/bind"
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/rawdb"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/crypto"
+	"github.com/simplechain-org/client/ethclient"
+	"github.com/simplechain-org/client/ethclient/mocknode"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/params"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+	testTo     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+)
+
+// offlineBackend is a backend whose transaction submissions can be failed as if
+// the node was unreachable, and whose gas estimations and receipt lookups can be
+// hooked into.
+type offlineBackend struct {
+	*ethclient.Client
+	offline    bool
+	onEstimate func()                 // Called before estimating gas
+	onMissing  func(hash common.Hash) // Called on receipt lookups of unknown transactions
+}
+
+func (b *offlineBackend) EstimateGas(ctx context.Context, call client.CallMsg) (uint64, error) {
+	if b.onEstimate != nil {
+		b.onEstimate()
+	}
+	return b.Client.EstimateGas(ctx, call)
+}
+
+func (b *offlineBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	receipt, err := b.Client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, client.NotFound) && b.onMissing != nil {
+		b.onMissing(hash)
+	}
+	return receipt, err
+}
+
+func (b *offlineBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if b.offline {
+		return errors.New("connection refused")
+	}
+	return b.Client.SendTransaction(ctx, tx)
+}
+
+// testManager bundles a manager with the mock node it sends to.
+type testManager struct {
+	*Manager
+	t       *testing.T
+	node    *mocknode.Node
+	backend *offlineBackend
+	db      ethdb.KeyValueStore
+	clock   time.Time
+	events  chan *Event
+}
+
+func newTestManager(t *testing.T, config Config) *testManager {
+	node := mocknode.New(nil)
+	node.SetGasTip(big.NewInt(params.GWei))
+	node.SetGasEstimate(params.TxGas)
+
+	tm := &testManager{
+		t:       t,
+		node:    node,
+		backend: &offlineBackend{Client: node.Client()},
+		db:      rawdb.NewMemoryDatabase(),
+		clock:   time.Unix(1700000000, 0),
+	}
+	tm.restart(config)
+	return tm
+}
+
+// restart replaces the manager with a new one on the same database.
+func (tm *testManager) restart(config Config) {
+	tm.t.Helper()
+
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, params.TestChainConfig.ChainID)
+	m, err := NewManager(tm.backend, auth.Signer, tm.db, config)
+	if err != nil {
+		tm.t.Fatal(err)
+	}
+	m.now = func() time.Time { return tm.clock }
+	tm.Manager = m
+	tm.events = make(chan *Event, 64)
+	m.SubscribeEvents(tm.events)
+}
+
+func (tm *testManager) close() {
+	tm.backend.Close()
+	tm.node.Close()
+}
+
+func (tm *testManager) send(req *Request) *types.Transaction {
+	tm.t.Helper()
+
+	if req == nil {
+		req = &Request{From: testAddr, To: &testTo, Value: big.NewInt(1)}
+	}
+	tx, err := tm.Send(context.Background(), req)
+	if err != nil {
+		tm.t.Fatal(err)
+	}
+	return tx
+}
+
+func (tm *testManager) check() {
+	tm.t.Helper()
+
+	if err := tm.Check(context.Background()); err != nil {
+		tm.t.Fatal(err)
+	}
+}
+
+// expect checks the next events against the expected statuses and returns them.
+func (tm *testManager) expect(statuses ...Status) []*Event {
+	tm.t.Helper()
+
+	var events []*Event
+	for _, status := range statuses {
+		select {
+		case ev := <-tm.events:
+			if ev.Status != status {
+				tm.t.Fatalf("event status mismatch: have %v, want %v", ev.Status, status)
+			}
+			events = append(events, ev)
+		default:
+			tm.t.Fatalf("missing %v event", status)
+		}
+	}
+	select {
+	case ev := <-tm.events:
+		tm.t.Fatalf("unexpected %v event for %x", ev.Status, ev.ID)
+	default:
+	}
+	return events
+}
+
+func TestSendConfirm(t *testing.T) {
+	tm := newTestManager(t, Config{Confirmations: 2})
+	defer tm.close()
+
+	tx0, tx1 := tm.send(nil), tm.send(nil)
+	if tx0.Nonce() != 0 || tx1.Nonce() != 1 {
+		t.Fatalf("nonce mismatch: have %d, %d", tx0.Nonce(), tx1.Nonce())
+	}
+	if tx0.Type() != types.DynamicFeeTxType {
+		t.Fatalf("transaction type mismatch: have %d", tx0.Type())
+	}
+	if pending := tm.node.Pending(); len(pending) != 2 {
+		t.Fatalf("pending count mismatch: have %d, want 2", len(pending))
+	}
+	tm.check()
+	tm.expect()
+
+	tm.node.Commit()
+	tm.check()
+	tm.expect(StatusMined, StatusMined)
+	tm.check()
+	tm.expect()
+
+	tm.node.AddBlock(nil)
+	tm.check()
+	events := tm.expect(StatusConfirmed, StatusConfirmed)
+	if events[0].ID != tx0.Hash() || events[0].Receipt.TxHash != tx0.Hash() {
+		t.Errorf("event mismatch: id %x, receipt %x", events[0].ID, events[0].Receipt.TxHash)
+	}
+	receipt, err := tm.Wait(context.Background(), tx1.Hash())
+	if err != nil || receipt.TxHash != tx1.Hash() {
+		t.Fatalf("wait mismatch: %v", err)
+	}
+	if len(tm.Pending()) != 0 {
+		t.Fatal("finalized transactions still in flight")
+	}
+	if _, err := tm.Wait(context.Background(), common.Hash{1}); !errors.Is(err, ErrUnknownTx) {
+		t.Fatalf("wait error mismatch: have %v, want %v", err, ErrUnknownTx)
+	}
+}
+
+func TestReplaceStuck(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *Request
+	}{
+		{"legacy", &Request{From: testAddr, To: &testTo, GasPrice: big.NewInt(params.GWei)}},
+		{"dynamic", &Request{From: testAddr, To: &testTo}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := newTestManager(t, Config{ResubmitInterval: time.Minute})
+			defer tm.close()
+
+			tx := tm.send(tt.req)
+			tm.clock = tm.clock.Add(30 * time.Second)
+			tm.check()
+			tm.expect()
+
+			// Stuck for too long, replaced with a pricier version
+			tm.clock = tm.clock.Add(time.Minute)
+			tm.check()
+			next := tm.expect(StatusPending)[0].Tx
+			if next.Hash() == tx.Hash() || next.Nonce() != tx.Nonce() || next.Type() != tx.Type() {
+				t.Fatalf("replacement mismatch: hash %x, nonce %d, type %d", next.Hash(), next.Nonce(), next.Type())
+			}
+			if pending := tm.node.Pending(); len(pending) != 1 || pending[0].Hash() != next.Hash() {
+				t.Fatal("replacement not accepted by the node")
+			}
+			tm.node.Commit()
+			tm.check()
+			ev := tm.expect(StatusConfirmed)[0]
+			if ev.ID != tx.Hash() || ev.Tx.Hash() != next.Hash() || ev.Receipt.TxHash != next.Hash() {
+				t.Fatalf("confirmation mismatch: id %x, tx %x", ev.ID, ev.Tx.Hash())
+			}
+		})
+	}
+}
+
+func TestBumpFees(t *testing.T) {
+	tm := newTestManager(t, Config{MaxGasPrice: big.NewInt(25 * params.GWei)})
+	defer tm.close()
+
+	head := &types.Header{Number: common.Big1, BaseFee: big.NewInt(params.GWei)}
+	legacy := types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(10 * params.GWei), Gas: params.TxGas})
+	dynamic := types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(2 * params.GWei), GasFeeCap: big.NewInt(20 * params.GWei), Gas: params.TxGas})
+
+	bumped, err := tm.bumpFees(context.Background(), head, legacy)
+	if err != nil || bumped.Type() != types.LegacyTxType || bumped.GasPrice().Cmp(big.NewInt(11*params.GWei)) != 0 {
+		t.Fatalf("legacy bump mismatch: %v %v", bumped, err)
+	}
+	bumped, err = tm.bumpFees(context.Background(), head, dynamic)
+	if err != nil || bumped.GasTipCap().Cmp(big.NewInt(2200000000)) != 0 || bumped.GasFeeCap().Cmp(big.NewInt(22*params.GWei)) != 0 {
+		t.Fatalf("dynamic bump mismatch: tip %v, cap %v, %v", bumped.GasTipCap(), bumped.GasFeeCap(), err)
+	}
+	// The suggested tip and base fee take precedence over small bumps
+	tm.node.SetGasTip(big.NewInt(5 * params.GWei))
+	head.BaseFee = big.NewInt(9 * params.GWei)
+	bumped, _ = tm.bumpFees(context.Background(), head, dynamic)
+	if bumped.GasTipCap().Cmp(big.NewInt(5*params.GWei)) != 0 || bumped.GasFeeCap().Cmp(big.NewInt(23*params.GWei)) != 0 {
+		t.Fatalf("market bump mismatch: tip %v, cap %v", bumped.GasTipCap(), bumped.GasFeeCap())
+	}
+	// Beyond the cap, no replacement is possible
+	capped := types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(24 * params.GWei), Gas: params.TxGas})
+	if bumped, err = tm.bumpFees(context.Background(), head, capped); bumped != nil || err != nil {
+		t.Fatalf("capped bump mismatch: %v %v", bumped, err)
+	}
+}
+
+func TestDroppedUnderpriced(t *testing.T) {
+	tm := newTestManager(t, Config{ResubmitInterval: time.Minute})
+	defer tm.close()
+
+	tx := tm.send(nil)
+
+	// Another sender replaces the transaction with a much pricier one
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	other, _ := types.SignNewTx(testKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     tx.Nonce(),
+		GasTipCap: big.NewInt(100 * params.GWei),
+		GasFeeCap: big.NewInt(1000 * params.GWei),
+		Gas:       params.TxGas,
+		To:        &testTo,
+	})
+	if err := tm.node.SendTransaction(other); err != nil {
+		t.Fatal(err)
+	}
+	tm.clock = tm.clock.Add(2 * time.Minute)
+	tm.check()
+	tm.expect()
+
+	tm.node.Commit()
+	tm.check()
+	tm.expect(StatusDropped)
+	if _, err := tm.Wait(context.Background(), tx.Hash()); !errors.Is(err, ErrDropped) {
+		t.Fatalf("wait error mismatch: have %v, want %v", err, ErrDropped)
+	}
+}
+
+// Tests that transactions included between their receipt lookup and the nonce
+// query of a check are not mistaken for dropped ones.
+func TestMinedDuringCheck(t *testing.T) {
+	tm := newTestManager(t, Config{Confirmations: 2})
+	defer tm.close()
+
+	tx := tm.send(nil)
+	tm.backend.onMissing = func(hash common.Hash) {
+		tm.backend.onMissing = nil
+		tm.node.Commit()
+	}
+	tm.check()
+	if ev := tm.expect(StatusMined)[0]; ev.Receipt == nil || ev.Receipt.TxHash != tx.Hash() {
+		t.Fatalf("mined event mismatch: %+v", ev)
+	}
+}
+
+// Tests that the node round trips of a send don't block the other operations of
+// the manager.
+func TestSendUnlocked(t *testing.T) {
+	tm := newTestManager(t, Config{})
+	defer tm.close()
+
+	tx := tm.send(nil)
+
+	estimating, release := make(chan struct{}), make(chan struct{})
+	tm.backend.onEstimate = func() {
+		close(estimating)
+		<-release
+	}
+	sent := make(chan error, 1)
+	go func() {
+		_, err := tm.Send(context.Background(), &Request{From: testAddr, To: &testTo})
+		sent <- err
+	}()
+	<-estimating
+
+	done := make(chan struct{})
+	go func() {
+		tm.Status(tx.Hash())
+		tm.Pending()
+		tm.Check(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("manager blocked by pending send")
+	}
+	close(release)
+	if err := <-sent; err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	if pending := tm.Pending(); len(pending) != 2 {
+		t.Fatalf("pending count mismatch: have %d, want 2", len(pending))
+	}
+}
+
+func TestNonceResync(t *testing.T) {
+	tm := newTestManager(t, Config{})
+	defer tm.close()
+
+	tm.send(nil)
+	tm.node.Commit()
+
+	// Another sender uses the next nonce behind the manager's back
+	signer := types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+	other, _ := types.SignNewTx(testKey, signer, &types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(params.GWei), Gas: params.TxGas, To: &testTo})
+	tm.node.SendTransaction(other)
+	tm.node.Commit()
+
+	if tx := tm.send(nil); tx.Nonce() != 2 {
+		t.Fatalf("nonce mismatch: have %d, want 2", tx.Nonce())
+	}
+}
+
+func TestQueuedResume(t *testing.T) {
+	tm := newTestManager(t, Config{})
+	defer tm.close()
+
+	// Transactions that can't be submitted are queued
+	tm.backend.offline = true
+	tx := tm.send(nil)
+	if status, _ := tm.Status(tx.Hash()); status != StatusQueued {
+		t.Fatalf("status mismatch: have %v, want %v", status, StatusQueued)
+	}
+	// After a restart, they are still known and hold their nonce
+	tm.restart(Config{})
+	tm.backend.offline = false
+	if pending := tm.Pending(); len(pending) != 1 || pending[0] != tx.Hash() {
+		t.Fatalf("resumed transactions mismatch: %v", pending)
+	}
+	if next := tm.send(nil); next.Nonce() != 1 {
+		t.Fatalf("nonce mismatch: have %d, want 1", next.Nonce())
+	}
+	tm.check()
+	ev := tm.expect(StatusPending)[0]
+	if ev.ID != tx.Hash() || len(tm.node.Pending()) != 2 {
+		t.Fatal("queued transaction not submitted")
+	}
+	tm.node.Commit()
+	tm.check()
+	tm.expect(StatusConfirmed, StatusConfirmed)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txmgr
+
+import (
+	"fmt"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/core/types"
+	"github.com/simplechain-org/client/ethdb"
+	"github.com/simplechain-org/client/rlp"
+)
+
+// txPrefix + id (hash) -> RLP encoded record
+var txPrefix = []byte("txmgr-tx-")
+
+// Status is the lifecycle stage of a managed transaction.
+type Status uint8
+
+const (
+	StatusQueued    Status = iota // Signed, but not accepted by the node yet
+	StatusPending                 // Accepted by the node, awaiting inclusion
+	StatusMined                   // Included, awaiting confirmations
+	StatusConfirmed               // Included and confirmed, executed successfully
+	StatusFailed                  // Included and confirmed, but reverted
+	StatusDropped                 // Nonce used by another transaction
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusQueued:
+		return "queued"
+	case StatusPending:
+		return "pending"
+	case StatusMined:
+		return "mined"
+	case StatusConfirmed:
+		return "confirmed"
+	case StatusFailed:
+		return "failed"
+	case StatusDropped:
+		return "dropped"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// Final reports whether the status doesn't change anymore.
+func (s Status) Final() bool {
+	return s >= StatusConfirmed
+}
+
+// record is the persisted state of a managed transaction.
+type record struct {
+	ID        common.Hash          // Hash of the first signed version
+	From      common.Address       // Sender of the transaction
+	Nonce     uint64               // Nonce allocated to the transaction
+	Status    Status               // Current lifecycle stage
+	Attempts  []*types.Transaction // Signed versions sent, the last one being current
+	Submitted uint64               // Time of the last submission, in unix nanoseconds
+}
+
+// current returns the latest signed version of the transaction.
+func (r *record) current() *types.Transaction {
+	return r.Attempts[len(r.Attempts)-1]
+}
+
+func recordKey(id common.Hash) []byte {
+	return append(append([]byte{}, txPrefix...), id.Bytes()...)
+}
+
+// readRecords loads all records stored in the database.
+func readRecords(db ethdb.KeyValueStore) ([]*record, error) {
+	it := db.NewIterator(txPrefix, nil)
+	defer it.Release()
+
+	var records []*record
+	for it.Next() {
+		rec := new(record)
+		if err := rlp.DecodeBytes(it.Value(), rec); err != nil {
+			return nil, fmt.Errorf("invalid transaction record %x: %w", it.Key()[len(txPrefix):], err)
+		}
+		if len(rec.Attempts) == 0 {
+			return nil, fmt.Errorf("invalid transaction record %x: no attempts", it.Key()[len(txPrefix):])
+		}
+		records = append(records, rec)
+	}
+	return records, it.Error()
+}
+
+// writeRecord stores a record in the database.
+func writeRecord(db ethdb.KeyValueWriter, rec *record) error {
+	blob, err := rlp.EncodeToBytes(rec)
+	if err != nil {
+		return err
+	}
+	return db.Put(recordKey(rec.ID), blob)
+}
+
+// deleteRecord removes a record from the database.
+func deleteRecord(db ethdb.KeyValueWriter, id common.Hash) error {
+	return db.Delete(recordKey(id))
+}
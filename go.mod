@@ -28,6 +28,7 @@ require (
 	github.com/status-im/keycard-go v0.3.2
 	github.com/stretchr/testify v1.8.4
 	github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7
+	github.com/tyler-smith/go-bip39 v1.1.0
 	github.com/urfave/cli v1.22.1
 	golang.org/x/crypto v0.16.0
 	golang.org/x/sys v0.15.0
@@ -336,6 +336,8 @@ func (c *EthashConfig) String() string {
 type CliqueConfig struct {
 	Period uint64 `json:"period"` // Number of seconds between blocks to enforce
 	Epoch  uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
+
+	LivenessWindow uint64 `json:"livenessWindow,omitempty"` // Number of recent blocks to track signer liveness over (0 = default)
 }
 
 // String implements the stringer interface, returning the consensus engine details.
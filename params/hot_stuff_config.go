@@ -2,6 +2,12 @@ package params
 
 // HotStuff
 
+// Signature schemes selectable via HotStuffConfig.Crypto.
+const (
+	HotStuffCryptoSecp256k1 = "secp256k1" // Votes are individual secp256k1 signatures (default)
+	HotStuffCryptoBLS       = "bls"       // Votes are aggregated into a single BLS signature
+)
+
 // String implements the stringer interface, returning the impl engine details.
 func (h *HotStuffConfig) String() string {
 	return "hotStuff"
@@ -0,0 +1,357 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultJWTIatSkew  = 60 * time.Second // Default allowed distance of a token's iat from the local time
+	jwtRefreshInterval = 30 * time.Second // Age after which client side tokens are reminted
+	apiKeyHeader       = "X-Api-Key"
+)
+
+var (
+	// ErrMissingCredentials is returned if a request to an authenticated endpoint
+	// carries neither a bearer token nor an API key.
+	ErrMissingCredentials = errors.New("missing credentials")
+
+	// ErrInvalidToken is returned if a bearer token is malformed, not signed with
+	// the configured secret or expired.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrStaleToken is returned if the issued-at time of a bearer token is too far
+	// from the local time.
+	ErrStaleToken = errors.New("stale token")
+
+	// ErrUnknownAPIKey is returned if a request carries an API key that is not
+	// configured.
+	ErrUnknownAPIKey = errors.New("unknown API key")
+
+	// jwtHeader is the only accepted header of bearer tokens.
+	jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+)
+
+// AuthConfig configures the credentials accepted by an authenticated endpoint.
+//
+// Scopes are lists of namespaces (e.g. "eth") or fully qualified methods (e.g.
+// "clique_propose") granted to a caller. A leading '!' denies instead of grants,
+// and "*" grants every method. Denials take precedence; a scope list without any
+// grants allows everything not explicitly denied.
+//
+// The scope claim of a bearer token can only narrow the configured JWTScopes,
+// methods outside of them stay inaccessible regardless of the claim.
+type AuthConfig struct {
+	JWTSecret  []byte              // HS256 secret of bearer tokens, tokens are rejected if empty
+	JWTScopes  []string            // Upper bound of the scopes of bearer tokens
+	MaxIatSkew time.Duration       // Maximum distance of a token's iat from the local time
+	APIKeys    map[string][]string // Static API keys and the scopes granted to them
+}
+
+// Permissions is the parsed form of a scope list.
+type Permissions struct {
+	allow    []string
+	deny     []string
+	bound    *Permissions // upper bound set by the operator, nil if unrestricted
+	identity string       // authenticated caller, empty if anonymous
+}
+
+// NewPermissions parses a scope list.
+func NewPermissions(scopes []string) *Permissions {
+	perms := new(Permissions)
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		switch {
+		case scope == "":
+		case strings.HasPrefix(scope, "!"):
+			perms.deny = append(perms.deny, scope[1:])
+		default:
+			perms.allow = append(perms.allow, scope)
+		}
+	}
+	return perms
+}
+
+// Allowed reports whether the given method may be called.
+func (p *Permissions) Allowed(method string) bool {
+	if p.bound != nil && !p.bound.Allowed(method) {
+		return false
+	}
+	for _, scope := range p.deny {
+		if scopeMatches(scope, method) {
+			return false
+		}
+	}
+	if len(p.allow) == 0 {
+		return true
+	}
+	for _, scope := range p.allow {
+		if scopeMatches(scope, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// Identity returns the authenticated identity of the caller holding the
+// permissions: the subject of a bearer token or a fingerprint of an API key.
+// Bearer tokens without a subject are anonymous.
+func (p *Permissions) Identity() string {
+	return p.identity
+}
+
+// scopeMatches reports whether a single scope covers the given method.
+func scopeMatches(scope, method string) bool {
+	if scope == "*" || scope == method {
+		return true
+	}
+	return strings.HasPrefix(method, scope+serviceMethodSeparator)
+}
+
+type permissionsContextKey struct{}
+
+// PermissionsFromContext returns the permissions of the authenticated caller
+// serving the request, if any.
+func PermissionsFromContext(ctx context.Context) (*Permissions, bool) {
+	perms, ok := ctx.Value(permissionsContextKey{}).(*Permissions)
+	return perms, ok
+}
+
+// permissionedCodec is implemented by codecs of connections that were established
+// through an authenticated handler, and outlive the request context.
+type permissionedCodec interface {
+	permissions() *Permissions
+}
+
+// unauthorizedError is returned for calls outside of the caller's scopes.
+type unauthorizedError struct{ method string }
+
+func (e *unauthorizedError) ErrorCode() int { return -32001 }
+
+func (e *unauthorizedError) Error() string {
+	return fmt.Sprintf("method %s is not permitted", e.method)
+}
+
+// authHandler authenticates requests before passing them to the wrapped handler.
+type authHandler struct {
+	config AuthConfig
+	keys   []string
+	scopes map[string]*Permissions
+	next   http.Handler
+}
+
+// NewAuthHandler wraps an HTTP or WebSocket handler of a server, rejecting requests
+// without a valid HS256 bearer token or API key. Calls on accepted requests are
+// restricted to the scopes of the credentials.
+//
+// Bearer tokens are expected in the Authorization header. API keys are accepted
+// either in the X-Api-Key header, or as bearer tokens.
+func NewAuthHandler(config AuthConfig, next http.Handler) http.Handler {
+	if config.MaxIatSkew == 0 {
+		config.MaxIatSkew = defaultJWTIatSkew
+	}
+	h := &authHandler{
+		config: config,
+		scopes: make(map[string]*Permissions, len(config.APIKeys)),
+		next:   next,
+	}
+	for key, scopes := range config.APIKeys {
+		fingerprint := sha256.Sum256([]byte(key))
+
+		h.keys = append(h.keys, key)
+		h.scopes[key] = NewPermissions(scopes)
+		h.scopes[key].identity = fmt.Sprintf("key:%x", fingerprint[:4])
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	perms, err := h.authenticate(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	ctx := context.WithValue(r.Context(), permissionsContextKey{}, perms)
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// authenticate checks the credentials of a request, returning the permissions
+// granted to them.
+func (h *authHandler) authenticate(r *http.Request) (*Permissions, error) {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return h.lookupKey(key)
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, ErrMissingCredentials
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	if perms, err := h.lookupKey(token); err == nil {
+		return perms, nil
+	}
+	if len(h.config.JWTSecret) == 0 {
+		return nil, ErrUnknownAPIKey
+	}
+	claims, err := parseJWT(h.config.JWTSecret, token, time.Now(), h.config.MaxIatSkew)
+	if err != nil {
+		return nil, err
+	}
+	perms := NewPermissions(h.config.JWTScopes)
+	if claims.Scope != "" {
+		bound := perms
+		perms = NewPermissions(strings.Fields(claims.Scope))
+		perms.bound = bound
+	}
+	if claims.Subject != "" {
+		perms.identity = "jwt:" + claims.Subject
+	}
+	return perms, nil
+}
+
+// lookupKey returns the permissions of a static API key. All configured keys are
+// compared in constant time to avoid leaking their contents.
+func (h *authHandler) lookupKey(key string) (*Permissions, error) {
+	var match string
+	for _, have := range h.keys {
+		if subtle.ConstantTimeCompare([]byte(have), []byte(key)) == 1 {
+			match = have
+		}
+	}
+	if match == "" {
+		return nil, ErrUnknownAPIKey
+	}
+	return h.scopes[match], nil
+}
+
+// jwtClaims are the token claims interpreted by authenticated endpoints.
+type jwtClaims struct {
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+}
+
+// mintJWT creates an HS256 token issued at the given time.
+func mintJWT(secret []byte, iat time.Time, scopes []string) (string, error) {
+	claims, err := json.Marshal(&jwtClaims{IssuedAt: iat.Unix(), Scope: strings.Join(scopes, " ")})
+	if err != nil {
+		return "", err
+	}
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(jwtSignature(secret, unsigned)), nil
+}
+
+// parseJWT verifies an HS256 token and returns its claims.
+func parseJWT(secret []byte, token string, now time.Time, skew time.Duration) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidToken)
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	var head struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(header, &head); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if head.Alg != "HS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, head.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if !hmac.Equal(sig, jwtSignature(secret, parts[0]+"."+parts[1])) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	claims := new(jwtClaims)
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.IssuedAt == 0 {
+		return nil, fmt.Errorf("%w: missing issued-at", ErrInvalidToken)
+	}
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	if diff := now.Sub(time.Unix(claims.IssuedAt, 0)); diff > skew || diff < -skew {
+		return nil, fmt.Errorf("%w: issued-at off by %v", ErrStaleToken, diff)
+	}
+	return claims, nil
+}
+
+func jwtSignature(secret []byte, unsigned string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+// HTTPAuth sets the credentials of outgoing HTTP requests and WebSocket handshakes.
+type HTTPAuth func(header http.Header) error
+
+// NewJWTAuth creates an HTTPAuth presenting HS256 bearer tokens signed with the
+// given secret. Tokens carry the given scopes, and are reminted periodically so
+// their issued-at time stays within the server's allowed skew.
+func NewJWTAuth(secret []byte, scopes ...string) HTTPAuth {
+	var (
+		lock   sync.Mutex
+		token  string
+		minted time.Time
+	)
+	return func(header http.Header) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if now := time.Now(); token == "" || now.Sub(minted) >= jwtRefreshInterval {
+			fresh, err := mintJWT(secret, now, scopes)
+			if err != nil {
+				return err
+			}
+			token, minted = fresh, now
+		}
+		header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// NewAPIKeyAuth creates an HTTPAuth presenting a static API key.
+func NewAPIKeyAuth(key string) HTTPAuth {
+	return func(header http.Header) error {
+		header.Set(apiKeyHeader, key)
+		return nil
+	}
+}
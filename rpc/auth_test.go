@@ -0,0 +1,207 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testJWTSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestPermissions(t *testing.T) {
+	tests := []struct {
+		scopes  []string
+		method  string
+		allowed bool
+	}{
+		{nil, "clique_propose", true},
+		{[]string{"*"}, "clique_propose", true},
+		{[]string{"eth"}, "eth_blockNumber", true},
+		{[]string{"eth"}, "ethx_blockNumber", false},
+		{[]string{"eth"}, "clique_propose", false},
+		{[]string{"eth", "clique_getSigners"}, "clique_getSigners", true},
+		{[]string{"eth", "clique", "!clique_propose"}, "clique_propose", false},
+		{[]string{"eth", "clique", "!clique_propose"}, "clique_discard", true},
+		{[]string{"!clique_propose"}, "eth_call", true},
+		{[]string{"*", "!admin"}, "admin_addPeer", false},
+	}
+	for i, tt := range tests {
+		if have := NewPermissions(tt.scopes).Allowed(tt.method); have != tt.allowed {
+			t.Errorf("test %d: %v allowed %s: have %v, want %v", i, tt.scopes, tt.method, have, tt.allowed)
+		}
+	}
+}
+
+func TestParseJWT(t *testing.T) {
+	now := time.Now()
+	token, err := mintJWT(testJWTSecret, now, []string{"eth"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := parseJWT(testJWTSecret, token, now, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+	if claims.Scope != "eth" || claims.IssuedAt != now.Unix() {
+		t.Errorf("claims mismatch: %+v", claims)
+	}
+	if _, err := parseJWT([]byte("other"), token, now, time.Minute); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign secret error mismatch: have %v, want %v", err, ErrInvalidToken)
+	}
+	if _, err := parseJWT(testJWTSecret, token, now.Add(2*time.Minute), time.Minute); !errors.Is(err, ErrStaleToken) {
+		t.Errorf("old token error mismatch: have %v, want %v", err, ErrStaleToken)
+	}
+	if _, err := parseJWT(testJWTSecret, token, now.Add(-2*time.Minute), time.Minute); !errors.Is(err, ErrStaleToken) {
+		t.Errorf("future token error mismatch: have %v, want %v", err, ErrStaleToken)
+	}
+	if _, err := parseJWT(testJWTSecret, token[:len(token)-2], now, time.Minute); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("truncated token error mismatch: have %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func newTestAuthConfig() AuthConfig {
+	return AuthConfig{
+		JWTSecret: testJWTSecret,
+		APIKeys:   map[string][]string{"reader": {"test", "!test_echo"}},
+	}
+}
+
+func TestAuthHTTP(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Stop()
+	httpsrv := httptest.NewServer(NewAuthHandler(newTestAuthConfig(), srv))
+	defer httpsrv.Close()
+
+	// Unauthenticated requests are rejected before reaching the server
+	client, _ := DialHTTP(httpsrv.URL)
+	err := client.Call(nil, "test_noArgsRets")
+	if httpErr, ok := err.(HTTPError); !ok || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unauthenticated call error mismatch: have %v", err)
+	}
+	client.Close()
+
+	// Stale tokens are rejected
+	stale := func(header http.Header) error {
+		token, err := mintJWT(testJWTSecret, time.Now().Add(-time.Hour), nil)
+		header.Set("Authorization", "Bearer "+token)
+		return err
+	}
+	client, _ = DialHTTPWithAuth(httpsrv.URL, stale)
+	err = client.Call(nil, "test_noArgsRets")
+	if httpErr, ok := err.(HTTPError); !ok || httpErr.StatusCode != http.StatusUnauthorized || !strings.Contains(string(httpErr.Body), ErrStaleToken.Error()) {
+		t.Errorf("stale token error mismatch: have %v", err)
+	}
+	client.Close()
+
+	// Minted tokens are accepted and restricted to their scopes
+	client, _ = DialHTTPWithAuth(httpsrv.URL, NewJWTAuth(testJWTSecret, "test", "!test_returnError"))
+	defer client.Close()
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Errorf("scoped call failed: %v", err)
+	}
+	err = client.Call(nil, "test_returnError")
+	if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != (&unauthorizedError{}).ErrorCode() {
+		t.Errorf("denied call error mismatch: have %v", err)
+	}
+	if err := client.Call(nil, "rpc_modules"); err == nil {
+		t.Errorf("call outside of scopes succeeded")
+	}
+	// API keys are restricted to their configured scopes
+	keyed, _ := DialHTTPWithAuth(httpsrv.URL, NewAPIKeyAuth("reader"))
+	defer keyed.Close()
+	if err := keyed.Call(nil, "test_noArgsRets"); err != nil {
+		t.Errorf("keyed call failed: %v", err)
+	}
+	var resp echoResult
+	if err := keyed.Call(&resp, "test_echo", "x", 1, &echoArgs{"y"}); err == nil {
+		t.Errorf("denied keyed call succeeded")
+	}
+}
+
+// Tests that the scope claim of a bearer token cannot widen the configured
+// token scopes, nor lift their denials.
+func TestAuthScopeBound(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Stop()
+	config := newTestAuthConfig()
+	config.JWTScopes = []string{"test", "!test_returnError"}
+	httpsrv := httptest.NewServer(NewAuthHandler(config, srv))
+	defer httpsrv.Close()
+
+	for _, scopes := range [][]string{nil, {"*"}, {"test", "rpc"}} {
+		client, _ := DialHTTPWithAuth(httpsrv.URL, NewJWTAuth(testJWTSecret, scopes...))
+		if err := client.Call(nil, "test_noArgsRets"); err != nil {
+			t.Errorf("scopes %v: bounded call failed: %v", scopes, err)
+		}
+		err := client.Call(nil, "test_returnError")
+		if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != (&unauthorizedError{}).ErrorCode() {
+			t.Errorf("scopes %v: denied call error mismatch: have %v", scopes, err)
+		}
+		if err := client.Call(nil, "rpc_modules"); err == nil {
+			t.Errorf("scopes %v: call outside of bound succeeded", scopes)
+		}
+		client.Close()
+	}
+	// Claims may still narrow the configured scopes
+	client, _ := DialHTTPWithAuth(httpsrv.URL, NewJWTAuth(testJWTSecret, "test", "!test_noArgsRets"))
+	defer client.Close()
+	if err := client.Call(nil, "test_noArgsRets"); err == nil {
+		t.Errorf("narrowed call succeeded")
+	}
+}
+
+func TestAuthWebsocket(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Stop()
+	httpsrv := httptest.NewServer(NewAuthHandler(newTestAuthConfig(), srv.WebsocketHandler([]string{"*"})))
+	defer httpsrv.Close()
+	wsURL := "ws:" + strings.TrimPrefix(httpsrv.URL, "http:")
+
+	if client, err := DialWebsocket(context.Background(), wsURL, ""); err == nil {
+		client.Close()
+		t.Fatal("unauthenticated handshake succeeded")
+	}
+	client, err := DialWebsocketWithAuth(context.Background(), wsURL, "", NewJWTAuth(testJWTSecret, "nftest"))
+	if err != nil {
+		t.Fatalf("authenticated handshake failed: %v", err)
+	}
+	defer client.Close()
+
+	// Permissions must stick to the connection after the handshake
+	if err := client.Call(nil, "test_noArgsRets"); err == nil {
+		t.Errorf("call outside of scopes succeeded")
+	}
+	ch := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "nftest", ch, "someSubscription", 1, 0)
+	if err != nil {
+		t.Fatalf("scoped subscription failed: %v", err)
+	}
+	defer sub.Unsubscribe()
+	select {
+	case <-ch:
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
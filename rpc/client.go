@@ -83,6 +83,7 @@ type Client struct {
 	idgen    func() ID // for subscriptions
 	scheme   string    // connection type: http, ws or ipc
 	services *serviceRegistry
+	config   *handlerConfig // settings of server side connections, nil for dialed ones
 
 	idCounter uint32
 
@@ -121,7 +122,11 @@ func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	if !c.isHTTP() && c.scheme != "" {
 		ctx = context.WithValue(ctx, "scheme", c.scheme)
 	}
+	if pc, ok := conn.(permissionedCodec); ok && pc.permissions() != nil {
+		ctx = context.WithValue(ctx, permissionsContextKey{}, pc.permissions())
+	}
 	handler := newHandler(ctx, conn, c.idgen, c.services)
+	handler.configure(c.config)
 	return &clientConn{conn, handler}
 }
 
@@ -207,12 +212,12 @@ func newClient(initctx context.Context, connect reconnectFunc) (*Client, error)
 	if err != nil {
 		return nil, err
 	}
-	c := initClient(conn, randomIDGenerator(), new(serviceRegistry))
+	c := initClient(conn, randomIDGenerator(), new(serviceRegistry), nil)
 	c.reconnectFunc = connect
 	return c, nil
 }
 
-func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry) *Client {
+func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry, config *handlerConfig) *Client {
 	scheme := ""
 	switch conn.(type) {
 	case *httpConn:
@@ -226,6 +231,7 @@ func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry) *C
 		idgen:       idgen,
 		scheme:      scheme,
 		services:    services,
+		config:      config,
 		writeConn:   conn,
 		close:       make(chan struct{}),
 		closing:     make(chan struct{}),
@@ -291,6 +297,21 @@ func (c *Client) SetHeader(key, value string) {
 	conn.mu.Unlock()
 }
 
+// SetRequestCompression configures the client to gzip compress request bodies of
+// at least threshold bytes. Only use it with servers accepting compressed requests,
+// such as the ones of this package. A zero threshold disables compression. This
+// method only works for clients using HTTP, it doesn't have any effect for clients
+// using another transport.
+func (c *Client) SetRequestCompression(threshold int) {
+	if !c.isHTTP() {
+		return
+	}
+	conn := c.writeConn.(*httpConn)
+	conn.mu.Lock()
+	conn.compression = threshold
+	conn.mu.Unlock()
+}
+
 // Call performs a JSON-RPC call with the given arguments and unmarshals into
 // result if no error occurred.
 //
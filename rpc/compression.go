@@ -0,0 +1,212 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// defaultCompressionThreshold is the minimum size of HTTP responses and
+	// WebSocket messages which are compressed. Smaller payloads gain too little to
+	// be worth the CPU time.
+	defaultCompressionThreshold = 1024
+
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var (
+	gzipWriterPool = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+	zlibWriterPool = sync.Pool{New: func() interface{} { return zlib.NewWriter(nil) }}
+)
+
+// encoder is a pooled compressor.
+type encoder interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// newEncoder retrieves a compressor of the given content encoding from its pool.
+func newEncoder(encoding string, w io.Writer) encoder {
+	var enc encoder
+	switch encoding {
+	case encodingGzip:
+		enc = gzipWriterPool.Get().(*gzip.Writer)
+	case encodingDeflate:
+		enc = zlibWriterPool.Get().(*zlib.Writer)
+	default:
+		panic("unsupported encoding " + encoding)
+	}
+	enc.Reset(w)
+	return enc
+}
+
+// releaseEncoder closes a compressor and returns it to its pool.
+func releaseEncoder(enc encoder) error {
+	err := enc.Close()
+	switch enc := enc.(type) {
+	case *gzip.Writer:
+		gzipWriterPool.Put(enc)
+	case *zlib.Writer:
+		zlibWriterPool.Put(enc)
+	}
+	return err
+}
+
+// newDecoder wraps a body of the given content encoding into a decompressor. The
+// identity encoding returns the body as is.
+func newDecoder(encoding string, body io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case encodingGzip:
+		return gzip.NewReader(body)
+	case encodingDeflate:
+		return zlib.NewReader(body)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// negotiateEncoding picks the preferred compression accepted by an Accept-Encoding
+// header, returning the empty string if none is acceptable.
+func negotiateEncoding(accept string) string {
+	var (
+		best    string
+		bestQ   float64
+		options = strings.Split(accept, ",")
+	)
+	for _, option := range options {
+		parts := strings.Split(option, ";")
+		encoding := strings.ToLower(strings.TrimSpace(parts[0]))
+		if encoding != encodingGzip && encoding != encodingDeflate {
+			continue
+		}
+		q := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		// Prefer gzip on ties, it's what most clients expect
+		if q > bestQ || (q == bestQ && encoding == encodingGzip) {
+			best, bestQ = encoding, q
+		}
+	}
+	if bestQ <= 0 {
+		return ""
+	}
+	return best
+}
+
+// compressionWriter is an http.ResponseWriter compressing the response body if it
+// reaches the size threshold. Smaller bodies are buffered and sent uncompressed
+// when the writer is closed.
+type compressionWriter struct {
+	http.ResponseWriter
+	encoding  string
+	threshold int
+
+	status  int
+	buffer  []byte
+	encoder encoder // set once the threshold is reached
+}
+
+func newCompressionWriter(w http.ResponseWriter, encoding string, threshold int) *compressionWriter {
+	w.Header().Add("Vary", "Accept-Encoding")
+	return &compressionWriter{ResponseWriter: w, encoding: encoding, threshold: threshold}
+}
+
+// WriteHeader defers sending the status code until the encoding is decided.
+func (w *compressionWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// Write buffers the body until the threshold is reached, and compresses it from
+// then on.
+func (w *compressionWriter) Write(p []byte) (int, error) {
+	if w.encoder != nil {
+		return w.encoder.Write(p)
+	}
+	w.buffer = append(w.buffer, p...)
+	if len(w.buffer) < w.threshold {
+		return len(p), nil
+	}
+	header := w.Header()
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	w.writeHeader()
+
+	w.encoder = newEncoder(w.encoding, w.ResponseWriter)
+	if _, err := w.encoder.Write(w.buffer); err != nil {
+		return 0, err
+	}
+	w.buffer = nil
+	return len(p), nil
+}
+
+// Close flushes the response, uncompressed if it stayed below the threshold.
+func (w *compressionWriter) Close() error {
+	if w.encoder != nil {
+		return releaseEncoder(w.encoder)
+	}
+	w.writeHeader()
+	_, err := w.ResponseWriter.Write(w.buffer)
+	return err
+}
+
+func (w *compressionWriter) writeHeader() {
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+// compress encodes a payload with the given content encoding.
+func compress(encoding string, payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := newEncoder(encoding, &buf)
+	if _, err := enc.Write(payload); err != nil {
+		releaseEncoder(enc)
+		return nil, err
+	}
+	if err := releaseEncoder(enc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressedBody is a decompressed HTTP body, closing the original one.
+type decompressedBody struct {
+	io.Reader
+	body io.Closer
+}
+
+func (b *decompressedBody) Close() error {
+	return b.body.Close()
+}
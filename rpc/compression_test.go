@@ -0,0 +1,257 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// encodingRecorder is an HTTP handler recording the content encodings of the
+// requests and responses it serves.
+type encodingRecorder struct {
+	handler http.Handler
+
+	lock     sync.Mutex
+	request  string
+	response string
+}
+
+func (r *encodingRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.handler.ServeHTTP(w, req)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.request = req.Header.Get("content-encoding")
+	r.response = w.Header().Get("content-encoding")
+}
+
+func (r *encodingRecorder) encodings() (string, string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.request, r.response
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"deflate, gzip", "gzip"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"br, DEFLATE;q=0.8", "deflate"},
+		{"gzip;q=0", ""},
+	}
+	for _, test := range tests {
+		if have := negotiateEncoding(test.accept); have != test.want {
+			t.Errorf("encoding mismatch for %q: have %q, want %q", test.accept, have, test.want)
+		}
+	}
+}
+
+func TestHTTPResponseCompression(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	recorder := &encodingRecorder{handler: server}
+	httpsrv := httptest.NewServer(recorder)
+	defer httpsrv.Close()
+	client, _ := DialHTTP(httpsrv.URL)
+	defer client.Close()
+
+	// Responses below the threshold are sent as is
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "small", 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, enc := recorder.encodings(); enc != "" {
+		t.Errorf("small response encoding mismatch: have %q, want none", enc)
+	}
+	// Larger ones are compressed and decoded by the client
+	large := strings.Repeat("x", 4*defaultCompressionThreshold)
+	if err := client.Call(&result, "test_echo", large, 2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, enc := recorder.encodings(); enc != encodingGzip {
+		t.Errorf("large response encoding mismatch: have %q, want %q", enc, encodingGzip)
+	}
+	if result.String != large || result.Int != 2 {
+		t.Errorf("large response mismatch: have %d bytes, int %d", len(result.String), result.Int)
+	}
+	// Compression can be disabled
+	server.SetHTTPCompression(0)
+	if err := client.Call(&result, "test_echo", large, 3, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, enc := recorder.encodings(); enc != "" {
+		t.Errorf("disabled compression encoding mismatch: have %q, want none", enc)
+	}
+}
+
+func TestHTTPDeflateResponse(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	large := strings.Repeat("x", 4*defaultCompressionThreshold)
+	body, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0", "id": 1, "method": "test_echo", "params": []interface{}{large, 1, nil},
+	})
+	req, _ := http.NewRequest("POST", httpsrv.URL, bytes.NewReader(body))
+	req.Header.Set("content-type", contentType)
+	req.Header.Set("accept-encoding", "deflate")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if enc := resp.Header.Get("content-encoding"); enc != encodingDeflate {
+		t.Fatalf("response encoding mismatch: have %q, want %q", enc, encodingDeflate)
+	}
+	if vary := resp.Header.Get("vary"); vary != "Accept-Encoding" {
+		t.Errorf("vary header mismatch: have %q", vary)
+	}
+	reader, err := zlib.NewReader(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg struct{ Result echoResult }
+	if err := json.Unmarshal(blob, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Result.String != large {
+		t.Errorf("decoded response mismatch: have %d bytes", len(msg.Result.String))
+	}
+}
+
+func TestHTTPRequestCompression(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	recorder := &encodingRecorder{handler: server}
+	httpsrv := httptest.NewServer(recorder)
+	defer httpsrv.Close()
+	client, _ := DialHTTP(httpsrv.URL)
+	defer client.Close()
+
+	client.SetRequestCompression(defaultCompressionThreshold)
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "small", 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if enc, _ := recorder.encodings(); enc != "" {
+		t.Errorf("small request encoding mismatch: have %q, want none", enc)
+	}
+	large := strings.Repeat("y", 4*defaultCompressionThreshold)
+	if err := client.Call(&result, "test_echo", large, 2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if enc, _ := recorder.encodings(); enc != encodingGzip {
+		t.Errorf("large request encoding mismatch: have %q, want %q", enc, encodingGzip)
+	}
+	if result.String != large {
+		t.Errorf("large request echo mismatch: have %d bytes", len(result.String))
+	}
+}
+
+func TestHTTPUnsupportedRequestEncoding(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	req, _ := http.NewRequest("POST", httpsrv.URL, strings.NewReader(`{}`))
+	req.Header.Set("content-type", contentType)
+	req.Header.Set("content-encoding", "br")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("response status mismatch: have %d, want %d", resp.StatusCode, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestWebsocketCompression(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	defer httpsrv.Close()
+	wsURL := "ws:" + strings.TrimPrefix(httpsrv.URL, "http:")
+
+	// The default dialer negotiates permessage-deflate
+	dialer := defaultWebsocketDialer()
+	conn, resp, err := dialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if ext := resp.Header.Get("Sec-Websocket-Extensions"); !strings.Contains(ext, "permessage-deflate") {
+		t.Errorf("extension mismatch: have %q, want permessage-deflate", ext)
+	}
+	// Compressed and uncompressed messages are both served
+	client, err := DialWebsocket(context.Background(), wsURL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for _, str := range []string{"small", strings.Repeat("z", 4*defaultCompressionThreshold)} {
+		var result echoResult
+		if err := client.Call(&result, "test_echo", str, 1, nil); err != nil {
+			t.Fatal(err)
+		}
+		if result.String != str {
+			t.Errorf("echo mismatch: have %d bytes, want %d", len(result.String), len(str))
+		}
+	}
+	// Peers without compression support are served too
+	plain := websocket.Dialer{}
+	client, err = DialWebsocketWithDialer(context.Background(), wsURL, "", plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	large := strings.Repeat("z", 4*defaultCompressionThreshold)
+	var result echoResult
+	if err := client.Call(&result, "test_echo", large, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if result.String != large {
+		t.Errorf("uncompressed echo mismatch: have %d bytes", len(result.String))
+	}
+}
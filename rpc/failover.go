@@ -0,0 +1,689 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/simplechain-org/client/log"
+)
+
+const (
+	defaultHealthInterval = 15 * time.Second
+	defaultHealthTimeout  = 5 * time.Second
+	defaultHealthMethod   = "eth_blockNumber"
+
+	// Quorum reads without a deadline give up on the endpoints not agreeing
+	// within this time.
+	defaultQuorumTimeout = 30 * time.Second
+
+	// Subscriptions whose endpoint failed are moved over to another one, retrying
+	// with exponential backoff up to this delay.
+	maxResubscribeBackoff = 30 * time.Second
+)
+
+var errNoEndpoints = errors.New("no RPC endpoints")
+
+// DefaultQuorumMethods are the methods read in quorum if enabled, unless
+// configured otherwise.
+var DefaultQuorumMethods = []string{
+	"eth_chainId",
+	"eth_getBalance",
+	"eth_getTransactionCount",
+	"eth_getStorageAt",
+	"eth_getCode",
+	"eth_getProof",
+	"eth_call",
+	"eth_getBlockByHash",
+	"eth_getBlockByNumber",
+	"eth_getTransactionByHash",
+	"eth_getTransactionReceipt",
+	"eth_getLogs",
+}
+
+// FailoverConfig configures a client spreading its requests over several endpoints.
+type FailoverConfig struct {
+	HealthInterval time.Duration // Interval between endpoint health checks (default = 15s)
+	HealthTimeout  time.Duration // Timeout of a single health check (default = 5s)
+	HealthMethod   string        // Method called to check endpoint health (default = eth_blockNumber)
+
+	// Quorum is the number of endpoints that must return the same response for
+	// a quorum read to succeed. Values below two disable quorum reads.
+	Quorum int
+
+	// QuorumMethods are the methods read in quorum (default = DefaultQuorumMethods).
+	// Note that responses have to be identical, so reads relative to the latest
+	// block only succeed if enough endpoints are in sync.
+	QuorumMethods []string
+}
+
+// quorumError is returned if not enough endpoints agreed on a quorum read.
+type quorumError struct {
+	method    string
+	quorum    int
+	endpoints int
+}
+
+func (e *quorumError) ErrorCode() int { return defaultErrorCode }
+
+func (e *quorumError) Error() string {
+	return fmt.Sprintf("no quorum of %d among %d endpoints for %s", e.quorum, e.endpoints, e.method)
+}
+
+// DialFailover creates a client spreading its requests over the given endpoints.
+// Endpoints that can't be dialed are skipped, as long as enough remain to reach
+// the configured quorum.
+//
+// Calls are routed to the healthy endpoint with the lowest latency, failing over
+// to the next one on transport errors. Subscriptions stick to the endpoint they
+// were created on for as long as it works, and are moved over to another one if
+// it fails. Notifications may be lost or duplicated during the move.
+//
+// The returned client is a regular *Client, so it can be passed to the typed
+// API wrappers such as ethclient.NewClient.
+func DialFailover(ctx context.Context, urls []string, config FailoverConfig) (*Client, error) {
+	var (
+		clients []*Client
+		names   []string
+	)
+	for _, url := range urls {
+		c, err := DialContext(ctx, url)
+		if err != nil {
+			log.Warn("Failed to dial RPC endpoint", "url", url, "err", err)
+			continue
+		}
+		clients = append(clients, c)
+		names = append(names, url)
+	}
+	if len(clients) == 0 || len(clients) < config.Quorum {
+		for _, c := range clients {
+			c.Close()
+		}
+		return nil, fmt.Errorf("%w: dialed %d of %d endpoints, quorum %d", errNoEndpoints, len(clients), len(urls), config.Quorum)
+	}
+	return newFailoverClient(clients, names, config), nil
+}
+
+// NewFailoverClient creates a client spreading its requests over the given
+// clients, as DialFailover does. The clients are closed along with the returned
+// one.
+func NewFailoverClient(clients []*Client, config FailoverConfig) (*Client, error) {
+	if len(clients) == 0 || len(clients) < config.Quorum {
+		return nil, fmt.Errorf("%w: %d endpoints, quorum %d", errNoEndpoints, len(clients), config.Quorum)
+	}
+	names := make([]string, len(clients))
+	for i := range clients {
+		names[i] = strconv.Itoa(i)
+	}
+	return newFailoverClient(clients, names, config), nil
+}
+
+func newFailoverClient(clients []*Client, names []string, config FailoverConfig) *Client {
+	if config.HealthInterval <= 0 {
+		config.HealthInterval = defaultHealthInterval
+	}
+	if config.HealthTimeout <= 0 {
+		config.HealthTimeout = defaultHealthTimeout
+	}
+	if config.HealthMethod == "" {
+		config.HealthMethod = defaultHealthMethod
+	}
+	if config.QuorumMethods == nil {
+		config.QuorumMethods = DefaultQuorumMethods
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	fc := &failoverCodec{
+		config:  config,
+		quorum:  make(map[string]bool),
+		subs:    make(map[ID]*failoverSub),
+		out:     make(chan readOp),
+		closeCh: make(chan interface{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for _, method := range config.QuorumMethods {
+		fc.quorum[method] = true
+	}
+	for i, c := range clients {
+		fc.endpoints = append(fc.endpoints, &failoverEndpoint{client: c, name: names[i], healthy: true})
+	}
+	fc.wg.Add(1)
+	go fc.healthLoop()
+
+	return initClient(fc, randomIDGenerator(), new(serviceRegistry), nil)
+}
+
+// failoverEndpoint is a backend endpoint along with its health.
+type failoverEndpoint struct {
+	client *Client
+	name   string
+
+	healthy bool          // Whether the last request was answered
+	latency time.Duration // Moving average of the response times
+	lock    sync.Mutex
+}
+
+// call performs a call on the endpoint, updating its health. Missing and null
+// results are both returned as null.
+func (ep *failoverEndpoint) call(ctx context.Context, method string, args []interface{}) (json.RawMessage, error) {
+	var (
+		result json.RawMessage
+		start  = time.Now()
+	)
+	err := ep.client.CallContext(ctx, &result, method, args...)
+	if err == ErrNoResult || (err == nil && len(result) == 0) {
+		result, err = null, nil // Null results are decoded as no result at all
+	}
+	if err == nil || !isTransportError(err) {
+		ep.succeeded(time.Since(start))
+	} else if ctx.Err() == nil {
+		ep.failed(err)
+	}
+	return result, err
+}
+
+// succeeded marks the endpoint healthy, accounting for the latency of a response.
+func (ep *failoverEndpoint) succeeded(latency time.Duration) {
+	ep.lock.Lock()
+	defer ep.lock.Unlock()
+
+	if !ep.healthy {
+		log.Info("RPC endpoint recovered", "endpoint", ep.name)
+	}
+	ep.healthy = true
+	if ep.latency == 0 {
+		ep.latency = latency
+	} else {
+		ep.latency = (4*ep.latency + latency) / 5
+	}
+}
+
+// failed marks the endpoint unhealthy.
+func (ep *failoverEndpoint) failed(err error) {
+	ep.lock.Lock()
+	defer ep.lock.Unlock()
+
+	if ep.healthy {
+		log.Warn("RPC endpoint failed", "endpoint", ep.name, "err", err)
+	}
+	ep.healthy = false
+}
+
+// isTransportError reports whether an error was caused by the connection to an
+// endpoint, as opposed to an error response of the endpoint.
+func isTransportError(err error) bool {
+	_, ok := err.(Error)
+	return !ok
+}
+
+// failoverSub is a subscription of the client, backed by a subscription on one
+// of the endpoints.
+type failoverSub struct {
+	id        ID
+	namespace string
+	args      []interface{}
+	quit      chan struct{}
+
+	// Only accessed by the forwarding loop once started
+	endpoint *failoverEndpoint
+	sub      *ClientSubscription
+}
+
+// failoverCodec is a connection spreading the messages of a client over several
+// endpoints. Requests are handled asynchronously, their responses being fed back
+// to the client through readBatch.
+type failoverCodec struct {
+	config    FailoverConfig
+	endpoints []*failoverEndpoint
+	quorum    map[string]bool // Methods read in quorum
+
+	subs     map[ID]*failoverSub
+	subsLock sync.Mutex // Also held to close closeCh, guarding additions to wg
+
+	out       chan readOp // Responses and notifications for the client
+	closeCh   chan interface{}
+	closeOnce sync.Once
+	ctx       context.Context // Cancelled on close, for requests outliving their caller
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func (fc *failoverCodec) remoteAddr() string {
+	return "failover"
+}
+
+func (fc *failoverCodec) closed() <-chan interface{} {
+	return fc.closeCh
+}
+
+func (fc *failoverCodec) close() {
+	fc.closeOnce.Do(func() {
+		fc.subsLock.Lock()
+		close(fc.closeCh)
+		fc.subsLock.Unlock()
+
+		fc.cancel()
+		fc.wg.Wait()
+		for _, ep := range fc.endpoints {
+			ep.client.Close()
+		}
+	})
+}
+
+func (fc *failoverCodec) readBatch() ([]*jsonrpcMessage, bool, error) {
+	select {
+	case op := <-fc.out:
+		return op.msgs, op.batch, nil
+	case <-fc.closeCh:
+		return nil, false, io.EOF
+	}
+}
+
+// writeJSON dispatches the messages of the client to the endpoints. It doesn't
+// wait for the responses, which are delivered through readBatch.
+func (fc *failoverCodec) writeJSON(ctx context.Context, v interface{}) error {
+	select {
+	case <-fc.closeCh:
+		return ErrClientQuit
+	default:
+	}
+	switch msg := v.(type) {
+	case *jsonrpcMessage:
+		go func() {
+			if resp := fc.handle(ctx, msg); resp != nil {
+				fc.deliver([]*jsonrpcMessage{resp}, false)
+			}
+		}()
+	case []*jsonrpcMessage:
+		go func() {
+			var (
+				resps = make([]*jsonrpcMessage, len(msg))
+				wg    sync.WaitGroup
+			)
+			for i := range msg {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					resps[i] = fc.handle(ctx, msg[i])
+				}(i)
+			}
+			wg.Wait()
+
+			batch := resps[:0]
+			for _, resp := range resps {
+				if resp != nil {
+					batch = append(batch, resp)
+				}
+			}
+			if len(batch) > 0 {
+				fc.deliver(batch, true)
+			}
+		}()
+	default:
+		return fmt.Errorf("unsupported message type %T", v)
+	}
+	return nil
+}
+
+// deliver feeds messages back to the client.
+func (fc *failoverCodec) deliver(msgs []*jsonrpcMessage, batch bool) {
+	select {
+	case fc.out <- readOp{msgs: msgs, batch: batch}:
+	case <-fc.closeCh:
+	}
+}
+
+// handle processes a single message of the client, returning the response to
+// deliver, if any.
+func (fc *failoverCodec) handle(ctx context.Context, msg *jsonrpcMessage) *jsonrpcMessage {
+	args, err := callArgs(msg)
+	switch {
+	case msg.isNotification():
+		if err == nil {
+			fc.notify(msg.Method, args)
+		}
+		return nil
+	case !msg.isCall():
+		return nil // Responses to callbacks, which endpoints don't make
+	case err != nil:
+		return msg.errorResponse(err)
+	case msg.isSubscribe():
+		return fc.subscribe(ctx, msg, args)
+	case msg.isUnsubscribe():
+		return fc.unsubscribe(msg, args)
+	case fc.config.Quorum > 1 && fc.quorum[msg.Method]:
+		return fc.callQuorum(ctx, msg, args)
+	default:
+		return fc.call(ctx, msg, args)
+	}
+}
+
+// callArgs splits the positional parameters of a message into call arguments.
+func callArgs(msg *jsonrpcMessage) ([]interface{}, error) {
+	if len(msg.Params) == 0 {
+		return nil, nil
+	}
+	var params []json.RawMessage
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return nil, &invalidParamsError{"non-array args"}
+	}
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = param
+	}
+	return args, nil
+}
+
+// route returns the endpoints in order of preference: the healthy ones first,
+// by ascending latency, then the unhealthy ones as a last resort.
+func (fc *failoverCodec) route() []*failoverEndpoint {
+	type entry struct {
+		ep      *failoverEndpoint
+		healthy bool
+		latency time.Duration
+	}
+	entries := make([]entry, len(fc.endpoints))
+	for i, ep := range fc.endpoints {
+		ep.lock.Lock()
+		entries[i] = entry{ep, ep.healthy, ep.latency}
+		ep.lock.Unlock()
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].healthy != entries[j].healthy {
+			return entries[i].healthy
+		}
+		return entries[i].latency < entries[j].latency
+	})
+	endpoints := make([]*failoverEndpoint, len(entries))
+	for i, entry := range entries {
+		endpoints[i] = entry.ep
+	}
+	return endpoints
+}
+
+// call forwards a call to the preferred endpoint, failing over to the next one on
+// transport errors. Error responses of an endpoint are returned as they are.
+func (fc *failoverCodec) call(ctx context.Context, msg *jsonrpcMessage, args []interface{}) *jsonrpcMessage {
+	var err error
+	for _, ep := range fc.route() {
+		var result json.RawMessage
+		if result, err = ep.call(ctx, msg.Method, args); err == nil {
+			return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: result}
+		}
+		if !isTransportError(err) || ctx.Err() != nil {
+			break
+		}
+		log.Debug("Failing over RPC call", "method", msg.Method, "endpoint", ep.name, "err", err)
+	}
+	return msg.errorResponse(err)
+}
+
+// callQuorum forwards a call to all endpoints, returning the first response
+// returned by enough of them. It gives up as soon as the quorum can't be reached
+// anymore, or after defaultQuorumTimeout if the context has no deadline.
+func (fc *failoverCodec) callQuorum(ctx context.Context, msg *jsonrpcMessage, args []interface{}) *jsonrpcMessage {
+	var cancel context.CancelFunc
+	if _, ok := ctx.Deadline(); ok {
+		ctx, cancel = context.WithCancel(ctx)
+	} else {
+		ctx, cancel = context.WithTimeout(ctx, defaultQuorumTimeout)
+	}
+	defer cancel()
+
+	type reply struct {
+		key  string // Response identity, empty for transport errors
+		resp *jsonrpcMessage
+	}
+	endpoints := fc.route()
+	replies := make(chan reply, len(endpoints))
+	for _, ep := range endpoints {
+		go func(ep *failoverEndpoint) {
+			result, err := ep.call(ctx, msg.Method, args)
+			switch {
+			case err == nil:
+				var buf bytes.Buffer
+				if json.Compact(&buf, result) != nil {
+					buf.Write(result)
+				}
+				replies <- reply{"result:" + buf.String(), &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: result}}
+			case isTransportError(err):
+				replies <- reply{}
+			default:
+				resp := msg.errorResponse(err)
+				replies <- reply{fmt.Sprintf("error:%d:%s", resp.Error.Code, resp.Error.Message), resp}
+			}
+		}(ep)
+	}
+	var (
+		counts = make(map[string]int)
+		best   int // Most endpoints agreeing on a response so far
+	)
+	for pending := len(endpoints); pending > 0; pending-- {
+		// Stop waiting once even unanimous remaining replies can't make a quorum
+		if best+pending < fc.config.Quorum {
+			break
+		}
+		var reply reply
+		select {
+		case reply = <-replies:
+		case <-ctx.Done():
+			return msg.errorResponse(ctx.Err())
+		}
+		if reply.key == "" {
+			continue
+		}
+		if counts[reply.key]++; counts[reply.key] >= fc.config.Quorum {
+			return reply.resp
+		}
+		if counts[reply.key] > best {
+			best = counts[reply.key]
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return msg.errorResponse(err)
+	}
+	return msg.errorResponse(&quorumError{msg.Method, fc.config.Quorum, len(endpoints)})
+}
+
+// notify forwards a notification to the preferred endpoint, failing over to the
+// next one on transport errors.
+func (fc *failoverCodec) notify(method string, args []interface{}) {
+	ctx, cancel := context.WithTimeout(fc.ctx, defaultWriteTimeout)
+	defer cancel()
+
+	for _, ep := range fc.route() {
+		err := ep.client.Notify(ctx, method, args...)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		ep.failed(err)
+	}
+}
+
+// subscribe creates a subscription on the preferred endpoint supporting them. The
+// response is delivered directly, before any notification.
+func (fc *failoverCodec) subscribe(ctx context.Context, msg *jsonrpcMessage, args []interface{}) *jsonrpcMessage {
+	fs := &failoverSub{
+		id:        NewID(),
+		namespace: msg.namespace(),
+		args:      args,
+		quit:      make(chan struct{}),
+	}
+	ch := make(chan json.RawMessage)
+	var err error
+	for _, ep := range fc.route() {
+		var sub *ClientSubscription
+		if sub, err = ep.client.Subscribe(ctx, fs.namespace, ch, args...); err == nil {
+			fs.endpoint, fs.sub = ep, sub
+			break
+		}
+		if err == ErrNotificationsUnsupported {
+			continue
+		}
+		if !isTransportError(err) || ctx.Err() != nil {
+			return msg.errorResponse(err)
+		}
+		ep.failed(err)
+	}
+	if fs.sub == nil {
+		return msg.errorResponse(err)
+	}
+	// Register the forwarder under the lock closing the codec, so close doesn't
+	// miss it while waiting for the forwarders to exit
+	fc.subsLock.Lock()
+	select {
+	case <-fc.closeCh:
+		fc.subsLock.Unlock()
+		fs.sub.Unsubscribe()
+		return msg.errorResponse(ErrClientQuit)
+	default:
+	}
+	fc.subs[fs.id] = fs
+	fc.wg.Add(1)
+	fc.subsLock.Unlock()
+
+	fc.deliver([]*jsonrpcMessage{msg.response(fs.id)}, false)
+
+	go fc.forward(fs, ch)
+	return nil
+}
+
+// unsubscribe tears down a subscription of the client.
+func (fc *failoverCodec) unsubscribe(msg *jsonrpcMessage, args []interface{}) *jsonrpcMessage {
+	var id ID
+	if len(args) != 1 || json.Unmarshal(args[0].(json.RawMessage), &id) != nil {
+		return msg.errorResponse(&invalidParamsError{"expected subscription id as first argument"})
+	}
+	fc.subsLock.Lock()
+	fs, ok := fc.subs[id]
+	delete(fc.subs, id)
+	fc.subsLock.Unlock()
+
+	if !ok {
+		return msg.errorResponse(ErrSubscriptionNotFound)
+	}
+	close(fs.quit)
+	return msg.response(true)
+}
+
+// forward relays the notifications of an endpoint subscription to the client,
+// moving the subscription over to another endpoint if its own fails.
+func (fc *failoverCodec) forward(fs *failoverSub, ch chan json.RawMessage) {
+	defer fc.wg.Done()
+
+	for {
+		select {
+		case result := <-ch:
+			params, _ := json.Marshal(&subscriptionResult{ID: string(fs.id), Result: result})
+			fc.deliver([]*jsonrpcMessage{{Version: vsn, Method: fs.namespace + notificationMethodSuffix, Params: params}}, false)
+
+		case err := <-fs.sub.Err():
+			fs.endpoint.failed(err)
+			if !fc.resubscribe(fs, ch) {
+				return
+			}
+		case <-fs.quit:
+			fs.sub.Unsubscribe()
+			return
+		case <-fc.closeCh:
+			fs.sub.Unsubscribe()
+			return
+		}
+	}
+}
+
+// resubscribe recreates a failed subscription on the preferred endpoint, retrying
+// until it succeeds or the subscription is torn down.
+func (fc *failoverCodec) resubscribe(fs *failoverSub, ch chan json.RawMessage) bool {
+	backoff := time.Second
+	for {
+		for _, ep := range fc.route() {
+			ctx, cancel := context.WithTimeout(fc.ctx, subscribeTimeout)
+			sub, err := ep.client.Subscribe(ctx, fs.namespace, ch, fs.args...)
+			cancel()
+			if err == nil {
+				log.Warn("Moved RPC subscription", "id", fs.id, "from", fs.endpoint.name, "to", ep.name)
+				fs.endpoint, fs.sub = ep, sub
+				return true
+			}
+		}
+		select {
+		case <-time.After(backoff):
+		case <-fs.quit:
+			return false
+		case <-fc.closeCh:
+			return false
+		}
+		if backoff *= 2; backoff > maxResubscribeBackoff {
+			backoff = maxResubscribeBackoff
+		}
+	}
+}
+
+// healthLoop periodically checks the health of all endpoints.
+func (fc *failoverCodec) healthLoop() {
+	defer fc.wg.Done()
+
+	ticker := time.NewTicker(fc.config.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		fc.checkHealth()
+		select {
+		case <-ticker.C:
+		case <-fc.closeCh:
+			return
+		}
+	}
+}
+
+// checkHealth probes all endpoints concurrently, updating their health. Error
+// responses count as healthy, as the endpoint did answer.
+func (fc *failoverCodec) checkHealth() {
+	var wg sync.WaitGroup
+	for _, ep := range fc.endpoints {
+		wg.Add(1)
+		go func(ep *failoverEndpoint) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(fc.ctx, fc.config.HealthTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := ep.client.CallContext(ctx, nil, fc.config.HealthMethod)
+			switch {
+			case err == nil || err == ErrNoResult || !isTransportError(err):
+				ep.succeeded(time.Since(start))
+			case fc.ctx.Err() == nil:
+				ep.failed(err)
+			}
+		}(ep)
+	}
+	wg.Wait()
+}
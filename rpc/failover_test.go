@@ -0,0 +1,186 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// valueService returns a fixed value, to tell endpoints apart.
+type valueService struct{ value int }
+
+func (s *valueService) Value() int { return s.value }
+
+// newFailoverTestClient creates a failover client over in-process servers
+// returning the given values.
+func newFailoverTestClient(t *testing.T, config FailoverConfig, values ...int) (*Client, []*Client) {
+	backends := make([]*Client, len(values))
+	for i, value := range values {
+		server := newTestServer()
+		if err := server.RegisterName("value", &valueService{value}); err != nil {
+			t.Fatal(err)
+		}
+		backends[i] = DialInProc(server)
+	}
+	if config.HealthMethod == "" {
+		config.HealthMethod = "test_noArgsRets"
+	}
+	client, err := NewFailoverClient(backends, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, backends
+}
+
+func TestFailoverCall(t *testing.T) {
+	client, backends := newFailoverTestClient(t, FailoverConfig{}, 1, 2)
+	defer client.Close()
+
+	var resp echoResult
+	if err := client.Call(&resp, "test_echo", "hello", 10, &echoArgs{"world"}); err != nil {
+		t.Fatal(err)
+	}
+	if resp.String != "hello" || resp.Int != 10 || resp.Args.S != "world" {
+		t.Errorf("incorrect result %#v", resp)
+	}
+	// Error responses must be returned as is, without failing over
+	err := client.Call(nil, "test_returnError")
+	if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != 444 {
+		t.Errorf("error mismatch: have %v, want testError", err)
+	}
+	// Kill the preferred endpoint and ensure calls fail over
+	var first int
+	if err := client.Call(&first, "value_value"); err != nil {
+		t.Fatal(err)
+	}
+	backends[first-1].Close()
+
+	var second int
+	if err := client.Call(&second, "value_value"); err != nil {
+		t.Fatalf("failover call failed: %v", err)
+	}
+	if second == first {
+		t.Errorf("call served by dead endpoint %d", first)
+	}
+	// Batches must be spread the same way
+	batch := []BatchElem{
+		{Method: "value_value", Result: new(int)},
+		{Method: "test_returnError", Result: new(int)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	if batch[0].Error != nil || *batch[0].Result.(*int) != second {
+		t.Errorf("batch element 0 mismatch: have %v (%v), want %d", *batch[0].Result.(*int), batch[0].Error, second)
+	}
+	if batch[1].Error == nil {
+		t.Errorf("batch element 1 succeeded, want error")
+	}
+}
+
+func TestFailoverQuorum(t *testing.T) {
+	config := FailoverConfig{Quorum: 2, QuorumMethods: []string{"value_value"}}
+	client, backends := newFailoverTestClient(t, config, 7, 5, 7)
+	defer client.Close()
+
+	var value int
+	if err := client.Call(&value, "value_value"); err != nil {
+		t.Fatal(err)
+	}
+	if value != 7 {
+		t.Errorf("quorum value mismatch: have %d, want 7", value)
+	}
+	// Without the second agreeing endpoint, quorum is lost
+	backends[2].Close()
+	if err := client.Call(&value, "value_value"); err == nil {
+		t.Errorf("quorum read succeeded without quorum: %d", value)
+	}
+	// Methods outside of the quorum set are still served by a single endpoint
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Errorf("non-quorum call failed: %v", err)
+	}
+}
+
+// hangingService never answers until the client gives up.
+type hangingService struct{}
+
+func (s *hangingService) Value(ctx context.Context) int {
+	<-ctx.Done()
+	return 0
+}
+
+// Tests that quorum reads stop waiting for a hanging endpoint once the answered
+// ones can't agree anymore.
+func TestFailoverQuorumUnreachable(t *testing.T) {
+	var backends []*Client
+	for _, service := range []interface{}{&valueService{7}, &valueService{5}, new(hangingService)} {
+		server := newTestServer()
+		if err := server.RegisterName("value", service); err != nil {
+			t.Fatal(err)
+		}
+		defer server.Stop()
+		backends = append(backends, DialInProc(server))
+	}
+	client, err := NewFailoverClient(backends, FailoverConfig{Quorum: 3, QuorumMethods: []string{"value_value"}, HealthMethod: "test_noArgsRets"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- client.Call(nil, "value_value") }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("quorum read succeeded without quorum")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("quorum read waiting on hanging endpoint")
+	}
+}
+
+func TestFailoverSubscription(t *testing.T) {
+	client, _ := newFailoverTestClient(t, FailoverConfig{}, 1, 2)
+	defer client.Close()
+
+	var (
+		ch    = make(chan int)
+		count = 10
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := client.Subscribe(ctx, "nftest", ch, "someSubscription", count, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < count; i++ {
+		select {
+		case value := <-ch:
+			if value != i {
+				t.Fatalf("notification %d mismatch: have %d", i, value)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for notification %d", i)
+		}
+	}
+	sub.Unsubscribe()
+}
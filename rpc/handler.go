@@ -19,10 +19,12 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/simplechain-org/client/log"
@@ -60,11 +62,21 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	limiter        *rateLimiter  // per-client request limits, nil if unlimited
+	inflight       int32         // number of calls being served, tracked if limited
+	interceptors   []Interceptor // observers of served calls and subscriptions
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
 }
 
+// handlerConfig holds the settings a server applies to the handlers of its
+// connections.
+type handlerConfig struct {
+	limiter      *rateLimiter  // per-client request limits, nil if unlimited
+	interceptors []Interceptor // observers of served calls, in registration order
+}
+
 type callProc struct {
 	ctx       context.Context
 	notifiers []*Notifier
@@ -91,6 +103,15 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 	return h
 }
 
+// configure applies the server side settings to the handler. It must be called
+// before any message is handled.
+func (h *handler) configure(config *handlerConfig) {
+	if config != nil {
+		h.limiter = config.limiter
+		h.interceptors = config.interceptors
+	}
+}
+
 // handleBatch executes all messages in a batch and returns the responses.
 func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	// Emit error response for empty batches:
@@ -100,6 +121,23 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		})
 		return
 	}
+	// Reject every call of batches exceeding the limit without serving any:
+	if h.limiter != nil && h.limiter.limits.MaxBatchSize > 0 && len(msgs) > h.limiter.limits.MaxBatchSize {
+		batchLimitedMeter.Mark(1)
+		h.startCallProc(func(cp *callProc) {
+			err := &limitExceededError{fmt.Sprintf("batch too large (%d>%d)", len(msgs), h.limiter.limits.MaxBatchSize)}
+			answers := make([]*jsonrpcMessage, 0, len(msgs))
+			for _, msg := range msgs {
+				if msg.isCall() {
+					answers = append(answers, msg.errorResponse(err))
+				}
+			}
+			if len(answers) > 0 {
+				h.conn.writeJSON(cp.ctx, answers)
+			}
+		})
+		return
+	}
 
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
@@ -199,6 +237,7 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 	for _, n := range nn {
 		if sub := n.takeSubscription(); sub != nil {
 			h.serverSubs[sub.ID] = sub
+			h.subscriptionEvent(SubscriptionCreated, sub, 0)
 		}
 	}
 }
@@ -212,6 +251,24 @@ func (h *handler) cancelServerSubscriptions(err error) {
 		s.err <- err
 		close(s.err)
 		delete(h.serverSubs, id)
+		h.subscriptionEvent(SubscriptionClosed, s, 0)
+	}
+}
+
+// subscriptionEvent reports a subscription event to the interceptors.
+func (h *handler) subscriptionEvent(event SubscriptionEvent, sub *Subscription, size int) {
+	if len(h.interceptors) == 0 {
+		return
+	}
+	info := &SubscriptionInfo{
+		Event:      event,
+		Namespace:  sub.namespace,
+		ID:         sub.ID,
+		RemoteAddr: h.conn.remoteAddr(),
+		Size:       size,
+	}
+	for _, ic := range h.interceptors {
+		ic.SubscriptionEvent(info)
 	}
 }
 
@@ -314,8 +371,52 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	}
 }
 
-// handleCall processes method calls.
+// handleCall processes method calls, passing them through the interceptors.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if len(h.interceptors) == 0 {
+		return h.serveCall(cp, msg)
+	}
+	info := &CallInfo{
+		Method:        msg.Method,
+		ParamsSize:    len(msg.Params),
+		RemoteAddr:    h.conn.remoteAddr(),
+		metricsMethod: h.metricsMethod(msg.Method),
+	}
+
+	ctx := cp.ctx
+	for _, ic := range h.interceptors {
+		ctx = ic.PreCall(ctx, info)
+	}
+	// Serve the call with the intercepted context, restoring the original for the
+	// remaining calls of a batch.
+	parent := cp.ctx
+	cp.ctx = ctx
+	start := time.Now()
+	answer := h.serveCall(cp, msg)
+	elapsed := time.Since(start)
+	cp.ctx = parent
+
+	var err Error
+	if answer != nil && answer.Error != nil {
+		err = answer.Error
+	}
+	for i := len(h.interceptors) - 1; i >= 0; i-- {
+		h.interceptors[i].PostCall(ctx, info, elapsed, err)
+	}
+	return answer
+}
+
+// serveCall processes method calls.
+func (h *handler) serveCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if perms, ok := PermissionsFromContext(cp.ctx); ok && !perms.Allowed(msg.Method) {
+		return msg.errorResponse(&unauthorizedError{msg.Method})
+	}
+	if h.limiter != nil {
+		if err := h.limit(cp.ctx, msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
+		defer atomic.AddInt32(&h.inflight, -1)
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -350,6 +451,32 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	return answer
 }
 
+// limit admits a call into the in-flight set of the connection if the limits of
+// the client allow it. The caller must leave the set once the call is served.
+func (h *handler) limit(ctx context.Context, method string) error {
+	inflight := atomic.AddInt32(&h.inflight, 1)
+	if max := h.limiter.limits.MaxInflight; max > 0 && inflight > int32(max) {
+		atomic.AddInt32(&h.inflight, -1)
+		inflightLimitedMeter.Mark(1)
+		newRPCRejectedMeter(h.metricsMethod(method)).Mark(1)
+		return &limitExceededError{fmt.Sprintf("too many concurrent calls (max %d)", max)}
+	}
+	if err := h.limiter.allow(limiterClientKey(ctx, h.conn.remoteAddr()), method); err != nil {
+		atomic.AddInt32(&h.inflight, -1)
+		return err
+	}
+	return nil
+}
+
+// metricsMethod returns the name per-method metrics of a call are recorded under:
+// the method itself if served, or a single name for all the others.
+func (h *handler) metricsMethod(method string) string {
+	if h.reg.served(method) {
+		return method
+	}
+	return unknownMethodMetric
+}
+
 // handleSubscribe processes *_subscribe method calls.
 func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
 	if !h.allowSubscribe {
@@ -403,6 +530,7 @@ func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 	}
 	close(s.err)
 	delete(h.serverSubs, id)
+	h.subscriptionEvent(SubscriptionClosed, s, 0)
 	return true, nil
 }
 
@@ -44,8 +44,11 @@ type httpConn struct {
 	url       string
 	closeOnce sync.Once
 	closeCh   chan interface{}
-	mu        sync.Mutex // protects headers
+	mu        sync.Mutex // protects headers and compression
 	headers   http.Header
+	auth      HTTPAuth // optional, sets credentials on each request
+
+	compression int // minimum size of gzip compressed requests, 0 = disabled
 }
 
 // httpConn is treated specially by Client.
@@ -105,6 +108,16 @@ var DefaultHTTPTimeouts = HTTPTimeouts{
 // DialHTTPWithClient creates a new RPC client that connects to an RPC server over HTTP
 // using the provided HTTP Client.
 func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
+	return dialHTTP(endpoint, client, nil)
+}
+
+// DialHTTPWithAuth creates a new RPC client that connects to an RPC server over HTTP,
+// setting the credentials of each request with the given auth.
+func DialHTTPWithAuth(endpoint string, auth HTTPAuth) (*Client, error) {
+	return dialHTTP(endpoint, new(http.Client), auth)
+}
+
+func dialHTTP(endpoint string, client *http.Client, auth HTTPAuth) (*Client, error) {
 	// Sanity check URL so we don't end up with a client that will fail every request.
 	_, err := url.Parse(endpoint)
 	if err != nil {
@@ -112,13 +125,15 @@ func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
 	}
 
 	initctx := context.Background()
-	headers := make(http.Header, 2)
+	headers := make(http.Header, 3)
 	headers.Set("accept", contentType)
+	headers.Set("accept-encoding", "gzip, deflate")
 	headers.Set("content-type", contentType)
 	return newClient(initctx, func(context.Context) (ServerCodec, error) {
 		hc := &httpConn{
 			client:  client,
 			headers: headers,
+			auth:    auth,
 			url:     endpoint,
 			closeCh: make(chan interface{}),
 		}
@@ -169,6 +184,16 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 	if err != nil {
 		return nil, err
 	}
+	hc.mu.Lock()
+	headers, compression := hc.headers.Clone(), hc.compression
+	hc.mu.Unlock()
+
+	compressed := compression > 0 && len(body) >= compression
+	if compressed {
+		if body, err = compress(encodingGzip, body); err != nil {
+			return nil, err
+		}
+	}
 	req, err := http.NewRequestWithContext(ctx, "POST", hc.url, ioutil.NopCloser(bytes.NewReader(body)))
 	if err != nil {
 		return nil, err
@@ -176,9 +201,18 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 	req.ContentLength = int64(len(body))
 
 	// set headers
-	hc.mu.Lock()
-	req.Header = hc.headers.Clone()
-	hc.mu.Unlock()
+	req.Header = headers
+	if compressed {
+		req.Header.Set("content-encoding", encodingGzip)
+	}
+	if id, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set(requestIDHeader, id)
+	}
+	if hc.auth != nil {
+		if err := hc.auth(req.Header); err != nil {
+			return nil, err
+		}
+	}
 
 	// do request
 	resp, err := hc.client.Do(req)
@@ -198,7 +232,17 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 			Body:       body,
 		}
 	}
-	return resp.Body, nil
+	// Setting Accept-Encoding disables the transparent decompression of the
+	// transport, so responses are decoded here.
+	decoded, err := newDecoder(resp.Header.Get("content-encoding"), resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if decoded == resp.Body {
+		return resp.Body, nil
+	}
+	return &decompressedBody{Reader: decoded, body: resp.Body}, nil
 }
 
 // httpServerConn turns a HTTP connection into a Conn.
@@ -208,8 +252,10 @@ type httpServerConn struct {
 	r *http.Request
 }
 
-func newHTTPServerConn(r *http.Request, w http.ResponseWriter) ServerCodec {
-	body := io.LimitReader(r.Body, maxRequestContentLength)
+// newHTTPServerConn creates a codec reading the request from the given decoded
+// body, the size limit applies to the decompressed content.
+func newHTTPServerConn(r *http.Request, body io.Reader, w http.ResponseWriter) ServerCodec {
+	body = io.LimitReader(body, maxRequestContentLength)
 	conn := &httpServerConn{Reader: body, Writer: w, r: r}
 	return NewCodec(conn)
 }
@@ -249,9 +295,24 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if origin := r.Header.Get("Origin"); origin != "" {
 		ctx = context.WithValue(ctx, "Origin", origin)
 	}
+	if id := r.Header.Get(requestIDHeader); id != "" {
+		ctx = WithRequestID(ctx, id)
+	}
 
+	body, err := newDecoder(r.Header.Get("content-encoding"), r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
 	w.Header().Set("content-type", contentType)
-	codec := newHTTPServerConn(r, w)
+	if s.httpCompression > 0 {
+		if encoding := negotiateEncoding(r.Header.Get("accept-encoding")); encoding != "" {
+			cw := newCompressionWriter(w, encoding, s.httpCompression)
+			defer cw.Close()
+			w = cw
+		}
+	}
+	codec := newHTTPServerConn(r, body, w)
 	defer codec.close()
 	s.serveSingleRequest(ctx, codec)
 }
@@ -0,0 +1,167 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/simplechain-org/client/log"
+)
+
+// requestIDHeader is the HTTP header carrying request IDs between nodes.
+const requestIDHeader = "X-Request-Id"
+
+// CallInfo describes a call served by a server.
+type CallInfo struct {
+	Method     string // Name of the called method
+	ParamsSize int    // Size of the JSON encoded parameters
+	RemoteAddr string // Address of the caller, empty for in-process calls
+
+	metricsMethod string // Method name to record metrics under, bounded to served methods
+}
+
+// SubscriptionEvent is the kind of a subscription life cycle event.
+type SubscriptionEvent int
+
+const (
+	SubscriptionCreated  SubscriptionEvent = iota // Subscription ID was sent to the client
+	SubscriptionNotified                          // Notification was sent to the client
+	SubscriptionClosed                            // Subscription was unsubscribed or its connection closed
+)
+
+// String implements fmt.Stringer.
+func (e SubscriptionEvent) String() string {
+	switch e {
+	case SubscriptionCreated:
+		return "created"
+	case SubscriptionNotified:
+		return "notified"
+	case SubscriptionClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// SubscriptionInfo describes a subscription event.
+type SubscriptionInfo struct {
+	Event      SubscriptionEvent
+	Namespace  string // Namespace the subscription was created in
+	ID         ID     // ID of the subscription
+	RemoteAddr string // Address of the subscriber, empty for in-process subscriptions
+	Size       int    // Size of the JSON encoded notification payload
+}
+
+// Interceptor observes the calls and subscriptions served by a server.
+//
+// Interceptors are invoked in registration order before calls, and in reverse
+// order after them. They run on the goroutine serving the call and must not block.
+type Interceptor interface {
+	// PreCall is invoked before a call is served. The returned context is passed
+	// to the method and to the remaining interceptors.
+	PreCall(ctx context.Context, info *CallInfo) context.Context
+
+	// PostCall is invoked after a call is served, with the context returned by the
+	// last PreCall and the error sent to the caller, if any.
+	PostCall(ctx context.Context, info *CallInfo, elapsed time.Duration, err Error)
+
+	// SubscriptionEvent is invoked on subscription life cycle events.
+	SubscriptionEvent(info *SubscriptionInfo)
+}
+
+// Use appends interceptors to the chain invoked for every call served afterwards.
+// It must not be called concurrently with serving requests.
+func (s *Server) Use(interceptors ...Interceptor) {
+	s.config.interceptors = append(s.config.interceptors, interceptors...)
+}
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a context carrying the given request ID. Clients forward
+// it to HTTP servers in the X-Request-Id header.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by the context, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey{}).(string)
+	return id, ok && id != ""
+}
+
+// newRequestID generates a random request ID.
+func newRequestID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// metricsInterceptor is the built-in interceptor recording call statistics.
+type metricsInterceptor struct {
+	slowCall time.Duration
+}
+
+// NewMetricsInterceptor creates an interceptor recording per-method latency and
+// parameter size histograms, error code counters and subscription counters.
+// Calls to methods not served by the server are all recorded under "unknown".
+//
+// Every call is tagged with a request ID, taken from the caller's X-Request-Id
+// header if present or generated otherwise, which is available to the method
+// through RequestIDFromContext. Calls slower than slowCall are logged as warnings
+// with their request ID and caller, others at debug level. A zero slowCall
+// disables the warnings.
+func NewMetricsInterceptor(slowCall time.Duration) Interceptor {
+	return &metricsInterceptor{slowCall: slowCall}
+}
+
+// PreCall implements Interceptor, tagging the call with a request ID.
+func (m *metricsInterceptor) PreCall(ctx context.Context, info *CallInfo) context.Context {
+	if _, ok := RequestIDFromContext(ctx); !ok {
+		ctx = WithRequestID(ctx, newRequestID())
+	}
+	return ctx
+}
+
+// PostCall implements Interceptor, recording the statistics of a served call.
+func (m *metricsInterceptor) PostCall(ctx context.Context, info *CallInfo, elapsed time.Duration, err Error) {
+	method := info.metricsMethod
+	if method == "" {
+		method = unknownMethodMetric
+	}
+	newRPCLatencyHistogram(method).Update(elapsed.Microseconds())
+	newRPCParamsSizeHistogram(method).Update(int64(info.ParamsSize))
+
+	id, _ := RequestIDFromContext(ctx)
+	logctx := []interface{}{"method", info.Method, "reqid", id, "remote", info.RemoteAddr, "size", info.ParamsSize, "t", elapsed}
+	if err != nil {
+		newRPCErrorCounter(method, err.ErrorCode()).Inc(1)
+		logctx = append(logctx, "code", err.ErrorCode(), "err", err.Error())
+	}
+	if m.slowCall > 0 && elapsed >= m.slowCall {
+		log.Warn("Slow RPC call", logctx...)
+	} else {
+		log.Debug("Intercepted RPC call", logctx...)
+	}
+}
+
+// SubscriptionEvent implements Interceptor, counting subscription events.
+func (m *metricsInterceptor) SubscriptionEvent(info *SubscriptionInfo) {
+	newRPCSubscriptionCounter(info.Namespace, info.Event).Inc(1)
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client/metrics"
+)
+
+// recordingInterceptor logs every invocation into a shared journal.
+type recordingInterceptor struct {
+	name    string
+	lock    *sync.Mutex
+	journal *[]string
+}
+
+func (r *recordingInterceptor) record(format string, args ...interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	*r.journal = append(*r.journal, r.name+":"+fmt.Sprintf(format, args...))
+}
+
+func (r *recordingInterceptor) PreCall(ctx context.Context, info *CallInfo) context.Context {
+	r.record("pre %s", info.Method)
+	return ctx
+}
+
+func (r *recordingInterceptor) PostCall(ctx context.Context, info *CallInfo, elapsed time.Duration, err Error) {
+	code := 0
+	if err != nil {
+		code = err.ErrorCode()
+	}
+	r.record("post %s %d", info.Method, code)
+}
+
+func (r *recordingInterceptor) SubscriptionEvent(info *SubscriptionInfo) {
+	r.record("%s %s", info.Namespace, info.Event)
+}
+
+func TestInterceptorChain(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		journal []string
+	)
+	server := newTestServer()
+	server.Use(
+		&recordingInterceptor{name: "a", lock: &lock, journal: &journal},
+		&recordingInterceptor{name: "b", lock: &lock, journal: &journal},
+	)
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal(err)
+	}
+	client.Call(nil, "test_returnError")
+
+	want := []string{
+		"a:pre test_noArgsRets", "b:pre test_noArgsRets", "b:post test_noArgsRets 0", "a:post test_noArgsRets 0",
+		"a:pre test_returnError", "b:pre test_returnError", "b:post test_returnError 444", "a:post test_returnError 444",
+	}
+	lock.Lock()
+	if !reflect.DeepEqual(journal, want) {
+		t.Errorf("call journal mismatch:\nhave %q\nwant %q", journal, want)
+	}
+	journal = nil
+	lock.Unlock()
+
+	// Subscription life cycle events are reported too
+	ch := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "nftest", ch, "someSubscription", 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	<-ch
+	sub.Unsubscribe()
+
+	// Notifications may be reported after the client reacted to them, so only the
+	// set of events is checked
+	want = []string{
+		"a:nftest closed", "a:nftest created", "a:nftest notified", "a:nftest notified",
+		"b:nftest closed", "b:nftest created", "b:nftest notified", "b:nftest notified",
+	}
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		var events []string
+		lock.Lock()
+		for _, entry := range journal {
+			if entry[2:5] != "pre" && entry[2:6] != "post" {
+				events = append(events, entry)
+			}
+		}
+		lock.Unlock()
+		sort.Strings(events)
+		if reflect.DeepEqual(events, want) {
+			return
+		}
+	}
+	t.Errorf("subscription journal mismatch:\nhave %q\nwant %q", journal, want)
+}
+
+// requestIDService returns the request ID of its calls.
+type requestIDService struct{}
+
+func (s *requestIDService) RequestID(ctx context.Context) string {
+	id, _ := RequestIDFromContext(ctx)
+	return id
+}
+
+func TestRequestIDPropagation(t *testing.T) {
+	server := newTestServer()
+	server.RegisterName("reqid", new(requestIDService))
+	server.Use(NewMetricsInterceptor(time.Second))
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+	client, _ := DialHTTP(httpsrv.URL)
+	defer client.Close()
+
+	var id string
+	if err := client.CallContext(WithRequestID(context.Background(), "caller-1"), &id, "reqid_requestID"); err != nil {
+		t.Fatal(err)
+	}
+	if id != "caller-1" {
+		t.Errorf("forwarded request ID mismatch: have %q, want %q", id, "caller-1")
+	}
+	if err := client.Call(&id, "reqid_requestID"); err != nil {
+		t.Fatal(err)
+	}
+	if id == "" || id == "caller-1" {
+		t.Errorf("generated request ID mismatch: have %q", id)
+	}
+}
+
+// Tests that the metrics interceptor records calls to methods not served under a
+// single name, instead of registering metrics for any name sent by clients.
+func TestMetricsInterceptorUnknownMethods(t *testing.T) {
+	server := newTestServer()
+	server.Use(NewMetricsInterceptor(0))
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Call(nil, "bogus_method"); err == nil {
+		t.Fatal("call to unknown method succeeded")
+	}
+	for _, name := range []string{"rpc/latency/bogus_method", "rpc/params/bogus_method", "rpc/errors/bogus_method/-32601"} {
+		if metrics.DefaultRegistry.Get(name) != nil {
+			t.Errorf("metric %s registered for unknown method", name)
+		}
+	}
+	for _, name := range []string{"rpc/latency/test_noArgsRets", "rpc/latency/unknown", "rpc/errors/unknown/-32601"} {
+		if metrics.DefaultRegistry.Get(name) == nil {
+			t.Errorf("metric %s not registered", name)
+		}
+	}
+}
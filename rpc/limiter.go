@@ -0,0 +1,153 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// limiterClientCacheLimit is the number of clients whose buckets are tracked.
+// Buckets of the least recently active clients are dropped beyond it, which
+// refills them.
+const limiterClientCacheLimit = 4096
+
+// Rate is the refill rate and capacity of a token bucket.
+type Rate struct {
+	Limit float64 // Tokens added per second
+	Burst int     // Maximum number of tokens held
+}
+
+// RateLimits configures the per-client limits of a server. Clients are identified
+// by their authenticated identity if any, or by the host of their remote address.
+type RateLimits struct {
+	Methods      map[string]Rate // Token buckets of fully qualified methods
+	Namespaces   map[string]Rate // Token buckets of whole namespaces
+	MaxBatchSize int             // Maximum number of messages in a batch, unlimited if zero
+	MaxInflight  int             // Maximum number of calls served at once on a connection, unlimited if zero
+}
+
+// limitExceededError is returned for requests rejected by a limit.
+type limitExceededError struct{ message string }
+
+func (e *limitExceededError) ErrorCode() int { return -32005 }
+
+func (e *limitExceededError) Error() string { return e.message }
+
+// bucket is a token bucket.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// available refills the bucket up to the given time, and reports whether it holds a
+// token to take.
+func (b *bucket) available(rate Rate, now time.Time) bool {
+	b.tokens += now.Sub(b.updated).Seconds() * rate.Limit
+	if b.tokens > float64(rate.Burst) {
+		b.tokens = float64(rate.Burst)
+	}
+	b.updated = now
+	return b.tokens >= 1
+}
+
+// rateLimiter enforces RateLimits across all connections of a server.
+type rateLimiter struct {
+	limits  RateLimits
+	clients *lru.Cache // client key -> map[string]*bucket
+	lock    sync.Mutex
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	clients, _ := lru.New(limiterClientCacheLimit)
+	return &rateLimiter{limits: limits, clients: clients}
+}
+
+// allow takes a token from the method and namespace buckets of a client, failing
+// without taking any if either of them is empty.
+func (l *rateLimiter) allow(client, method string) error {
+	var (
+		namespace = method
+		scopes    []string
+	)
+	if i := strings.Index(method, serviceMethodSeparator); i >= 0 {
+		namespace = method[:i]
+	}
+	if _, ok := l.limits.Methods[method]; ok {
+		scopes = append(scopes, method)
+	}
+	if _, ok := l.limits.Namespaces[namespace]; ok {
+		scopes = append(scopes, namespace+serviceMethodSeparator)
+	}
+	if len(scopes) == 0 {
+		return nil
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var buckets map[string]*bucket
+	if cached, ok := l.clients.Get(client); ok {
+		buckets = cached.(map[string]*bucket)
+	} else {
+		buckets = make(map[string]*bucket)
+		l.clients.Add(client, buckets)
+	}
+	now := time.Now()
+	for _, scope := range scopes {
+		rate := l.rate(scope)
+		b := buckets[scope]
+		if b == nil {
+			b = &bucket{tokens: float64(rate.Burst), updated: now}
+			buckets[scope] = b
+		}
+		if !b.available(rate, now) {
+			newRPCRejectedMeter(strings.TrimSuffix(scope, serviceMethodSeparator)).Mark(1)
+			rateLimitedMeter.Mark(1)
+			return &limitExceededError{fmt.Sprintf("rate limit of %s exceeded", strings.TrimSuffix(scope, serviceMethodSeparator))}
+		}
+	}
+	for _, scope := range scopes {
+		buckets[scope].tokens--
+	}
+	return nil
+}
+
+// rate returns the rate of a bucket scope. Namespace scopes carry a trailing
+// separator to tell them apart from methods.
+func (l *rateLimiter) rate(scope string) Rate {
+	if strings.HasSuffix(scope, serviceMethodSeparator) {
+		return l.limits.Namespaces[strings.TrimSuffix(scope, serviceMethodSeparator)]
+	}
+	return l.limits.Methods[scope]
+}
+
+// limiterClientKey identifies the client of a connection for rate limiting.
+func limiterClientKey(ctx context.Context, remote string) string {
+	if perms, ok := PermissionsFromContext(ctx); ok && perms.Identity() != "" {
+		return perms.Identity()
+	}
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/simplechain-org/client/metrics"
+)
+
+// isLimitExceeded reports whether err is a limit rejection.
+func isLimitExceeded(err error) bool {
+	rpcErr, ok := err.(Error)
+	return ok && rpcErr.ErrorCode() == (&limitExceededError{}).ErrorCode()
+}
+
+func TestRateLimits(t *testing.T) {
+	server := newTestServer()
+	server.SetRateLimits(RateLimits{
+		Methods:    map[string]Rate{"test_noArgsRets": {Burst: 2}},
+		Namespaces: map[string]Rate{"test": {Burst: 3}},
+	})
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	// Method buckets are drained first, without touching the namespace
+	for i := 0; i < 2; i++ {
+		if err := client.Call(nil, "test_noArgsRets"); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	if err := client.Call(nil, "test_noArgsRets"); !isLimitExceeded(err) {
+		t.Fatalf("method limit error mismatch: have %v", err)
+	}
+	// The namespace bucket is shared by all of its methods
+	var resp echoResult
+	if err := client.Call(&resp, "test_echo", "x", 1, &echoArgs{"y"}); err != nil {
+		t.Fatalf("namespace call failed: %v", err)
+	}
+	if err := client.Call(&resp, "test_echo", "x", 1, &echoArgs{"y"}); !isLimitExceeded(err) {
+		t.Fatalf("namespace limit error mismatch: have %v", err)
+	}
+	// Unlimited namespaces are unaffected
+	if _, err := client.SupportedModules(); err != nil {
+		t.Fatalf("unlimited call failed: %v", err)
+	}
+}
+
+func TestRateLimitRefill(t *testing.T) {
+	limiter := newRateLimiter(RateLimits{Methods: map[string]Rate{"test_echo": {Limit: 50, Burst: 1}}})
+	if err := limiter.allow("a", "test_echo"); err != nil {
+		t.Fatalf("first call rejected: %v", err)
+	}
+	if err := limiter.allow("a", "test_echo"); err == nil {
+		t.Fatal("drained bucket admitted call")
+	}
+	if err := limiter.allow("b", "test_echo"); err != nil {
+		t.Fatalf("other client rejected: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := limiter.allow("a", "test_echo"); err != nil {
+		t.Fatalf("refilled bucket rejected call: %v", err)
+	}
+}
+
+func TestBatchSizeLimit(t *testing.T) {
+	server := newTestServer()
+	server.SetRateLimits(RateLimits{MaxBatchSize: 2})
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+	client, _ := DialHTTP(httpsrv.URL)
+	defer client.Close()
+
+	batch := []BatchElem{
+		{Method: "test_rets", Result: new(string)},
+		{Method: "test_rets", Result: new(string)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	for i, elem := range batch {
+		if elem.Error != nil {
+			t.Errorf("element %d of admitted batch failed: %v", i, elem.Error)
+		}
+	}
+	batch = append(batch, BatchElem{Method: "test_rets", Result: new(string)})
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	for i, elem := range batch {
+		if !isLimitExceeded(elem.Error) {
+			t.Errorf("element %d of oversized batch error mismatch: have %v", i, elem.Error)
+		}
+	}
+}
+
+func TestInflightLimit(t *testing.T) {
+	server := newTestServer()
+	server.SetRateLimits(RateLimits{MaxInflight: 1})
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	done := make(chan error)
+	go func() { done <- client.Call(nil, "test_sleep", 500*time.Millisecond) }()
+	time.Sleep(100 * time.Millisecond)
+
+	if err := client.Call(nil, "test_noArgsRets"); !isLimitExceeded(err) {
+		t.Errorf("concurrent call error mismatch: have %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("slow call failed: %v", err)
+	}
+	if err := client.Call(nil, "test_noArgsRets"); err != nil {
+		t.Errorf("call after slow call failed: %v", err)
+	}
+}
+
+// Tests that rejections are metered under served methods or limit scopes only,
+// never under arbitrary method names sent by clients.
+func TestRejectedMeterNames(t *testing.T) {
+	server := newTestServer()
+	server.SetRateLimits(RateLimits{Namespaces: map[string]Rate{"test": {Burst: 1}}, MaxInflight: 1})
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	done := make(chan error)
+	go func() { done <- client.Call(nil, "test_sleep", 300*time.Millisecond) }()
+	time.Sleep(100 * time.Millisecond)
+
+	if err := client.Call(nil, "bogus_inflight"); !isLimitExceeded(err) {
+		t.Errorf("concurrent call error mismatch: have %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("slow call failed: %v", err)
+	}
+	if err := client.Call(nil, "test_bogusRate"); !isLimitExceeded(err) {
+		t.Errorf("drained namespace error mismatch: have %v", err)
+	}
+	for _, name := range []string{"rpc/rejected/bogus_inflight", "rpc/rejected/test_bogusRate"} {
+		if metrics.DefaultRegistry.Get(name) != nil {
+			t.Errorf("meter %s registered for unserved method", name)
+		}
+	}
+	for _, name := range []string{"rpc/rejected/" + unknownMethodMetric, "rpc/rejected/test"} {
+		if metrics.DefaultRegistry.Get(name) == nil {
+			t.Errorf("meter %s not registered", name)
+		}
+	}
+}
@@ -22,11 +22,19 @@ import (
 	"github.com/simplechain-org/client/metrics"
 )
 
+// unknownMethodMetric is the name per-method metrics of calls to methods not
+// served are recorded under, as clients may send any method name.
+const unknownMethodMetric = "unknown"
+
 var (
 	rpcRequestGauge        = metrics.NewRegisteredGauge("rpc/requests", nil)
 	successfulRequestGauge = metrics.NewRegisteredGauge("rpc/success", nil)
 	failedReqeustGauge     = metrics.NewRegisteredGauge("rpc/failure", nil)
 	rpcServingTimer        = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	rateLimitedMeter     = metrics.NewRegisteredMeter("rpc/rejected/ratelimit", nil)
+	inflightLimitedMeter = metrics.NewRegisteredMeter("rpc/rejected/inflight", nil)
+	batchLimitedMeter    = metrics.NewRegisteredMeter("rpc/rejected/batch", nil)
 )
 
 func newRPCServingTimer(method string, valid bool) metrics.Timer {
@@ -37,3 +45,31 @@ func newRPCServingTimer(method string, valid bool) metrics.Timer {
 	m := fmt.Sprintf("rpc/duration/%s/%s", method, flag)
 	return metrics.GetOrRegisterTimer(m, nil)
 }
+
+func newRPCRejectedMeter(method string) metrics.Meter {
+	return metrics.GetOrRegisterMeter(fmt.Sprintf("rpc/rejected/%s", method), nil)
+}
+
+func newRPCLatencyHistogram(method string) metrics.Histogram {
+	m := fmt.Sprintf("rpc/latency/%s", method)
+	return metrics.GetOrRegisterHistogramLazy(m, nil, newRPCSample)
+}
+
+func newRPCParamsSizeHistogram(method string) metrics.Histogram {
+	m := fmt.Sprintf("rpc/params/%s", method)
+	return metrics.GetOrRegisterHistogramLazy(m, nil, newRPCSample)
+}
+
+func newRPCSample() metrics.Sample {
+	return metrics.NewExpDecaySample(1028, 0.015)
+}
+
+func newRPCErrorCounter(method string, code int) metrics.Counter {
+	m := fmt.Sprintf("rpc/errors/%s/%d", method, code)
+	return metrics.GetOrRegisterCounter(m, nil)
+}
+
+func newRPCSubscriptionCounter(namespace string, event fmt.Stringer) metrics.Counter {
+	m := fmt.Sprintf("rpc/subscriptions/%s/%s", namespace, event)
+	return metrics.GetOrRegisterCounter(m, nil)
+}
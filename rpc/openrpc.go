@@ -0,0 +1,318 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+)
+
+const openRPCVersion = "1.2.6"
+
+// Titles of the schemas of well known types. Generators rely on them to map the
+// schemas back onto the original types.
+const (
+	SchemaAddress           = "address"
+	SchemaHash              = "hash"
+	SchemaBytes             = "bytes"
+	SchemaBig               = "big"
+	SchemaUint64            = "uint64"
+	SchemaUint              = "uint"
+	SchemaBigInt            = "bigint"
+	SchemaBlockNumber       = "blockNumber"
+	SchemaBlockNumberOrHash = "blockNumberOrHash"
+	SchemaSubscriptionID    = "subscriptionID"
+)
+
+const hexQuantityPattern = "^0x(0|[1-9a-fA-F][0-9a-fA-F]*)$"
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// knownSchemas are the schemas of types whose JSON encoding is not derivable
+	// from their Go representation.
+	knownSchemas = map[reflect.Type]JSONSchema{
+		reflect.TypeOf(common.Address{}):     {Title: SchemaAddress, Type: "string", Pattern: "^0x[0-9a-fA-F]{40}$"},
+		reflect.TypeOf(common.Hash{}):        {Title: SchemaHash, Type: "string", Pattern: "^0x[0-9a-fA-F]{64}$"},
+		reflect.TypeOf(hexutil.Bytes{}):      {Title: SchemaBytes, Type: "string", Pattern: "^0x([0-9a-fA-F]{2})*$"},
+		reflect.TypeOf(hexutil.Big{}):        {Title: SchemaBig, Type: "string", Pattern: hexQuantityPattern},
+		reflect.TypeOf(hexutil.Uint64(0)):    {Title: SchemaUint64, Type: "string", Pattern: hexQuantityPattern},
+		reflect.TypeOf(hexutil.Uint(0)):      {Title: SchemaUint, Type: "string", Pattern: hexQuantityPattern},
+		reflect.TypeOf(big.Int{}):            {Title: SchemaBigInt, Type: "integer"},
+		reflect.TypeOf(BlockNumber(0)):       {Title: SchemaBlockNumber, Type: "string", Pattern: "^(latest|pending|earliest|0x(0|[1-9a-fA-F][0-9a-fA-F]*))$"},
+		reflect.TypeOf(BlockNumberOrHash{}):  {Title: SchemaBlockNumberOrHash},
+		reflect.TypeOf(ID("")):               {Title: SchemaSubscriptionID, Type: "string"},
+		reflect.TypeOf(json.RawMessage(nil)): {},
+	}
+)
+
+// OpenRPCDocument is an OpenRPC description of the methods served by a server.
+type OpenRPCDocument struct {
+	OpenRPC    string             `json:"openrpc"`
+	Info       OpenRPCInfo        `json:"info"`
+	Methods    []*OpenRPCMethod   `json:"methods"`
+	Components *OpenRPCComponents `json:"components,omitempty"`
+}
+
+// OpenRPCInfo is the metadata of an OpenRPC document.
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenRPCMethod describes a single method.
+type OpenRPCMethod struct {
+	Name           string                      `json:"name"`
+	Params         []*OpenRPCContentDescriptor `json:"params"`
+	Result         *OpenRPCContentDescriptor   `json:"result"`
+	ParamStructure string                      `json:"paramStructure,omitempty"`
+}
+
+// OpenRPCContentDescriptor describes a parameter or result of a method.
+type OpenRPCContentDescriptor struct {
+	Name     string      `json:"name"`
+	Required bool        `json:"required,omitempty"`
+	Schema   *JSONSchema `json:"schema"`
+}
+
+// OpenRPCComponents holds the schemas referenced throughout a document.
+type OpenRPCComponents struct {
+	Schemas map[string]*JSONSchema `json:"schemas"`
+}
+
+// JSONSchema is the subset of JSON schema used to describe method signatures.
+type JSONSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+}
+
+// schemaRefPrefix is the prefix of references into the document's components.
+const schemaRefPrefix = "#/components/schemas/"
+
+// RefName returns the name of the component a reference schema points to.
+func (s *JSONSchema) RefName() string {
+	return strings.TrimPrefix(s.Ref, schemaRefPrefix)
+}
+
+// openRPC generates the OpenRPC document of all registered services.
+func (r *serviceRegistry) openRPC() *OpenRPCDocument {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		builder = &schemaBuilder{schemas: make(map[string]*JSONSchema), names: make(map[reflect.Type]string)}
+		doc     = &OpenRPCDocument{
+			OpenRPC: openRPCVersion,
+			Info:    OpenRPCInfo{Title: "JSON-RPC API", Version: "1.0"},
+			Methods: []*OpenRPCMethod{},
+		}
+	)
+	for _, svc := range r.services {
+		for name, cb := range svc.callbacks {
+			method := svc.name + serviceMethodSeparator + name
+			if strings.HasSuffix(method, subscribeMethodSuffix) || strings.HasSuffix(method, unsubscribeMethodSuffix) {
+				continue // shadowed by the subscription handlers
+			}
+			doc.Methods = append(doc.Methods, builder.method(method, cb))
+		}
+		if len(svc.subscriptions) > 0 {
+			doc.Methods = append(doc.Methods, builder.subscribeMethods(svc)...)
+		}
+	}
+	sort.Slice(doc.Methods, func(i, j int) bool { return doc.Methods[i].Name < doc.Methods[j].Name })
+	if len(builder.schemas) > 0 {
+		doc.Components = &OpenRPCComponents{Schemas: builder.schemas}
+	}
+	return doc
+}
+
+// schemaBuilder derives JSON schemas from Go types, collecting the schemas of
+// named structs into components to support recursive types.
+type schemaBuilder struct {
+	schemas map[string]*JSONSchema
+	names   map[reflect.Type]string
+}
+
+// method describes a callback.
+func (b *schemaBuilder) method(name string, cb *callback) *OpenRPCMethod {
+	method := &OpenRPCMethod{
+		Name:           name,
+		Params:         make([]*OpenRPCContentDescriptor, len(cb.argTypes)),
+		ParamStructure: "by-position",
+	}
+	for i, typ := range cb.argTypes {
+		method.Params[i] = &OpenRPCContentDescriptor{
+			Name:     fmt.Sprintf("arg%d", i),
+			Required: typ.Kind() != reflect.Ptr,
+			Schema:   b.schema(typ),
+		}
+	}
+	method.Result = &OpenRPCContentDescriptor{Name: "result", Schema: &JSONSchema{Type: "null"}}
+	if fntype := cb.fn.Type(); fntype.NumOut() > 0 && cb.errPos != 0 {
+		typ := fntype.Out(0)
+		method.Result.Required = typ.Kind() != reflect.Ptr
+		method.Result.Schema = b.schema(typ)
+	}
+	return method
+}
+
+// subscribeMethods describes the subscribe and unsubscribe methods of a service.
+// Subscription specific parameters are not described.
+func (b *schemaBuilder) subscribeMethods(svc service) []*OpenRPCMethod {
+	names := make([]string, 0, len(svc.subscriptions))
+	for name := range svc.subscriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	id := b.schema(reflect.TypeOf(ID("")))
+	return []*OpenRPCMethod{
+		{
+			Name:           svc.name + subscribeMethodSuffix,
+			Params:         []*OpenRPCContentDescriptor{{Name: "subscription", Required: true, Schema: &JSONSchema{Type: "string", Enum: names}}},
+			Result:         &OpenRPCContentDescriptor{Name: "id", Required: true, Schema: id},
+			ParamStructure: "by-position",
+		},
+		{
+			Name:           svc.name + unsubscribeMethodSuffix,
+			Params:         []*OpenRPCContentDescriptor{{Name: "id", Required: true, Schema: id}},
+			Result:         &OpenRPCContentDescriptor{Name: "result", Required: true, Schema: &JSONSchema{Type: "boolean"}},
+			ParamStructure: "by-position",
+		},
+	}
+}
+
+// schema returns the schema of the JSON encoding of a Go type.
+func (b *schemaBuilder) schema(typ reflect.Type) *JSONSchema {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if known, ok := knownSchemas[typ]; ok {
+		return &known
+	}
+	ptr := reflect.PtrTo(typ)
+	switch {
+	case ptr.Implements(jsonMarshalerType):
+		return &JSONSchema{}
+	case ptr.Implements(textMarshalerType):
+		return &JSONSchema{Type: "string"}
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && typ.Kind() == reflect.Slice {
+			return &JSONSchema{Type: "string"} // base64 encoded
+		}
+		return &JSONSchema{Type: "array", Items: b.schema(typ.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: b.schema(typ.Elem())}
+	case reflect.Struct:
+		return b.structSchema(typ)
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// structSchema returns a reference to the component describing a struct, or the
+// schema itself for anonymous structs.
+func (b *schemaBuilder) structSchema(typ reflect.Type) *JSONSchema {
+	if typ.Name() == "" {
+		return b.objectSchema(typ)
+	}
+	if name, ok := b.names[typ]; ok {
+		return &JSONSchema{Ref: schemaRefPrefix + name}
+	}
+	name := typ.Name()
+	if _, taken := b.schemas[name]; taken {
+		pkg := typ.PkgPath()
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	// Reserve the name before recursing into the fields
+	b.names[typ] = name
+	b.schemas[name] = nil
+	b.schemas[name] = b.objectSchema(typ)
+	return &JSONSchema{Ref: schemaRefPrefix + name}
+}
+
+// objectSchema describes the JSON object a struct is encoded into.
+func (b *schemaBuilder) objectSchema(typ reflect.Type) *JSONSchema {
+	schema := &JSONSchema{Type: "object", Title: typ.Name(), Properties: make(map[string]*JSONSchema)}
+	b.addFields(schema, typ)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// addFields adds the fields of a struct to an object schema, flattening embedded
+// structs the way encoding/json does.
+func (b *schemaBuilder) addFields(schema *JSONSchema, typ reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addFields(schema, embedded)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = b.schema(field.Type)
+		if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/simplechain-org/client/log"
+)
+
+// Kinds of recorded messages.
+const (
+	RecordRequest      = "request"
+	RecordResponse     = "response"
+	RecordNotification = "notification"
+)
+
+// Directions of recorded messages, seen from the server.
+const (
+	RecordIn  = "in"
+	RecordOut = "out"
+)
+
+// RecordEntry is a single message of a recording.
+type RecordEntry struct {
+	Time    time.Time       `json:"time"`
+	Conn    uint64          `json:"conn"`             // Connection the message was exchanged on
+	Remote  string          `json:"remote,omitempty"` // Address of the peer, empty for in-process connections
+	Dir     string          `json:"dir"`              // RecordIn or RecordOut
+	Kind    string          `json:"kind"`             // RecordRequest, RecordResponse or RecordNotification
+	Batch   bool            `json:"batch,omitempty"`  // Whether the message was part of a batch
+	Elapsed time.Duration   `json:"elapsed,omitempty"`
+	Message json.RawMessage `json:"msg"`
+}
+
+// Recorder writes the traffic of a server as an append-only stream of JSON lines,
+// one RecordEntry per message. Responses carry the time elapsed since their request
+// was received.
+type Recorder struct {
+	conns uint64 // connection counter, accessed atomically
+
+	lock   sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+	failed bool // whether a write failed already, to only log it once
+}
+
+// NewRecorder creates a recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// OpenRecorder creates a recorder appending to the file at path, creating it if
+// it doesn't exist.
+func OpenRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	rec := NewRecorder(f)
+	rec.closer = f
+	return rec, nil
+}
+
+// Close stops recording, closing the file opened by OpenRecorder.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.enc = nil
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+	return nil
+}
+
+// SetRecorder configures the recorder of the traffic of connections served
+// afterwards. A nil recorder disables recording. It must not be called
+// concurrently with serving requests.
+func (s *Server) SetRecorder(r *Recorder) {
+	s.recorder = r
+}
+
+// record writes an entry, logging the first failure.
+func (r *Recorder) record(entry *RecordEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.enc == nil {
+		return
+	}
+	if err := r.enc.Encode(entry); err != nil && !r.failed {
+		log.Warn("Failed to record RPC traffic", "err", err)
+		r.failed = true
+	}
+}
+
+// wrap returns a codec recording the traffic of the given one.
+func (r *Recorder) wrap(codec ServerCodec) ServerCodec {
+	return &recordingCodec{
+		ServerCodec: codec,
+		recorder:    r,
+		conn:        atomic.AddUint64(&r.conns, 1),
+		pending:     make(map[string]time.Time),
+	}
+}
+
+// recordingCodec is a ServerCodec recording the messages it reads and writes.
+type recordingCodec struct {
+	ServerCodec
+	recorder *Recorder
+	conn     uint64
+
+	lock    sync.Mutex
+	pending map[string]time.Time // receive times of unanswered requests
+}
+
+func (c *recordingCodec) readBatch() ([]*jsonrpcMessage, bool, error) {
+	msgs, batch, err := c.ServerCodec.readBatch()
+	if err != nil {
+		return msgs, batch, err
+	}
+	for _, msg := range msgs {
+		c.record(RecordIn, msg, batch)
+	}
+	return msgs, batch, nil
+}
+
+func (c *recordingCodec) writeJSON(ctx context.Context, v interface{}) error {
+	if err := c.ServerCodec.writeJSON(ctx, v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case *jsonrpcMessage:
+		c.record(RecordOut, v, false)
+	case []*jsonrpcMessage:
+		for _, msg := range v {
+			c.record(RecordOut, msg, true)
+		}
+	}
+	return nil
+}
+
+// permissions forwards the scopes of an authenticated connection.
+func (c *recordingCodec) permissions() *Permissions {
+	if pc, ok := c.ServerCodec.(permissionedCodec); ok {
+		return pc.permissions()
+	}
+	return nil
+}
+
+// record writes a message, matching responses with the requests they answer.
+func (c *recordingCodec) record(dir string, msg *jsonrpcMessage, batch bool) {
+	blob, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	entry := &RecordEntry{
+		Time:    time.Now(),
+		Conn:    c.conn,
+		Remote:  c.remoteAddr(),
+		Dir:     dir,
+		Batch:   batch,
+		Message: blob,
+	}
+	switch {
+	case msg.isCall():
+		entry.Kind = RecordRequest
+		if dir == RecordIn {
+			c.lock.Lock()
+			c.pending[string(msg.ID)] = entry.Time
+			c.lock.Unlock()
+		}
+	case msg.isNotification():
+		entry.Kind = RecordNotification
+	default:
+		entry.Kind = RecordResponse
+		if dir == RecordOut {
+			c.lock.Lock()
+			if start, ok := c.pending[string(msg.ID)]; ok {
+				entry.Elapsed = entry.Time.Sub(start)
+				delete(c.pending, string(msg.ID))
+			}
+			c.lock.Unlock()
+		}
+	}
+	c.recorder.record(entry)
+}
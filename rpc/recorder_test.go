@@ -0,0 +1,181 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// changedEchoService is a regressed variant of testService.Echo.
+type changedEchoService struct{}
+
+func (s *changedEchoService) Echo(str string, i int, args *echoArgs) echoResult {
+	return echoResult{str, i + 1, args}
+}
+
+// recordTraffic serves some calls and a subscription with recording enabled.
+func recordTraffic(t *testing.T) []*RecordEntry {
+	t.Helper()
+
+	var (
+		buf bytes.Buffer
+		rec = NewRecorder(&buf)
+	)
+	server := newTestServer()
+	server.SetRecorder(rec)
+	defer server.Stop()
+	client := DialInProc(server)
+
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "x", 1, &echoArgs{"y"}); err != nil {
+		t.Fatal(err)
+	}
+	client.Call(nil, "test_returnError")
+	batch := []BatchElem{
+		{Method: "test_echo", Args: []interface{}{"a", 2, nil}, Result: new(echoResult)},
+		{Method: "test_rets", Result: new(string)},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "nftest", ch, "someSubscription", 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	<-ch
+	sub.Unsubscribe()
+	client.Close()
+
+	// Wait for the trailing messages to be recorded
+	time.Sleep(100 * time.Millisecond)
+	rec.Close()
+	entries, err := ReadRecording(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestRecorder(t *testing.T) {
+	entries := recordTraffic(t)
+
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		counts[entry.Dir+" "+entry.Kind]++
+		if entry.Dir == RecordIn && entry.Kind == RecordRequest {
+			batched := bytes.Contains(entry.Message, []byte(`["a"`)) || bytes.Contains(entry.Message, []byte("test_rets"))
+			if entry.Batch != batched {
+				t.Errorf("batch flag mismatch for %s", entry.Message)
+			}
+		}
+		if entry.Dir == RecordOut && entry.Kind == RecordResponse && entry.Elapsed <= 0 {
+			t.Errorf("response without timing: %s", entry.Message)
+		}
+	}
+	// echo, returnError, 2 batched, subscribe, unsubscribe
+	if have := counts[RecordIn+" "+RecordRequest]; have != 6 {
+		t.Errorf("request count mismatch: have %d, want 6", have)
+	}
+	if have := counts[RecordOut+" "+RecordResponse]; have != 6 {
+		t.Errorf("response count mismatch: have %d, want 6", have)
+	}
+	if have := counts[RecordOut+" "+RecordNotification]; have != 2 {
+		t.Errorf("notification count mismatch: have %d, want 2", have)
+	}
+}
+
+func TestRecorderFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rpc.jsonl")
+	for i := 0; i < 2; i++ {
+		rec, err := OpenRecorder(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := newTestServer()
+		server.SetRecorder(rec)
+		httpsrv := httptest.NewServer(server)
+		client, _ := DialHTTP(httpsrv.URL)
+		if err := client.Call(nil, "test_noArgsRets"); err != nil {
+			t.Fatal(err)
+		}
+		client.Close()
+		httpsrv.Close()
+		server.Stop()
+		rec.Close()
+	}
+	// Recordings are appended to the existing file
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	entries, err := ReadRecording(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("entry count mismatch: have %d, want 4", len(entries))
+	}
+	if entries[0].Remote == "" || entries[0].Remote != entries[1].Remote {
+		t.Errorf("remote address mismatch: %q %q", entries[0].Remote, entries[1].Remote)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	entries := recordTraffic(t)
+
+	// Replaying against the same services matches
+	server := newTestServer()
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	result, err := Replay(context.Background(), client, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Calls != 4 || result.Skipped != 2 || len(result.Mismatches) != 0 {
+		t.Fatalf("replay result mismatch: calls %d, skipped %d, mismatches %d", result.Calls, result.Skipped, len(result.Mismatches))
+	}
+	// Regressions are reported
+	regressed := newTestServer()
+	regressed.RegisterName("test", new(changedEchoService))
+	defer regressed.Stop()
+	client = DialInProc(regressed)
+	defer client.Close()
+
+	result, err = Replay(context.Background(), client, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Mismatches) != 2 {
+		t.Fatalf("mismatch count mismatch: have %d, want 2", len(result.Mismatches))
+	}
+	for _, mismatch := range result.Mismatches {
+		if mismatch.Method != "test_echo" {
+			t.Errorf("unexpected mismatch of %s: recorded %s, replayed %s", mismatch.Method, mismatch.Recorded, mismatch.Replayed)
+		}
+	}
+}
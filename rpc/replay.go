@@ -0,0 +1,174 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ReadRecording parses the entries of a recording written by a Recorder.
+func ReadRecording(r io.Reader) ([]*RecordEntry, error) {
+	var (
+		entries []*RecordEntry
+		scanner = bufio.NewScanner(r)
+		line    int
+	)
+	scanner.Buffer(nil, wsMessageSizeLimit)
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := new(RecordEntry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// ReplayMismatch is a replayed call whose response differs from the recorded one.
+type ReplayMismatch struct {
+	Conn     uint64          // Connection of the recorded call
+	Method   string          // Method of the call
+	Params   json.RawMessage // Parameters of the call
+	Recorded json.RawMessage // Recorded response, without ID
+	Replayed json.RawMessage // Replayed response, without ID
+}
+
+// ReplayResult summarizes a replay.
+type ReplayResult struct {
+	Calls      int // Number of replayed calls
+	Skipped    int // Number of recorded calls which could not be replayed
+	Mismatches []*ReplayMismatch
+}
+
+// Replay issues the calls of a recording through the client, in recorded order,
+// and compares their responses with the recorded ones. To reproduce the behavior
+// of the services registered in a Server, pass a client created by DialInProc.
+//
+// Subscriptions and calls without a recorded response are skipped. Errors are
+// only returned for malformed recordings and failures to reach the server.
+func Replay(ctx context.Context, client *Client, recording []*RecordEntry) (*ReplayResult, error) {
+	// Index the recorded responses by connection and ID.
+	type callKey struct {
+		conn uint64
+		id   string
+	}
+	responses := make(map[callKey]*jsonrpcMessage)
+	for _, entry := range recording {
+		if entry.Dir != RecordOut || entry.Kind != RecordResponse {
+			continue
+		}
+		msg := new(jsonrpcMessage)
+		if err := json.Unmarshal(entry.Message, msg); err != nil {
+			return nil, fmt.Errorf("invalid recorded response: %v", err)
+		}
+		responses[callKey{entry.Conn, string(msg.ID)}] = msg
+	}
+	result := new(ReplayResult)
+	for _, entry := range recording {
+		if entry.Dir != RecordIn || entry.Kind != RecordRequest {
+			continue
+		}
+		call := new(jsonrpcMessage)
+		if err := json.Unmarshal(entry.Message, call); err != nil {
+			return nil, fmt.Errorf("invalid recorded request: %v", err)
+		}
+		recorded, ok := responses[callKey{entry.Conn, string(call.ID)}]
+		if !ok || call.isSubscribe() || call.isUnsubscribe() {
+			result.Skipped++
+			continue
+		}
+		var args []interface{}
+		if len(call.Params) > 0 && string(call.Params) != "null" {
+			var params []json.RawMessage
+			if err := json.Unmarshal(call.Params, &params); err != nil {
+				result.Skipped++
+				continue
+			}
+			for _, param := range params {
+				args = append(args, param)
+			}
+		}
+		replayed := new(jsonrpcMessage)
+		err := client.CallContext(ctx, &replayed.Result, call.Method, args...)
+		if jerr, ok := err.(*jsonError); ok {
+			replayed.Error = jerr
+		} else if err != nil && err != ErrNoResult {
+			return result, err
+		}
+		result.Calls++
+
+		if !sameResponse(recorded, replayed) {
+			result.Mismatches = append(result.Mismatches, &ReplayMismatch{
+				Conn:     entry.Conn,
+				Method:   call.Method,
+				Params:   call.Params,
+				Recorded: responseJSON(recorded),
+				Replayed: responseJSON(replayed),
+			})
+		}
+	}
+	return result, nil
+}
+
+// sameResponse reports whether two responses carry equal results or errors,
+// regardless of their encoding.
+func sameResponse(a, b *jsonrpcMessage) bool {
+	if (a.Error == nil) != (b.Error == nil) {
+		return false
+	}
+	if a.Error != nil {
+		return a.Error.Code == b.Error.Code && a.Error.Message == b.Error.Message &&
+			sameJSON(a.Error.Data, b.Error.Data)
+	}
+	return sameJSON(a.Result, b.Result)
+}
+
+// sameJSON reports whether two values have equal JSON encodings, ignoring
+// formatting and key order.
+func sameJSON(a, b interface{}) bool {
+	var va, vb interface{}
+	if !normalizeJSON(a, &va) || !normalizeJSON(b, &vb) {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func normalizeJSON(v interface{}, out *interface{}) bool {
+	if raw, ok := v.(json.RawMessage); ok && len(raw) == 0 {
+		return true // missing result
+	}
+	blob, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(blob, out) == nil
+}
+
+// responseJSON encodes the result or error of a response.
+func responseJSON(msg *jsonrpcMessage) json.RawMessage {
+	blob, _ := json.Marshal(&jsonrpcMessage{Error: msg.Error, Result: msg.Result})
+	return blob
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package rpcgen generates typed Go clients from OpenRPC documents, such as the
+// ones served by rpc_discover.
+package rpcgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/simplechain-org/client/rpc"
+)
+
+// reservedNames are the identifiers used by the generated method bodies, which
+// parameters must not shadow.
+var reservedNames = map[string]bool{"c": true, "ctx": true, "result": true, "err": true}
+
+// Generate creates the source of a typed client for the methods of an OpenRPC
+// document. If namespaces are given, only their methods are included.
+//
+// Subscriptions are not part of the generated client, they are available through
+// the underlying rpc.Client.
+func Generate(doc *rpc.OpenRPCDocument, pkg, typ string, namespaces []string) (string, error) {
+	if typ == "" {
+		typ = "Client"
+	}
+	gen := &generator{imports: map[string]bool{"context": true, "github.com/simplechain-org/client/rpc": true}}
+	data := &tmplData{
+		Package: pkg,
+		Type:    typ,
+		Title:   doc.Info.Title,
+	}
+	included := make(map[string]bool)
+	for _, ns := range namespaces {
+		included[ns] = true
+	}
+	for _, method := range doc.Methods {
+		if strings.HasSuffix(method.Name, "_subscribe") || strings.HasSuffix(method.Name, "_unsubscribe") {
+			continue
+		}
+		if len(included) > 0 && !included[strings.SplitN(method.Name, "_", 2)[0]] {
+			continue
+		}
+		m, err := gen.method(method)
+		if err != nil {
+			return "", err
+		}
+		data.Methods = append(data.Methods, m)
+	}
+	if doc.Components != nil {
+		names := make([]string, 0, len(doc.Components.Schemas))
+		for name := range doc.Components.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			s, err := gen.structType(name, doc.Components.Schemas[name])
+			if err != nil {
+				return "", err
+			}
+			data.Structs = append(data.Structs, s)
+		}
+	}
+	for path := range gen.imports {
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			data.Imports = append(data.Imports, path)
+		} else {
+			data.StdImports = append(data.StdImports, path)
+		}
+	}
+	sort.Strings(data.StdImports)
+	sort.Strings(data.Imports)
+
+	buffer := new(bytes.Buffer)
+	tmpl := template.Must(template.New("").Parse(tmplSource))
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, buffer)
+	}
+	return string(code), nil
+}
+
+// generator maps schemas onto Go types, tracking the packages they need.
+type generator struct {
+	imports map[string]bool
+}
+
+// method converts an OpenRPC method into its client method.
+func (g *generator) method(method *rpc.OpenRPCMethod) (*tmplMethod, error) {
+	m := &tmplMethod{Original: method.Name, Name: identifier(method.Name, true)}
+	used := make(map[string]bool)
+	for i, param := range method.Params {
+		if param.Schema == nil {
+			return nil, fmt.Errorf("method %s: parameter %d has no schema", method.Name, i)
+		}
+		name := identifier(param.Name, false)
+		if name == "" || used[name] {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		if reservedNames[name] || token.IsKeyword(name) {
+			name += "Arg"
+		}
+		used[name] = true
+		m.Params = append(m.Params, &tmplParam{Name: name, Type: g.goType(param.Schema, param.Required)})
+	}
+	if method.Result != nil && method.Result.Schema != nil && method.Result.Schema.Type != "null" {
+		m.Result = g.goType(method.Result.Schema, method.Result.Required)
+	}
+	return m, nil
+}
+
+// structType converts an object schema component into a struct type.
+func (g *generator) structType(name string, schema *rpc.JSONSchema) (*tmplStruct, error) {
+	if schema == nil || schema.Type != "object" {
+		return nil, fmt.Errorf("component %s is not an object", name)
+	}
+	s := &tmplStruct{Name: identifier(name, true)}
+
+	required := make(map[string]bool)
+	for _, field := range schema.Required {
+		required[field] = true
+	}
+	fields := make([]string, 0, len(schema.Properties))
+	for field := range schema.Properties {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		var (
+			prop = schema.Properties[field]
+			typ  = g.goType(prop, required[field])
+			tag  = field
+		)
+		if !required[field] {
+			tag += ",omitempty"
+			if isPrimitive(prop) {
+				typ, _ = g.baseType(prop) // omitted zero values decode fine
+			}
+		}
+		s.Fields = append(s.Fields, &tmplField{Name: identifier(field, true), Type: typ, Tag: tag})
+	}
+	return s, nil
+}
+
+// goType returns the Go type a schema decodes into. Optional values are pointers
+// unless the type is nillable already.
+func (g *generator) goType(schema *rpc.JSONSchema, required bool) string {
+	typ, nillable := g.baseType(schema)
+	if !required && !nillable {
+		return "*" + typ
+	}
+	return typ
+}
+
+// baseType returns the Go type of a schema, and whether it can hold nil.
+func (g *generator) baseType(schema *rpc.JSONSchema) (string, bool) {
+	if schema.Ref != "" {
+		return identifier(schema.RefName(), true), false
+	}
+	switch schema.Title {
+	case rpc.SchemaAddress:
+		return g.use("github.com/simplechain-org/client/common", "common.Address"), false
+	case rpc.SchemaHash:
+		return g.use("github.com/simplechain-org/client/common", "common.Hash"), false
+	case rpc.SchemaBytes:
+		return g.use("github.com/simplechain-org/client/common/hexutil", "hexutil.Bytes"), true
+	case rpc.SchemaBig:
+		return g.use("github.com/simplechain-org/client/common/hexutil", "hexutil.Big"), false
+	case rpc.SchemaUint64:
+		return g.use("github.com/simplechain-org/client/common/hexutil", "hexutil.Uint64"), false
+	case rpc.SchemaUint:
+		return g.use("github.com/simplechain-org/client/common/hexutil", "hexutil.Uint"), false
+	case rpc.SchemaBigInt:
+		return g.use("math/big", "big.Int"), false
+	case rpc.SchemaBlockNumber:
+		return "rpc.BlockNumber", false
+	case rpc.SchemaBlockNumberOrHash:
+		return "rpc.BlockNumberOrHash", false
+	case rpc.SchemaSubscriptionID:
+		return "rpc.ID", false
+	}
+	switch schema.Type {
+	case "boolean":
+		return "bool", false
+	case "integer":
+		return "int64", false
+	case "number":
+		return "float64", false
+	case "string":
+		return "string", false
+	case "array":
+		if schema.Items == nil {
+			return g.use("encoding/json", "[]json.RawMessage"), true
+		}
+		elem, _ := g.baseType(schema.Items)
+		return "[]" + elem, true
+	case "object":
+		if schema.AdditionalProperties != nil && len(schema.Properties) == 0 {
+			elem, _ := g.baseType(schema.AdditionalProperties)
+			return "map[string]" + elem, true
+		}
+	}
+	return g.use("encoding/json", "json.RawMessage"), true
+}
+
+// isPrimitive reports whether a schema describes a plain JSON boolean, number or
+// string.
+func isPrimitive(schema *rpc.JSONSchema) bool {
+	if schema.Ref != "" || schema.Title != "" {
+		return false
+	}
+	switch schema.Type {
+	case "boolean", "integer", "number", "string":
+		return true
+	}
+	return false
+}
+
+// use records the import of a package and returns the given type.
+func (g *generator) use(path, typ string) string {
+	g.imports[path] = true
+	return typ
+}
+
+// identifier converts a JSON name into a Go identifier, splitting it into words on
+// any character not allowed in identifiers.
+func identifier(name string, exported bool) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var id strings.Builder
+	for i, word := range words {
+		runes := []rune(word)
+		if i == 0 && !exported {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		id.WriteString(string(runes))
+	}
+	result := id.String()
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
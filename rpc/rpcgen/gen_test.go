@@ -0,0 +1,97 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpcgen
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"testing"
+
+	"github.com/simplechain-org/client/common"
+	"github.com/simplechain-org/client/common/hexutil"
+	"github.com/simplechain-org/client/rpc"
+)
+
+type testBlock struct {
+	Number *hexutil.Big   `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Extra  hexutil.Bytes  `json:"extraData"`
+	Parent *testBlock     `json:"parent,omitempty"`
+	Nonce  hexutil.Uint64 `json:"nonce"`
+}
+
+type testService struct{}
+
+func (s *testService) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*testBlock, error) {
+	return nil, nil
+}
+
+func (s *testService) Balance(account common.Address, block *rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	return nil, nil
+}
+
+func (s *testService) Ping() error { return nil }
+
+func (s *testService) Labels() map[string][]string { return nil }
+
+func (s *testService) NewBlocks(ctx context.Context) (*rpc.Subscription, error) { return nil, nil }
+
+func discover(t *testing.T) *rpc.OpenRPCDocument {
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("test", new(testService)); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	doc := new(rpc.OpenRPCDocument)
+	if err := client.Call(doc, "rpc_discover"); err != nil {
+		t.Fatalf("failed to discover: %v", err)
+	}
+	return doc
+}
+
+func TestGenerate(t *testing.T) {
+	code, err := Generate(discover(t), "testclient", "", []string{"test"})
+	if err != nil {
+		t.Fatalf("failed to generate client: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "", code, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, code)
+	}
+	for _, want := range []string{
+		`func \(c \*Client\) TestBlockByNumber\(ctx context.Context, arg0 rpc.BlockNumber\) \(\*TestBlock, error\)`,
+		`func \(c \*Client\) TestBalance\(ctx context.Context, arg0 common.Address, arg1 \*rpc.BlockNumberOrHash\) \(\*hexutil.Big, error\)`,
+		`func \(c \*Client\) TestPing\(ctx context.Context\) error`,
+		`func \(c \*Client\) TestLabels\(ctx context.Context\) \(map\[string\]\[\]string, error\)`,
+		`Number\s+\*hexutil.Big\s+` + "`json:\"number,omitempty\"`",
+		`Parent\s+\*TestBlock\s+` + "`json:\"parent,omitempty\"`",
+		`ExtraData\s+hexutil.Bytes\s+` + "`json:\"extraData\"`",
+	} {
+		if !regexp.MustCompile(want).MatchString(code) {
+			t.Errorf("generated code missing %s\n%s", want, code)
+		}
+	}
+	for _, unwanted := range []string{`RpcModules`, `TestSubscribe`, `TestNewBlocks`} {
+		if regexp.MustCompile(unwanted).MatchString(code) {
+			t.Errorf("generated code contains excluded method %s", unwanted)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpcgen
+
+// tmplData is the data structure required to fill the client template.
+type tmplData struct {
+	Package    string        // Name of the package to place the generated file in
+	Type       string        // Type name of the generated client
+	Title      string        // Title of the API the client is generated for
+	StdImports []string      // Standard library packages imported by the generated code
+	Imports    []string      // Other packages imported by the generated code
+	Structs    []*tmplStruct // Struct types referenced by the methods
+	Methods    []*tmplMethod // Methods of the API
+}
+
+// tmplStruct is a struct type generated from a schema component.
+type tmplStruct struct {
+	Name   string       // Go name of the struct
+	Fields []*tmplField // Fields of the struct, sorted by JSON name
+}
+
+// tmplField is a single field of a generated struct.
+type tmplField struct {
+	Name string // Go name of the field
+	Type string // Go type of the field
+	Tag  string // JSON tag of the field
+}
+
+// tmplMethod is a single method of the generated client.
+type tmplMethod struct {
+	Original string       // JSON-RPC method name
+	Name     string       // Go name of the method
+	Params   []*tmplParam // Positional parameters of the method
+	Result   string       // Go type of the result, empty if there is none
+}
+
+// tmplParam is a single parameter of a generated method.
+type tmplParam struct {
+	Name string // Go name of the parameter
+	Type string // Go type of the parameter
+}
+
+// tmplSource is the Go source template that the generated client is based on.
+const tmplSource = `// Code generated - DO NOT EDIT.
+// This file is a generated JSON-RPC client and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+{{range .StdImports}}
+	"{{.}}"{{end}}
+{{range .Imports}}
+	"{{.}}"{{end}}
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = context.Background
+
+{{range .Structs}}
+// {{.Name}} is an auto generated type of the {{$.Title}}.
+type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}} ` + "`json:\"{{.Tag}}\"`" + `
+{{end}}}
+{{end}}
+
+// {{.Type}} is a typed client of the {{.Title}}.
+type {{.Type}} struct {
+	client *rpc.Client
+}
+
+// New{{.Type}} creates a typed client on top of an RPC client.
+func New{{.Type}}(client *rpc.Client) *{{.Type}} {
+	return &{{.Type}}{client: client}
+}
+
+{{range .Methods}}
+// {{.Name}} invokes {{.Original}}.
+func (c *{{$.Type}}) {{.Name}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) {{if .Result}}({{.Result}}, error){{else}}error{{end}} {
+{{- if .Result}}
+	var result {{.Result}}
+	err := c.client.CallContext(ctx, &result, "{{.Original}}"{{range .Params}}, {{.Name}}{{end}})
+	return result, err
+{{- else}}
+	return c.client.CallContext(ctx, nil, "{{.Original}}"{{range .Params}}, {{.Name}}{{end}})
+{{- end}}
+}
+{{end}}
+`
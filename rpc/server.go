@@ -46,11 +46,15 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+	config   handlerConfig
+
+	httpCompression int       // minimum size of compressed HTTP responses, 0 = disabled
+	recorder        *Recorder // optional, records the served traffic
 }
 
 // NewServer creates a new server instance with no registered handlers.
 func NewServer() *Server {
-	server := &Server{idgen: randomIDGenerator(), codecs: mapset.NewSet(), run: 1}
+	server := &Server{idgen: randomIDGenerator(), codecs: mapset.NewSet(), run: 1, httpCompression: defaultCompressionThreshold}
 	// Register the default service providing meta information about the RPC service such
 	// as the services and methods it offers.
 	rpcService := &RPCService{server}
@@ -66,6 +70,20 @@ func (s *Server) RegisterName(name string, receiver interface{}) error {
 	return s.services.registerName(name, receiver)
 }
 
+// SetRateLimits configures the per-client limits of requests served afterwards.
+// It must not be called concurrently with serving requests.
+func (s *Server) SetRateLimits(limits RateLimits) {
+	s.config.limiter = newRateLimiter(limits)
+}
+
+// SetHTTPCompression configures the minimum size of HTTP responses which are gzip
+// or deflate compressed for clients accepting it. A zero threshold disables
+// response compression, compressed requests are accepted regardless. It must not
+// be called concurrently with serving requests.
+func (s *Server) SetHTTPCompression(threshold int) {
+	s.httpCompression = threshold
+}
+
 // ServeCodec reads incoming requests from codec, calls the appropriate callback and writes
 // the response back using the given codec. It will block until the codec is closed or the
 // server is stopped. In either case the codec is closed.
@@ -79,11 +97,14 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		return
 	}
 
+	if s.recorder != nil {
+		codec = s.recorder.wrap(codec)
+	}
 	// Add the codec to the set so it can be closed by Stop.
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(codec, s.idgen, &s.services, &s.config)
 	<-codec.closed()
 	c.Close()
 }
@@ -97,8 +118,12 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 		return
 	}
 
+	if s.recorder != nil {
+		codec = s.recorder.wrap(codec)
+	}
 	h := newHandler(ctx, codec, s.idgen, &s.services)
 	h.allowSubscribe = false
+	h.configure(&s.config)
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -145,3 +170,8 @@ func (s *RPCService) Modules() map[string]string {
 	}
 	return modules
 }
+
+// Discover returns the OpenRPC document describing all methods of the server.
+func (s *RPCService) Discover() *OpenRPCDocument {
+	return s.server.services.openRPC()
+}
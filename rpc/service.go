@@ -103,6 +103,24 @@ func (r *serviceRegistry) callback(method string) *callback {
 	return r.services[elem[0]].callbacks[elem[1]]
 }
 
+// served reports whether the given RPC method name is served by a registered
+// service, either as a callback or as the (un)subscribe method of a service
+// with subscriptions.
+func (r *serviceRegistry) served(method string) bool {
+	elem := strings.SplitN(method, serviceMethodSeparator, 2)
+	if len(elem) != 2 {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	svc := r.services[elem[0]]
+	if method == elem[0]+subscribeMethodSuffix || method == elem[0]+unsubscribeMethodSuffix {
+		return len(svc.subscriptions) > 0
+	}
+	return svc.callbacks[elem[1]] != nil
+}
+
 // subscription returns a subscription callback in the given service.
 func (r *serviceRegistry) subscription(service, name string) *callback {
 	r.mu.Lock()
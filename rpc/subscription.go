@@ -175,11 +175,15 @@ func (n *Notifier) activate() error {
 func (n *Notifier) send(sub *Subscription, data json.RawMessage) error {
 	params, _ := json.Marshal(&subscriptionResult{ID: string(sub.ID), Result: data})
 	ctx := context.Background()
-	return n.h.conn.writeJSON(ctx, &jsonrpcMessage{
+	err := n.h.conn.writeJSON(ctx, &jsonrpcMessage{
 		Version: vsn,
 		Method:  n.namespace + notificationMethodSuffix,
 		Params:  params,
 	})
+	if err == nil {
+		n.h.subscriptionEvent(SubscriptionNotified, sub, len(data))
+	}
+	return err
 }
 
 // A Subscription is created by a notifier and tied to that notifier. The client can use